@@ -27,6 +27,7 @@ export interface DemandeEquipier {
   Demande: Demande;
   Optionnelle: boolean;
   Files: PublicFile[] | null;
+  Manquant: boolean;
 }
 // registro/controllers/equipier.EquipierExt
 export interface EquipierExt {
@@ -41,6 +42,13 @@ export interface Photos {
   HasAlbum: boolean;
   URL: string;
 }
+// registro/controllers/equipier.UpdateCharteIn
+export interface UpdateCharteIn {
+  Token: string;
+  Accept: boolean;
+  Version: VersionTexte;
+  Signature: SignatureIn;
+}
 // registro/controllers/equipier.UpdateIn
 export interface UpdateIn {
   Token: string;
@@ -48,6 +56,13 @@ export interface UpdateIn {
   PersonneDetails: Ficheequipier;
   Presence: PresenceOffsets;
 }
+// registro/controllers/files.SignatureIn
+export interface SignatureIn {
+  Signataire: string;
+  Consentement: boolean;
+}
+// registro/controllers/files.VersionTexte
+export type VersionTexte = string;
 // registro/logic.PublicFile
 export interface PublicFile {
   Key: string;
@@ -398,17 +413,11 @@ export abstract class AbstractAPI {
   }
 
   /** UpdateCharte performs the request and handles the error */
-  async UpdateCharte(params: { token: string; accept: boolean }) {
+  async UpdateCharte(params: UpdateCharteIn) {
     const fullUrl = this.baseURL + "/api/v1/equipier/charte";
     this.startRequest();
     try {
-      await Axios.post(fullUrl, null, {
-        headers: this.getHeaders(),
-        params: {
-          token: params["token"],
-          accept: params["accept"] ? "ok" : "",
-        },
-      });
+      await Axios.post(fullUrl, params, { headers: this.getHeaders() });
       return true;
     } catch (error) {
       this.handleError(error);
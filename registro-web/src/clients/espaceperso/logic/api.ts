@@ -97,6 +97,13 @@ export interface SendMessageIn {
   Message: string;
   OnlyToFondSoutien: boolean;
 }
+// registro/controllers/espaceperso.SigneTexteIn
+export interface SigneTexteIn {
+  Token: string;
+  IdPersonne: IdPersonne;
+  Version: VersionTexte;
+  Signature: SignatureIn;
+}
 // registro/controllers/espaceperso.SondageExt
 export interface SondageExt {
   Camp: string;
@@ -124,6 +131,13 @@ export interface GeneratedFile {
   NomClient: string;
   Key: string;
 }
+// registro/controllers/files.SignatureIn
+export interface SignatureIn {
+  Signataire: string;
+  Consentement: boolean;
+}
+// registro/controllers/files.VersionTexte
+export type VersionTexte = string;
 // registro/logic.AideResolved
 export interface AideResolved {
   Structure: string;
@@ -970,13 +984,13 @@ export abstract class AbstractAPI {
   }
 
   /** DeleteDocument performs the request and handles the error */
-  async DeleteDocument(params: { key: string }) {
+  async DeleteDocument(params: { token: string; key: string }) {
     const fullUrl = this.baseURL + "/api/v1/espaceperso/documents";
     this.startRequest();
     try {
       await Axios.delete(fullUrl, {
         headers: this.getHeaders(),
-        params: { key: params["key"] },
+        params: { token: params["token"], key: params["key"] },
       });
       return true;
     } catch (error) {
@@ -985,17 +999,11 @@ export abstract class AbstractAPI {
   }
 
   /** AccepteCharte performs the request and handles the error */
-  async AccepteCharte(params: { token: string; idPersonne: IdPersonne }) {
+  async AccepteCharte(params: SigneTexteIn) {
     const fullUrl = this.baseURL + "/api/v1/espaceperso/documents/charte";
     this.startRequest();
     try {
-      await Axios.post(fullUrl, null, {
-        headers: this.getHeaders(),
-        params: {
-          token: params["token"],
-          idPersonne: String(params["idPersonne"]),
-        },
-      });
+      await Axios.post(fullUrl, params, { headers: this.getHeaders() });
       return true;
     } catch (error) {
       this.handleError(error);
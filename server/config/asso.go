@@ -30,6 +30,10 @@ type Asso struct {
 
 	RemisesHints RemisesHints
 
+	Retention Retention
+
+	Encadrement Encadrement
+
 	ConfigInscription
 }
 
@@ -85,6 +89,10 @@ var acve = Asso{
 		AutresInscrits: 15,
 	},
 
+	Retention: defaultRetention,
+
+	Encadrement: defaultEncadrement,
+
 	ConfigInscription: ConfigInscription{
 		SupportBonsCAF: true, SupportANCV: true,
 		SupportPaiementEnLigne:    true,
@@ -130,6 +138,10 @@ var repere = Asso{
 		AutresInscrits: 10,
 	},
 
+	Retention: defaultRetention,
+
+	Encadrement: defaultEncadrement,
+
 	ConfigInscription: ConfigInscription{
 		SupportBonsCAF: false, SupportANCV: false,
 		SupportPaiementEnLigne:    false,
@@ -154,6 +166,54 @@ type RemisesHints struct {
 	AutresInscrits int // in %
 }
 
+// Retention définit les durées de conservation (en mois) des données sensibles,
+// décomptées à partir de la fin du dernier séjour de la personne.
+// Une durée nulle désactive la suppression automatique.
+type Retention struct {
+	Fichesanitaire       int // fiches sanitaires
+	DocumentsParticipant int // vaccins et documents demandés aux participants
+	DocumentsEquipier    int // diplômes, pièces d'identité, ...
+	SecuriteSociale      int // numéro de sécurité sociale de la fiche équipier
+}
+
+var defaultRetention = Retention{
+	Fichesanitaire:       12,
+	DocumentsParticipant: 12,
+	DocumentsEquipier:    36,
+	SecuriteSociale:      36,
+}
+
+// Encadrement définit les règles d'encadrement (réglementation des ACM)
+// vérifiées par le planning des équipiers et le rapport de conformité.
+type Encadrement struct {
+	// Tranches est triée par âge croissant ; les participants plus âgés
+	// que la dernière tranche sont comptés dans celle-ci.
+	Tranches []TrancheEncadrement
+
+	DiplomesMin          int  // pourcentage minimal d'animateurs diplômés (BAFA ou équivalent)
+	SansQualificationMax int  // pourcentage maximal d'animateurs sans qualification (ni diplômés, ni stagiaires)
+	DirecteurBAFD        bool // si true, le directeur doit être titulaire ou stagiaire BAFD
+	SurveillantBaignade  int  // nombre minimal de surveillants de baignade
+}
+
+// TrancheEncadrement impose un animateur pour [Participants]
+// participants d'au plus [AgeMax] ans.
+type TrancheEncadrement struct {
+	AgeMax       int // inclusif
+	Participants int
+}
+
+var defaultEncadrement = Encadrement{
+	Tranches: []TrancheEncadrement{
+		{AgeMax: 5, Participants: 8},
+		{AgeMax: 17, Participants: 12},
+	},
+	DiplomesMin:          50,
+	SansQualificationMax: 20,
+	DirecteurBAFD:        true,
+	SurveillantBaignade:  1,
+}
+
 type MailsSettings struct {
 	AssoName            string // used in adress and as object prefix
 	Unsubscribe         string // used in 'List-Unsubscribe' header
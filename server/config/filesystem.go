@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 type Directories struct {
@@ -60,3 +63,75 @@ func NewDirectories() (Directories, error) {
 // containing editable form; or empty to disable
 // recu fiscal edition.
 func NewModeleRecuFiscal() string { return os.Getenv("MODELE_RECU_FISCAL") }
+
+// S3 configure un stockage des fichiers compatible S3, utilisé
+// à la place de FILES_DIR s'il est défini.
+type S3 struct {
+	Endpoint  string // URL avec schéma, par exemple https://s3.fr-par.scw.cloud
+	Region    string // optionnel, us-east-1 par défaut
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// NewS3 uses env variables FILES_S3_ENDPOINT, FILES_S3_REGION,
+// FILES_S3_BUCKET, FILES_S3_ACCESS_KEY and FILES_S3_SECRET_KEY.
+// An empty FILES_S3_ENDPOINT disables S3 storage (and returns the zero value).
+func NewS3() (S3, error) {
+	out := S3{
+		Endpoint:  os.Getenv("FILES_S3_ENDPOINT"),
+		Region:    os.Getenv("FILES_S3_REGION"),
+		Bucket:    os.Getenv("FILES_S3_BUCKET"),
+		AccessKey: os.Getenv("FILES_S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("FILES_S3_SECRET_KEY"),
+	}
+	if out.Endpoint == "" {
+		return S3{}, nil
+	}
+	if out.Bucket == "" {
+		return S3{}, fmt.Errorf("missing env FILES_S3_BUCKET")
+	}
+	if out.AccessKey == "" || out.SecretKey == "" {
+		return S3{}, fmt.Errorf("missing env FILES_S3_ACCESS_KEY or FILES_S3_SECRET_KEY")
+	}
+	return out, nil
+}
+
+// FilesEncryption configure le chiffrement du contenu des fichiers.
+type FilesEncryption struct {
+	MasterKey    string   // vide pour désactiver le chiffrement
+	PreviousKeys []string // anciennes clés, encore utilisées pour la lecture
+}
+
+// NewFilesEncryption uses env variables FILES_MASTER_KEY and
+// FILES_PREVIOUS_KEYS (comma separated, optional).
+// An empty FILES_MASTER_KEY disables encryption.
+func NewFilesEncryption() (FilesEncryption, error) {
+	out := FilesEncryption{MasterKey: os.Getenv("FILES_MASTER_KEY")}
+	if previous := os.Getenv("FILES_PREVIOUS_KEYS"); previous != "" {
+		out.PreviousKeys = strings.Split(previous, ",")
+	}
+	if out.MasterKey == "" && len(out.PreviousKeys) != 0 {
+		return FilesEncryption{}, errors.New("missing env FILES_MASTER_KEY")
+	}
+	return out, nil
+}
+
+// NewClamdSocket returns env FILES_CLAMD_SOCKET : the path of the
+// local clamd Unix socket used to scan uploaded files, or an empty
+// string to disable scanning.
+func NewClamdSocket() string { return os.Getenv("FILES_CLAMD_SOCKET") }
+
+// NewMaxDocumentSize returns env FILES_MAX_DOCUMENT_SIZE (in kB) :
+// the maximum size of documents converted to PDF, or 0 for the default value.
+func NewMaxDocumentSize() (int, error) {
+	size := os.Getenv("FILES_MAX_DOCUMENT_SIZE")
+	if size == "" {
+		return 0, nil
+	}
+	kb, err := strconv.Atoi(size)
+	if err != nil || kb <= 0 {
+		return 0, fmt.Errorf("invalid env FILES_MAX_DOCUMENT_SIZE: %s", size)
+	}
+	return kb * 1000, nil
+}
@@ -51,3 +51,7 @@ func NewSMTP(prod bool) (out SMTP, err error) {
 
 	return out, nil
 }
+
+// NewPublicHost returns env PUBLIC_HOST : the host (without scheme) used in the links
+// of the mails sent by daily jobs, or an empty string to disable these mails.
+func NewPublicHost() string { return os.Getenv("PUBLIC_HOST") }
@@ -346,9 +346,9 @@ func (ct *Controller) CampsDelete(c echo.Context) error {
 }
 
 func (ct *Controller) deleteCamp(id cps.IdCamp) error {
-	var toDelete []fs.IdFile
+	var toDelete fs.Files
 	err := utils.InTx(ct.db, func(tx *sql.Tx) error {
-		// intégrité sur les participants
+		// intégrité sur les participants et le registre des soins
 		// cascade sur les équipiers
 
 		links, err := fs.DeleteFileCampsByIdCamps(tx, id)
@@ -356,11 +356,24 @@ func (ct *Controller) deleteCamp(id cps.IdCamp) error {
 			return err
 		}
 
-		toDelete, err = fs.DeleteFilesByIDs(tx, links.IdFiles()...)
+		toDelete, err = fs.DeleteFiles(tx, links.IdFiles()...)
 		if err != nil {
 			return err
 		}
 
+		// justificatifs des notes de frais des équipiers
+		equipiers, err := cps.SelectEquipiersByIdCamps(tx, id)
+		if err != nil {
+			return err
+		}
+		justificatifs, err := logic.DeleteJustificatifsDepenses(tx, equipiers.IDs()...)
+		if err != nil {
+			return err
+		}
+		for idFile, file := range justificatifs {
+			toDelete[idFile] = file
+		}
+
 		// cascade sur les DemandeCamps et Groupes
 		_, err = cps.DeleteCampById(tx, id)
 		if err != nil {
@@ -375,7 +388,7 @@ func (ct *Controller) deleteCamp(id cps.IdCamp) error {
 
 	// cleanup the document content
 	go func() {
-		err = ct.files.Delete(toDelete...)
+		err = ct.files.Delete(ct.db, utils.MapValues(toDelete)...)
 		if err != nil {
 			log.Println(err)
 		}
@@ -433,6 +446,39 @@ func (ct *Controller) CampsDocuments(c echo.Context) error {
 	return c.JSON(200, out)
 }
 
+// CampsParticipantsFiles renvoie les documents des participants,
+// avec leur état de vérification.
+func (ct *Controller) CampsParticipantsFiles(c echo.Context) error {
+	id, err := utils.QueryParamInt[cps.IdCamp](c, "idCamp")
+	if err != nil {
+		return err
+	}
+	loader, err := fsAPI.LoadParticipantsFiles(ct.db, ct.key, []cps.IdCamp{id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, loader.For(id))
+}
+
+type CampsRevueDocumentIn struct {
+	IdCamp cps.IdCamp
+	logic.RevueDocumentIn
+}
+
+// CampsRevueDocument accepte ou refuse un document envoyé
+// par un participant ou un équipier.
+func (ct *Controller) CampsRevueDocument(c echo.Context) error {
+	var args CampsRevueDocumentIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := logic.RevueDocument(ct.db, ct.key, ct.smtp, ct.asso, c.Request().Host, args.IdCamp, args.RevueDocumentIn)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
 type FilesCamp struct {
 	ToShow cps.DocumentsToShow
 
@@ -8,6 +8,7 @@ import (
 
 	fsAPI "registro/controllers/files"
 	"registro/generators/sheets"
+	"registro/logic"
 	cps "registro/sql/camps"
 	fs "registro/sql/files"
 	pr "registro/sql/personnes"
@@ -123,3 +124,116 @@ func (ct *Controller) exportListeEquipiers(idCamp cps.IdCamp) ([]byte, string, e
 	name := fmt.Sprintf("Equipiers %s.xlsx", camp.Label())
 	return content, name, nil
 }
+
+// CampsLoadPlanningEquipiers renvoie les affectations des équipiers
+// sur les séjours de l'année, avec les conflits de dates
+// et le contrôle des taux d'encadrement.
+func (ct *Controller) CampsLoadPlanningEquipiers(c echo.Context) error {
+	year, err := utils.QueryParamInt[int](c, "year")
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadPlanningEquipiers(ct.db, year, ct.asso.Encadrement)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// CampsLoadConformite renvoie le rapport de conformité
+// de l'équipe du séjour aux règles d'encadrement.
+func (ct *Controller) CampsLoadConformite(c echo.Context) error {
+	id, err := utils.QueryParamInt[cps.IdCamp](c, "idCamp")
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadEncadrementCamp(ct.db, id, ct.asso.Encadrement)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+type CampsValideConformiteIn struct {
+	IdCamp  cps.IdCamp
+	Validee bool
+}
+
+// CampsValideConformite enregistre la validation du rapport
+// d'encadrement, qui autorise les directeurs à envoyer les documents.
+func (ct *Controller) CampsValideConformite(c echo.Context) error {
+	var args CampsValideConformiteIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.valideConformite(args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) valideConformite(args CampsValideConformiteIn) (logic.EncadrementCamp, error) {
+	camp, err := cps.SelectCamp(ct.db, args.IdCamp)
+	if err != nil {
+		return logic.EncadrementCamp{}, utils.SQLError(err)
+	}
+	// la validation porte sur le rapport actuel
+	rapport, err := logic.LoadEncadrementCamp(ct.db, camp.Id, ct.asso.Encadrement)
+	if err != nil {
+		return logic.EncadrementCamp{}, err
+	}
+	camp.ConformiteValidee = args.Validee
+	camp.ConformiteEmpreinte = ""
+	if args.Validee {
+		camp.ConformiteEmpreinte = rapport.Empreinte()
+	}
+	_, err = camp.Update(ct.db)
+	if err != nil {
+		return logic.EncadrementCamp{}, utils.SQLError(err)
+	}
+	return logic.LoadEncadrementCamp(ct.db, camp.Id, ct.asso.Encadrement)
+}
+
+// CampsLoadDeclaration renvoie les données de la déclaration du séjour
+// (TAM/SIAM), avec les informations manquantes.
+func (ct *Controller) CampsLoadDeclaration(c echo.Context) error {
+	idCamp, err := utils.QueryParamInt[cps.IdCamp](c, "idCamp")
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadDeclarationCamp(ct.db, idCamp)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// CampsDownloadDeclaration renvoie la déclaration du séjour,
+// au format Excel, ou CSV si le paramètre 'csv' vaut 'true'.
+func (ct *Controller) CampsDownloadDeclaration(c echo.Context) error {
+	idCamp, err := utils.QueryParamInt[cps.IdCamp](c, "idCamp")
+	if err != nil {
+		return err
+	}
+	content, name, err := ExportDeclarationCamp(ct.db, idCamp, utils.QueryParamBool(c, "csv"))
+	if err != nil {
+		return err
+	}
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+// ExportDeclarationCamp renvoie le fichier de déclaration du séjour et son nom.
+func ExportDeclarationCamp(db cps.DB, idCamp cps.IdCamp, asCsv bool) ([]byte, string, error) {
+	declaration, err := logic.LoadDeclarationCamp(db, idCamp)
+	if err != nil {
+		return nil, "", err
+	}
+	if asCsv {
+		content, err := sheets.DeclarationCampCsv(declaration)
+		return content, fmt.Sprintf("Déclaration %s.csv", declaration.Camp), err
+	}
+	content, err := sheets.DeclarationCamp(declaration)
+	return content, fmt.Sprintf("Déclaration %s.xlsx", declaration.Camp), err
+}
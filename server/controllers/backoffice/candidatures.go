@@ -0,0 +1,68 @@
+package backoffice
+
+import (
+	"registro/logic"
+	cps "registro/sql/camps"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CandidaturesGet renvoie les candidatures équipiers
+// des séjours donnés.
+func (ct *Controller) CandidaturesGet(c echo.Context) error {
+	idCamps, err := utils.QueryParamInts[cps.IdCamp](c, "idCamps")
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadCandidatures(ct.db, idCamps...)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+type CandidaturesSetStatutIn struct {
+	IdCandidature cps.IdCandidature
+	Statut        cps.StatutCandidature
+}
+
+// CandidaturesSetStatut présélectionne un candidat, ou le remet en attente.
+func (ct *Controller) CandidaturesSetStatut(c echo.Context) error {
+	var args CandidaturesSetStatutIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	err := logic.SetStatutCandidature(ct.db, args.IdCandidature, args.Statut, cps.OptIdCamp{})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+// CandidaturesAccepte identifie le profil du candidat
+// et l'ajoute à l'équipe du séjour.
+func (ct *Controller) CandidaturesAccepte(c echo.Context) error {
+	var args logic.AccepteCandidatureIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := logic.AccepteCandidature(ct.db, ct.builtins, ct.asso.ID, args, cps.OptIdCamp{})
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// CandidaturesRefuse décline la candidature, et prévient le candidat par mail.
+func (ct *Controller) CandidaturesRefuse(c echo.Context) error {
+	var args logic.RefuseCandidatureIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	err := logic.RefuseCandidature(ct.db, ct.smtp, ct.asso, args, cps.OptIdCamp{})
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
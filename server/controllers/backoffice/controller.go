@@ -39,6 +39,8 @@ type Controller struct {
 	passwordFondsoutien string // backoffice client key, with Fonds de soutien role
 
 	builtins fs.Builtins
+
+	doublons doublonsCache
 }
 
 func NewController(db *sql.DB, key crypto.Encrypter, password, passwordFondsoutien string, files fs.FileSystem, smtp config.SMTP, asso config.Asso, immich config.Immich, helloasso config.Helloasso) (*Controller, error) {
@@ -57,6 +59,7 @@ func NewController(db *sql.DB, key crypto.Encrypter, password, passwordFondsouti
 		password,
 		passwordFondsoutien,
 		builtins,
+		doublonsCache{},
 	}, nil
 }
 
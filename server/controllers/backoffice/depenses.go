@@ -0,0 +1,194 @@
+package backoffice
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	fsAPI "registro/controllers/files"
+	"registro/generators/sheets"
+	"registro/logic"
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// notes de frais des équipiers, validées par les directeurs
+
+func (ct *Controller) loadDepenses(year int) ([]logic.DepenseEquipier, error) {
+	camps, err := cps.SelectAllCamps(ct.db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	camps.RestrictByYear(year)
+	return logic.LoadDepensesCamps(ct.db, ct.key, camps.IDs()...)
+}
+
+// CampsDepensesGet renvoie les notes de frais des équipiers
+// des séjours de l'année.
+func (ct *Controller) CampsDepensesGet(c echo.Context) error {
+	year, err := utils.QueryParamInt[int](c, "year")
+	if err != nil {
+		return err
+	}
+	out, err := ct.loadDepenses(year)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// CampsDepensesDownload renvoie la liste des notes de frais approuvées
+// (à rembourser) des séjours de l'année, au format Excel.
+func (ct *Controller) CampsDepensesDownload(c echo.Context) error {
+	year, err := utils.QueryParamInt[int](c, "year")
+	if err != nil {
+		return err
+	}
+	content, err := ct.exportDepenses(year)
+	if err != nil {
+		return err
+	}
+	mimeType := fsAPI.SetBlobHeader(c, content, fmt.Sprintf("Notes de frais %d.xlsx", year))
+	return c.Blob(200, mimeType, content)
+}
+
+func (ct *Controller) exportDepenses(year int) ([]byte, error) {
+	depenses, err := ct.loadDepenses(year)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		rows  [][]sheets.Cell
+		total ds.MultiCurrencies
+	)
+	for _, depense := range depenses {
+		if depense.Depense.Statut != cps.DepenseApprouvee {
+			continue
+		}
+		rows = append(rows, []sheets.Cell{
+			{ValueF: float32(depense.Depense.Id), NumFormat: sheets.Int},
+			{Value: depense.Equipier},
+			{Value: depense.Camp},
+			{Value: depense.Depense.Categorie.String()},
+			{Value: depense.Depense.Date.String()},
+			{Value: depense.Depense.Description},
+			{Value: depense.Depense.Montant.String()},
+			{ValueF: float32(len(depense.Justificatifs)), NumFormat: sheets.Int},
+		})
+		total.Add(depense.Depense.Montant)
+	}
+	return sheets.CreateTableTotal([]string{"ID", "Equipier", "Séjour", "Catégorie", "Date", "Description", "Montant", "Justificatifs"}, rows, total.String())
+}
+
+// loadDepenseApprouvee renvoie la note de frais [id], qui doit avoir
+// été approuvée par le directeur.
+func (ct *Controller) loadDepenseApprouvee(id cps.IdDepense) (logic.DepenseEquipier, error) {
+	depense, err := cps.SelectDepense(ct.db, id)
+	if err != nil {
+		return logic.DepenseEquipier{}, utils.SQLError(err)
+	}
+	equipier, err := cps.SelectEquipier(ct.db, depense.IdEquipier)
+	if err != nil {
+		return logic.DepenseEquipier{}, utils.SQLError(err)
+	}
+	depenses, err := logic.LoadDepensesCamps(ct.db, ct.key, equipier.IdCamp)
+	if err != nil {
+		return logic.DepenseEquipier{}, err
+	}
+	for _, item := range depenses {
+		if item.Depense.Id != id {
+			continue
+		}
+		if item.Depense.Statut != cps.DepenseApprouvee {
+			return logic.DepenseEquipier{}, errors.New("La note de frais n'est pas approuvée.")
+		}
+		return item, nil
+	}
+	return logic.DepenseEquipier{}, errors.New("internal error: missing depense")
+}
+
+// lockDepenseApprouvee verrouille la note de frais [id] et vérifie
+// qu'elle est toujours approuvée : son statut a pu changer
+// depuis [loadDepenseApprouvee].
+func lockDepenseApprouvee(tx *sql.Tx, id cps.IdDepense) (cps.Depense, error) {
+	depense, err := cps.SelectDepenseForUpdate(tx, id)
+	if err != nil {
+		return cps.Depense{}, err
+	}
+	if depense.Statut != cps.DepenseApprouvee {
+		return cps.Depense{}, errors.New("La note de frais n'est plus approuvée.")
+	}
+	return depense, nil
+}
+
+// CampsDepenseRembourse marque la note de frais comme remboursée.
+func (ct *Controller) CampsDepenseRembourse(c echo.Context) error {
+	id, err := utils.QueryParamInt[cps.IdDepense](c, "idDepense")
+	if err != nil {
+		return err
+	}
+	out, err := ct.rembourseDepense(id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) rembourseDepense(id cps.IdDepense) (cps.Depense, error) {
+	depense, err := ct.loadDepenseApprouvee(id)
+	if err != nil {
+		return cps.Depense{}, err
+	}
+	var out cps.Depense
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		depense.Depense, err = lockDepenseApprouvee(tx, id)
+		if err != nil {
+			return err
+		}
+		depense.Depense.Statut = cps.DepenseRemboursee
+		out, err = depense.Depense.Update(tx)
+		return err
+	})
+	return out, err
+}
+
+// CampsDepenseConvertit enregistre l'abandon du remboursement
+// de la note de frais par l'équipier : un don est créé,
+// qui sera pris en compte dans son reçu fiscal.
+func (ct *Controller) CampsDepenseConvertit(c echo.Context) error {
+	id, err := utils.QueryParamInt[cps.IdDepense](c, "idDepense")
+	if err != nil {
+		return err
+	}
+	out, err := ct.convertitDepense(id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) convertitDepense(id cps.IdDepense) (cps.Depense, error) {
+	depense, err := ct.loadDepenseApprouvee(id)
+	if err != nil {
+		return cps.Depense{}, err
+	}
+	var out cps.Depense
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		depense.Depense, err = lockDepenseApprouvee(tx, id)
+		if err != nil {
+			return err
+		}
+		_, err = logic.NewDonDepense(depense, time.Now()).Insert(tx)
+		if err != nil {
+			return err
+		}
+		depense.Depense.Statut = cps.DepenseConvertie
+		out, err = depense.Depense.Update(tx)
+		return err
+	})
+	return out, err
+}
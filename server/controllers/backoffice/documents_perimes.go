@@ -0,0 +1,183 @@
+package backoffice
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"registro/logic"
+	"registro/mails"
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// RelanceDocumentsPerimes demande le renouvellement des documents dont
+// la validité expire avant le prochain séjour aux familles et aux équipiers,
+// et envoie un récapitulatif aux directeurs.
+// Un document n'est marqué comme périmé qu'une fois la relance envoyée :
+// en cas d'échec, il est donc relancé le lendemain.
+// Elle est lancée chaque jour ; les mails ne sont pas envoyés si [host] est vide.
+func (ct *Controller) RelanceDocumentsPerimes(host string) {
+	docs, err := ct.relanceDocumentsPerimes(host, time.Now())
+	if err != nil {
+		log.Println("relance des documents périmés :", err)
+	}
+	log.Printf("relance des documents périmés : %d document(s) périmé(s)", len(docs))
+}
+
+func (ct *Controller) relanceDocumentsPerimes(host string, now time.Time) ([]logic.DocumentPerime, error) {
+	docs, err := logic.ChercheDocumentsPerimes(ct.db, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	if host == "" {
+		if err = logic.MarqueDocumentsPerimes(ct.db, docs); err != nil {
+			return nil, err
+		}
+		return docs, nil
+	}
+	return ct.notifieDocumentsPerimes(host, docs)
+}
+
+func documentLabel(doc logic.DocumentPerime) string {
+	return fmt.Sprintf("%s (valide jusqu'au %s)", doc.Demande.Title(), doc.Validite)
+}
+
+// notifieDocumentsPerimes envoie les relances et marque les documents
+// dont la relance a été envoyée, qui sont renvoyés.
+// Un échec d'envoi n'interrompt pas les autres relances.
+func (ct *Controller) notifieDocumentsPerimes(host string, docs []logic.DocumentPerime) ([]logic.DocumentPerime, error) {
+	var (
+		idPersonnes []pr.IdPersonne
+		idCamps     []cps.IdCamp
+		idDossiers  []ds.IdDossier
+		byPersonne  = make(map[pr.IdPersonne][]logic.DocumentPerime)
+		byCamp      = make(map[cps.IdCamp][]logic.DocumentPerime)
+	)
+	for _, doc := range docs {
+		if _, has := byPersonne[doc.IdPersonne]; !has {
+			idPersonnes = append(idPersonnes, doc.IdPersonne)
+		}
+		if !slices.Contains(idCamps, doc.IdCamp) {
+			idCamps = append(idCamps, doc.IdCamp)
+		}
+		byPersonne[doc.IdPersonne] = append(byPersonne[doc.IdPersonne], doc)
+		if doc.IdDossier != 0 {
+			idDossiers = append(idDossiers, doc.IdDossier)
+		}
+	}
+
+	camps, err := cps.SelectCamps(ct.db, idCamps...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	equipiers, err := cps.SelectEquipiersByIdCamps(ct.db, idCamps...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	equipiersByCamp := equipiers.ByIdCamp()
+	dossiers, err := logic.LoadDossiers(ct.db, idDossiers)
+	if err != nil {
+		return nil, err
+	}
+	personnes, err := pr.SelectPersonnes(ct.db, append(equipiers.IdPersonnes(), idPersonnes...)...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+
+	pool, err := mails.NewPool(ct.smtp, ct.asso.MailsSettings, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	var (
+		marked []logic.DocumentPerime
+		errs   []error
+	)
+	// familles et équipiers
+	for _, idPersonne := range idPersonnes {
+		list := byPersonne[idPersonne]
+		first := list[0]
+		personne := personnes[idPersonne]
+		camp := camps[first.IdCamp]
+		labels := make([]string, len(list))
+		for i, doc := range list {
+			labels[i] = documentLabel(doc)
+		}
+
+		var (
+			to, html string
+			ccs      []string
+		)
+		if first.IdDossier != 0 { // participant
+			dossier := dossiers.For(first.IdDossier)
+			responsable := dossier.Responsable()
+			url := logic.EspacePersoURL(ct.key, host, first.IdDossier, utils.QP("origine", "documents-perimes"))
+			html, err = mails.RenouvelleDocuments(ct.asso, mails.NewContact(&responsable), camp.Label(), personne.FPrenom(), labels, url)
+			to, ccs = responsable.Mail, dossier.Dossier.CopiesMails
+		} else { // équipier
+			url := logic.EquipierURL(ct.key, host, first.IdEquipier)
+			html, err = mails.RenouvelleDocuments(ct.asso, mails.NewContact(&personne), camp.Label(), "", labels, url)
+			to = personne.Mail
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// sans adresse, les documents sont tout de même redemandés sur l'espace perso
+		if to != "" {
+			if err = pool.SendMail(to, "Documents à renouveler", html, ccs, nil); err != nil {
+				errs = append(errs, fmt.Errorf("relance de %s : %s", personne.PrenomNOM(), err))
+				continue
+			}
+		}
+		if err = logic.MarqueDocumentsPerimes(ct.db, list); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		marked = append(marked, list...)
+		for _, doc := range list {
+			byCamp[doc.IdCamp] = append(byCamp[doc.IdCamp], doc)
+		}
+	}
+
+	// récapitulatif pour les directeurs
+	directeursURL := utils.BuildUrl(host, "/directeurs")
+	for _, idCamp := range idCamps {
+		directeur, has := equipiersByCamp[idCamp].Directeur()
+		if !has {
+			continue
+		}
+		personne := personnes[directeur.IdPersonne]
+		if personne.Mail == "" {
+			continue
+		}
+		camp := camps[idCamp]
+		list := byCamp[idCamp]
+		if len(list) == 0 {
+			continue
+		}
+		labels := make([]string, len(list))
+		for i, doc := range list {
+			concerne := personnes[doc.IdPersonne]
+			labels[i] = concerne.PrenomNOM() + " : " + documentLabel(doc)
+		}
+		html, err := mails.RecapDocumentsPerimes(ct.asso, mails.NewContact(&personne), camp.Label(), labels, directeursURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err = pool.SendMail(personne.Mail, fmt.Sprintf("Documents périmés - %s", camp.Label()), html, nil, nil); err != nil {
+			errs = append(errs, fmt.Errorf("récapitulatif de %s : %s", camp.Label(), err))
+		}
+	}
+	return marked, errors.Join(errs...)
+}
@@ -298,7 +298,10 @@ func (ct *Controller) DossiersLoad(c echo.Context) error {
 type DossierDetails struct {
 	Dossier        logic.DossierExt
 	EspacepersoURL string
-	VirementCode   string
+	// EspacepersoURLResponsable2 est vide s'il n'y a pas de
+	// second responsable
+	EspacepersoURLResponsable2 string
+	VirementCode               string
 	// also displayed in espace perso
 	// name, IBAN
 	BankAccounts []config.BankAccount
@@ -310,9 +313,13 @@ func (ct *Controller) loadDossier(host string, id ds.IdDossier) (DossierDetails,
 		return DossierDetails{}, err
 	}
 	url := logic.EspacePersoURL(ct.key, host, id)
+	url2 := ""
+	if id2 := dossier.Dossier.Dossier.IdResponsable2; id2.Valid {
+		url2 = logic.EspacePersoURLResponsable2(ct.key, host, id, id2.Id)
+	}
 	virement := OffuscateurVirements.Mask(id)
 	accounts := ct.asso.BankAccounts
-	return DossierDetails{dossier.Publish(ct.key), url, virement, accounts}, nil
+	return DossierDetails{dossier.Publish(ct.key), url, url2, virement, accounts}, nil
 }
 
 func (ct *Controller) DossiersCreate(c echo.Context) error {
@@ -367,8 +374,13 @@ func (ct *Controller) updateDossier(args ds.Dossier) (string, error) {
 	if err != nil {
 		return "", utils.SQLError(err)
 	}
+	if args.IdResponsable2.Is(args.IdResponsable) {
+		return "", errors.New("Le second responsable doit être différent du responsable.")
+	}
 
 	current.IdResponsable = args.IdResponsable
+	current.IdResponsable2 = args.IdResponsable2
+	current.AccesResponsable2 = args.AccesResponsable2
 	current.CopiesMails = args.CopiesMails
 	current.PartageAdressesOK = args.PartageAdressesOK
 	current.DemandeFondSoutien = args.DemandeFondSoutien
@@ -380,6 +392,40 @@ func (ct *Controller) updateDossier(args ds.Dossier) (string, error) {
 	return responsable.PrenomNOM(), nil
 }
 
+// DossiersInviteResponsable2 envoie au second responsable
+// son lien vers l'espace personnel.
+func (ct *Controller) DossiersInviteResponsable2(c echo.Context) error {
+	id, err := utils.QueryParamInt[ds.IdDossier](c, "id")
+	if err != nil {
+		return err
+	}
+	err = ct.inviteResponsable2(c.Request().Host, id)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) inviteResponsable2(host string, id ds.IdDossier) error {
+	dossier, responsable, err := dossierAndResp(ct.db, id)
+	if err != nil {
+		return err
+	}
+	if !dossier.IdResponsable2.Valid {
+		return errors.New("internal error: missing second responsable")
+	}
+	responsable2, err := pr.SelectPersonne(ct.db, dossier.IdResponsable2.Id)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	url := logic.EspacePersoURLResponsable2(ct.key, host, id, responsable2.Id)
+	html, err := mails.InviteResponsable2(ct.asso, mails.NewContact(&responsable2), responsable.PrenomNOM(), dossier.AccesResponsable2 == ds.LectureSeule, url)
+	if err != nil {
+		return err
+	}
+	return mails.NewMailer(ct.smtp, ct.asso.MailsSettings).SendMail(responsable2.Mail, "Accès à l'espace de suivi", html, nil, nil)
+}
+
 func (ct *Controller) DossiersDelete(c echo.Context) error {
 	id, err := utils.QueryParamInt[ds.IdDossier](c, "id")
 	if err != nil {
@@ -415,11 +461,11 @@ func (ct *Controller) deleteDossier(id ds.IdDossier) error {
 	}
 
 	return utils.InTx(ct.db, func(tx *sql.Tx) error {
-		deleted, err := files.DeleteFilesByIDs(tx, links.IdFiles()...)
+		deleted, err := files.DeleteFiles(tx, links.IdFiles()...)
 		if err != nil {
 			return err
 		}
-		err = ct.files.Delete(deleted...)
+		err = ct.files.Delete(tx, utils.MapValues(deleted)...)
 		if err != nil {
 			return err
 		}
@@ -630,11 +676,11 @@ func (ct *Controller) deleteAideJustificatif(id cps.IdAide) error {
 		if err != nil {
 			return err
 		}
-		deleted, err := fs.DeleteFilesByIDs(tx, links.IdFiles()...)
+		deleted, err := fs.DeleteFiles(tx, links.IdFiles()...)
 		if err != nil {
 			return err
 		}
-		err = ct.files.Delete(deleted...)
+		err = ct.files.Delete(tx, utils.MapValues(deleted)...)
 		if err != nil {
 			return err
 		}
@@ -657,14 +703,14 @@ func (ct *Controller) AidesDelete(c echo.Context) error {
 
 // returns the dossier the [Aide] was linked
 func (ct *Controller) deleteAide(id cps.IdAide) error {
-	var files []fs.IdFile
+	var files fs.Files
 	err := utils.InTx(ct.db, func(tx *sql.Tx) error {
 		// remove associated documents
 		links, err := fs.DeleteFileAidesByIdAides(tx, id)
 		if err != nil {
 			return err
 		}
-		files, err = fs.DeleteFilesByIDs(tx, links.IdFiles()...)
+		files, err = fs.DeleteFiles(tx, links.IdFiles()...)
 		if err != nil {
 			return err
 		}
@@ -677,7 +723,7 @@ func (ct *Controller) deleteAide(id cps.IdAide) error {
 	if err != nil {
 		return err
 	}
-	err = ct.files.Delete(files...)
+	err = ct.files.Delete(ct.db, utils.MapValues(files)...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+package backoffice
+
+import (
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"registro/logic"
+	"registro/logic/search"
+	pr "registro/sql/personnes"
+
+	"github.com/labstack/echo/v4"
+)
+
+// doublonsCache stocke le résultat de la dernière recherche de doublons,
+// qui est coûteuse. Sa valeur zéro est utilisable.
+type doublonsCache struct {
+	lock     sync.Mutex
+	computed time.Time // zero if never computed
+	doublons []logic.DoublonExt
+}
+
+type DoublonsOut struct {
+	Computed time.Time
+	Doublons []logic.DoublonExt
+}
+
+func (dc *doublonsCache) get() DoublonsOut {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+	return DoublonsOut{dc.computed, slices.Clone(dc.doublons)}
+}
+
+func (dc *doublonsCache) set(doublons []logic.DoublonExt, computed time.Time) {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+	dc.doublons, dc.computed = doublons, computed
+}
+
+// remove supprime [id] des groupes, après une fusion
+func (dc *doublonsCache) remove(id pr.IdPersonne) {
+	dc.lock.Lock()
+	defer dc.lock.Unlock()
+	var out []logic.DoublonExt
+	for _, doublon := range dc.doublons {
+		doublon.Personnes = slices.DeleteFunc(slices.Clone(doublon.Personnes), func(h search.PersonneHeader) bool { return h.Id == id })
+		if len(doublon.Personnes) < 2 {
+			continue
+		}
+		out = append(out, doublon)
+	}
+	dc.doublons = out
+}
+
+// RechercheDoublons met à jour la liste des doublons.
+// Elle est lancée chaque nuit.
+func (ct *Controller) RechercheDoublons() {
+	if _, err := ct.refreshDoublons(); err != nil {
+		log.Println("recherche des doublons :", err)
+	}
+}
+
+func (ct *Controller) refreshDoublons() (DoublonsOut, error) {
+	doublons, err := logic.LoadDoublons(ct.db)
+	if err != nil {
+		return DoublonsOut{}, err
+	}
+	ct.doublons.set(doublons, time.Now())
+	return ct.doublons.get(), nil
+}
+
+// PersonnesLoadDoublons renvoie le résultat de la dernière
+// recherche de doublons (lancée si besoin).
+func (ct *Controller) PersonnesLoadDoublons(c echo.Context) error {
+	out := ct.doublons.get()
+	if out.Computed.IsZero() {
+		var err error
+		out, err = ct.refreshDoublons()
+		if err != nil {
+			return err
+		}
+	}
+	return c.JSON(200, out)
+}
+
+// PersonnesRefreshDoublons relance la recherche de doublons.
+func (ct *Controller) PersonnesRefreshDoublons(c echo.Context) error {
+	out, err := ct.refreshDoublons()
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// PersonnesFusionne fusionne deux profils et renvoie
+// le profil conservé.
+func (ct *Controller) PersonnesFusionne(c echo.Context) error {
+	var args logic.FusionIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	pe, err := logic.FusionnePersonnes(ct.db, args)
+	if err != nil {
+		return err
+	}
+	ct.doublons.remove(args.Supprime)
+	out := search.NewPersonneHeader(pe)
+	return c.JSON(200, out)
+}
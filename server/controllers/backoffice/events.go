@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"log"
 	"time"
 
 	"registro/config"
@@ -14,6 +15,7 @@ import (
 	cps "registro/sql/camps"
 	ds "registro/sql/dossiers"
 	evs "registro/sql/events"
+	pr "registro/sql/personnes"
 	"registro/utils"
 
 	"github.com/labstack/echo/v4"
@@ -70,7 +72,38 @@ func (ct *Controller) sendMessage(host string, args EventsSendMessageIn, fromFon
 		}
 		return nil
 	})
-	return event, err
+	if err != nil {
+		return event, err
+	}
+
+	// notifie le second responsable, avec son propre lien :
+	// le message est déjà enregistré, une erreur est seulement signalée
+	if id2 := dossier.IdResponsable2; id2.Valid {
+		if err := ct.notifieResponsable2(host, args, id2.Id, fromFondsSoutien); err != nil {
+			log.Println("notification du second responsable :", err)
+		}
+	}
+	return event, nil
+}
+
+func (ct *Controller) notifieResponsable2(host string, args EventsSendMessageIn, id pr.IdPersonne, fromFondsSoutien bool) error {
+	responsable2, err := pr.SelectPersonne(ct.db, id)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if responsable2.Mail == "" {
+		return errors.New("adresse mail manquante")
+	}
+	url := logic.EspacePersoURLResponsable2(ct.key, host, args.IdDossier, id)
+	body, err := mails.NotifieMessage(ct.asso, mails.NewContact(&responsable2), args.Contenu, url, fromFondsSoutien)
+	if err != nil {
+		return err
+	}
+	var replyTo mails.ReplyTo
+	if fromFondsSoutien {
+		replyTo = mails.CustomReplyTo(ct.asso.MailsSettings.FondsSoutien)
+	}
+	return mails.NewMailer(ct.smtp, ct.asso.MailsSettings).SendMail(responsable2.Mail, "Nouveau message", body, nil, replyTo)
 }
 
 func (ct *Controller) EventsDelete(c echo.Context) error {
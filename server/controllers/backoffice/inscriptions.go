@@ -56,7 +56,7 @@ func (ct *Controller) InscriptionsIdentifiePersonne(c echo.Context) error {
 		return err
 	}
 
-	err := logic.IdentifiePersonne(ct.db, args.Target)
+	_, err := logic.IdentifiePersonne(ct.db, args.Target)
 	if err != nil {
 		return err
 	}
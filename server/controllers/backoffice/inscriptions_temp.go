@@ -0,0 +1,108 @@
+package backoffice
+
+import (
+	"errors"
+	"time"
+
+	"registro/logic"
+	"registro/logic/search"
+	pr "registro/sql/personnes"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TemporairesOut struct {
+	Profils []logic.TempProfil
+	Actions []logic.IdentAction // annulables
+}
+
+// InscriptionsLoadTemporaires renvoie la liste des profils temporaires à identifier,
+// ainsi que les identifications récentes.
+func (ct *Controller) InscriptionsLoadTemporaires(c echo.Context) error {
+	profils, err := logic.LoadTempProfils(ct.db)
+	if err != nil {
+		return err
+	}
+	actions, err := logic.LoadIdentifications(ct.db, time.Now())
+	if err != nil {
+		return err
+	}
+	out := TemporairesOut{profils, actions}
+	return c.JSON(200, out)
+}
+
+// InscriptionsIdentifieTemporaire identifie un profil temporaire
+// et enregistre l'action pour une éventuelle annulation.
+func (ct *Controller) InscriptionsIdentifieTemporaire(c echo.Context) error {
+	var args logic.IdentTarget
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	record, err := logic.IdentifiePersonne(ct.db, args)
+	if err != nil {
+		return err
+	}
+	actions, err := logic.JournaliseIdentifications(ct.db, time.Now(), record)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, actions[0])
+}
+
+type AutoIdentifieIn struct {
+	Seuil int // score minimal, en pourcents
+}
+
+// InscriptionsAutoIdentifie rattache d'un coup tous les profils temporaires
+// dont le meilleur candidat dépasse le seuil donné.
+func (ct *Controller) InscriptionsAutoIdentifie(c echo.Context) error {
+	var args AutoIdentifieIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.autoIdentifie(args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) autoIdentifie(args AutoIdentifieIn) ([]logic.IdentAction, error) {
+	const minSeuil = 50
+	if args.Seuil < minSeuil || args.Seuil > 100 {
+		return nil, errors.New("invalid Seuil")
+	}
+	records, err := logic.AutoIdentifie(ct.db, args.Seuil)
+	// register the actions actually done, even on error
+	out, errJournal := logic.JournaliseIdentifications(ct.db, time.Now(), records...)
+	if err != nil {
+		return nil, err
+	}
+	if errJournal != nil {
+		return nil, errJournal
+	}
+	return out, nil
+}
+
+// InscriptionsAnnuleIdentification annule une identification récente,
+// et renvoie le profil temporaire restauré.
+func (ct *Controller) InscriptionsAnnuleIdentification(c echo.Context) error {
+	id, err := utils.QueryParamInt[pr.IdIdentification](c, "id")
+	if err != nil {
+		return err
+	}
+	out, err := ct.annuleIdentification(id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) annuleIdentification(id pr.IdIdentification) (search.PersonneHeader, error) {
+	temporaire, err := logic.AnnuleIdentification(ct.db, time.Now(), id)
+	if err != nil {
+		return search.PersonneHeader{}, err
+	}
+	return search.NewPersonneHeader(temporaire), nil
+}
@@ -228,11 +228,11 @@ func (ct *Controller) deleteParticipant(id cps.IdParticipant) error {
 			return err
 		}
 
-		deleted, err := files.DeleteFilesByIDs(tx, links.IdFiles()...)
+		deleted, err := files.DeleteFiles(tx, links.IdFiles()...)
 		if err != nil {
 			return err
 		}
-		err = ct.files.Delete(deleted...)
+		err = ct.files.Delete(tx, utils.MapValues(deleted)...)
 		if err != nil {
 			return err
 		}
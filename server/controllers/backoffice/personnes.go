@@ -1,8 +1,16 @@
 package backoffice
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+
+	fsAPI "registro/controllers/files"
 	"registro/logic"
 	"registro/logic/search"
+	fs "registro/sql/files"
 	pr "registro/sql/personnes"
 	"registro/utils"
 
@@ -66,3 +74,110 @@ func (ct *Controller) updatePersonne(args pr.Personne) (search.PersonneHeader, e
 
 	return search.NewPersonneHeader(current), nil
 }
+
+// PersonnesExportDonnees renvoie une archive .ZIP contenant
+// toutes les données liées à la personne, ainsi que ses documents,
+// pour répondre à une demande d'accès (RGPD).
+func (ct *Controller) PersonnesExportDonnees(c echo.Context) error {
+	id, err := utils.QueryParamInt[pr.IdPersonne](c, "id")
+	if err != nil {
+		return err
+	}
+	data, err := logic.LoadDonneesPersonne(ct.db, id)
+	if err != nil {
+		return err
+	}
+	archiveName := fmt.Sprintf("Données %s.zip", data.Personne.NOMPrenom())
+	return fsAPI.StreamZip(c.Response(), archiveName, ct.donneesPersonneItems(data))
+}
+
+func (ct *Controller) donneesPersonneItems(data logic.DonneesPersonne) iter.Seq2[fsAPI.ZipItem, error] {
+	sections := [...]struct {
+		name  string
+		value any
+	}{
+		{"personne.json", data.Personne},
+		{"fiche_sanitaire.json", data.Fichesanitaires},
+		{"fiche_sanitaire_versions.json", data.FichesanitaireVersions},
+		{"fiche_equipier.json", data.Ficheequipiers},
+		{"sejours.json", data.Camps},
+		{"participants.json", data.Participants},
+		{"equipiers.json", data.Equipiers},
+		{"soins.json", data.Soins},
+		{"candidatures.json", data.Candidatures},
+		{"notes_de_frais.json", data.Depenses},
+		{"dossiers.json", data.Dossiers},
+		{"paiements.json", data.Paiements},
+		{"messages.json", data.Events},
+		{"dons.json", data.Dons},
+		{"documents.json", data.Files},
+		{"signatures.json", data.Signatures},
+	}
+	return func(yield func(fsAPI.ZipItem, error) bool) {
+		for _, section := range sections {
+			content, err := json.MarshalIndent(section.value, "", "  ")
+			if err != nil {
+				yield(fsAPI.ZipItem{}, err)
+				return
+			}
+			if !yield(fsAPI.ZipItem{Name: section.name, Content: content}, nil) {
+				return
+			}
+		}
+		byFile := data.Links.ByIdFile()
+		signatures := data.Signatures.ByIdFile()
+		justificatifs := data.Justificatifs.ByIdFile()
+		for _, file := range data.Files {
+			content, err := ct.files.Load(file, false)
+			if err != nil {
+				yield(fsAPI.ZipItem{}, err)
+				return
+			}
+			demande := data.Demandes[byFile[file.Id].IdDemande]
+			title := demande.Title()
+			if signature, isSigned := signatures[file.Id]; isSigned {
+				title = signature.Document.String()
+			} else if _, isJustificatif := justificatifs[file.Id]; isJustificatif {
+				title = "Note de frais"
+			}
+			title = strings.ReplaceAll(title, "/", "-")
+			name := fmt.Sprintf("documents/%s (%d) %s", title, file.Id, file.NomClient)
+			if !yield(fsAPI.ZipItem{Name: name, Content: content}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// PersonnesAnonymise efface les données personnelles (droit à l'oubli),
+// en conservant les données comptables.
+func (ct *Controller) PersonnesAnonymise(c echo.Context) error {
+	id, err := utils.QueryParamInt[pr.IdPersonne](c, "id")
+	if err != nil {
+		return err
+	}
+	out, err := ct.anonymisePersonne(id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) anonymisePersonne(id pr.IdPersonne) (search.PersonneHeader, error) {
+	var pe pr.Personne
+	err := utils.InTx(ct.db, func(tx *sql.Tx) error {
+		var (
+			files fs.Files
+			err   error
+		)
+		pe, files, err = logic.AnonymisePersonne(tx, id)
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, utils.MapValues(files)...)
+	})
+	if err != nil {
+		return search.PersonneHeader{}, err
+	}
+	return search.NewPersonneHeader(pe), nil
+}
@@ -0,0 +1,34 @@
+package backoffice
+
+import (
+	"log"
+	"time"
+
+	"registro/logic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// délai utilisé pour prévenir des prochaines suppressions
+const retentionReportJours = 30
+
+// RetentionLoadReport liste les données sensibles qui seront
+// supprimées automatiquement dans les prochains jours.
+func (ct *Controller) RetentionLoadReport(c echo.Context) error {
+	out, err := logic.LoadPurgeReport(ct.db, ct.asso.Retention, time.Now(), retentionReportJours)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// PurgeDonneesExpirees supprime les données dont la durée de conservation
+// est dépassée. Elle est lancée chaque nuit.
+func (ct *Controller) PurgeDonneesExpirees() {
+	purged, err := logic.PurgeDonneesExpirees(ct.db, ct.files, ct.asso.Retention, time.Now())
+	if err != nil {
+		log.Println("purge des données expirées :", err)
+		return
+	}
+	log.Printf("purge des données expirées : %d élément(s) supprimé(s)", len(purged))
+}
@@ -0,0 +1,73 @@
+package directeurs
+
+import (
+	"registro/logic"
+	cps "registro/sql/camps"
+
+	"github.com/labstack/echo/v4"
+)
+
+// candidatures équipiers, déposées sur le formulaire public
+
+// CandidaturesGet renvoie les candidatures pour le séjour.
+func (ct *Controller) CandidaturesGet(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadCandidatures(ct.db, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+type CandidaturesSetStatutIn struct {
+	IdCandidature cps.IdCandidature
+	Statut        cps.StatutCandidature
+}
+
+// CandidaturesSetStatut présélectionne un candidat, ou le remet en attente.
+func (ct *Controller) CandidaturesSetStatut(c echo.Context) error {
+	user := JWTUser(c)
+	var args CandidaturesSetStatutIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	err := logic.SetStatutCandidature(ct.db, args.IdCandidature, args.Statut, user.Opt())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+// CandidaturesAccepte ajoute le candidat à l'équipe.
+// Le profil temporaire du candidat est identifié : voir [InscriptionsSearchSimilaires]
+// pour les profils existants proches.
+func (ct *Controller) CandidaturesAccepte(c echo.Context) error {
+	user := JWTUser(c)
+	var args logic.AccepteCandidatureIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	_, err := logic.AccepteCandidature(ct.db, ct.builtins, ct.asso.ID, args, user.Opt())
+	if err != nil {
+		return err
+	}
+	out, err := ct.getEquipiers(c.Request().Host, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// CandidaturesRefuse décline la candidature, et prévient le candidat par mail.
+func (ct *Controller) CandidaturesRefuse(c echo.Context) error {
+	user := JWTUser(c)
+	var args logic.RefuseCandidatureIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	err := logic.RefuseCandidature(ct.db, ct.smtp, ct.asso, args, user.Opt())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
@@ -0,0 +1,79 @@
+package directeurs
+
+import (
+	"fmt"
+
+	fsAPI "registro/controllers/files"
+	"registro/generators/pdfcreator"
+	"registro/generators/sheets"
+	"registro/logic"
+	cps "registro/sql/camps"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// filtreCuisine lit les paramètres optionnels "idGroupe" et "jours"
+func filtreCuisine(c echo.Context) (out logic.FiltreCuisine, err error) {
+	if c.QueryParam("idGroupe") != "" {
+		out.IdGroupe, err = utils.QueryParamInt[cps.IdGroupe](c, "idGroupe")
+		if err != nil {
+			return out, err
+		}
+	}
+	out.Jours, err = utils.QueryParamInts[int32](c, "jours")
+	return out, err
+}
+
+// CuisineGet renvoie les régimes et allergies alimentaires
+// des inscrits, et le nombre de repas par jour.
+func (ct *Controller) CuisineGet(c echo.Context) error {
+	user := JWTUser(c)
+	filtre, err := filtreCuisine(c)
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadSyntheseCuisine(ct.db, user, filtre)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) CuisineDownloadPDF(c echo.Context) error {
+	user := JWTUser(c)
+	filtre, err := filtreCuisine(c)
+	if err != nil {
+		return err
+	}
+	synthese, err := logic.LoadSyntheseCuisine(ct.db, user, filtre)
+	if err != nil {
+		return err
+	}
+	content, err := pdfcreator.CreateSyntheseCuisine(ct.asso, synthese)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Régimes et allergies %s.pdf", synthese.Camp)
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+func (ct *Controller) CuisineDownloadExcel(c echo.Context) error {
+	user := JWTUser(c)
+	filtre, err := filtreCuisine(c)
+	if err != nil {
+		return err
+	}
+	synthese, err := logic.LoadSyntheseCuisine(ct.db, user, filtre)
+	if err != nil {
+		return err
+	}
+	content, err := sheets.SyntheseCuisine(synthese)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Régimes et allergies %s.xlsx", synthese.Camp)
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
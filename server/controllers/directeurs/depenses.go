@@ -0,0 +1,71 @@
+package directeurs
+
+import (
+	"errors"
+	"strings"
+
+	"registro/logic"
+	cps "registro/sql/camps"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EquipiersDepensesGet renvoie les notes de frais des équipiers du séjour.
+func (ct *Controller) EquipiersDepensesGet(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadDepensesCamps(ct.db, ct.key, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+type EquipiersDepenseValideIn struct {
+	IdDepense   cps.IdDepense
+	Approuvee   bool
+	Commentaire string // motif du refus
+}
+
+// EquipiersDepenseValide approuve ou refuse une note de frais.
+// La décision peut être modifiée tant que la note n'a pas été
+// remboursée (ou convertie en don).
+func (ct *Controller) EquipiersDepenseValide(c echo.Context) error {
+	user := JWTUser(c)
+	var args EquipiersDepenseValideIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.valideDepense(user, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) valideDepense(user cps.IdCamp, args EquipiersDepenseValideIn) (cps.Depense, error) {
+	depense, err := cps.SelectDepense(ct.db, args.IdDepense)
+	if err != nil {
+		return cps.Depense{}, utils.SQLError(err)
+	}
+	equipier, err := cps.SelectEquipier(ct.db, depense.IdEquipier)
+	if err != nil {
+		return cps.Depense{}, utils.SQLError(err)
+	}
+	if equipier.IdCamp != user {
+		return cps.Depense{}, errors.New("access forbidden")
+	}
+	if depense.IsCloturee() {
+		return cps.Depense{}, errors.New("La note de frais a déjà été remboursée.")
+	}
+	depense.Statut = cps.DepenseRefusee
+	if args.Approuvee {
+		depense.Statut = cps.DepenseApprouvee
+	}
+	depense.Commentaire = strings.TrimSpace(args.Commentaire)
+	depense, err = depense.Update(ct.db)
+	if err != nil {
+		return cps.Depense{}, utils.SQLError(err)
+	}
+	return depense, nil
+}
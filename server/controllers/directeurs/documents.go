@@ -11,6 +11,7 @@ import (
 	filesAPI "registro/controllers/files"
 	fsAPI "registro/controllers/files"
 	"registro/generators/pdfcreator"
+	"registro/generators/sheets"
 	"registro/logic"
 	"registro/mails"
 	cps "registro/sql/camps"
@@ -24,9 +25,23 @@ import (
 
 var errNoDir = errors.New("Aucun directeur n'est déclaré pour ce camp !")
 
+var errConformiteNonValidee = errors.New("Le rapport d'encadrement doit être validé par le centre avant l'envoi des documents.")
+
+// isConformiteValidee renvoie true si la validation du rapport d'encadrement
+// par le centre porte sur le rapport actuel.
+func (ct *Controller) isConformiteValidee(idCamp cps.IdCamp) (bool, error) {
+	rapport, err := logic.LoadEncadrementCamp(ct.db, idCamp, ct.asso.Encadrement)
+	if err != nil {
+		return false, err
+	}
+	return rapport.ConformiteValidee, nil
+}
+
 type DocumentsOut struct {
 	Ready  bool
 	ToShow cps.DocumentsToShow
+	// requis pour passer [Ready] à true
+	ConformiteValidee bool
 	// à télécharger (n'inclut pas la lettre)
 	FilesToDownload []logic.PublicFile
 	CampDemandes    []DemandeDirecteur
@@ -59,6 +74,10 @@ func (ct *Controller) getDocuments(id cps.IdCamp) (DocumentsOut, error) {
 	if err != nil {
 		return DocumentsOut{}, err
 	}
+	conformiteValidee, err := ct.isConformiteValidee(camp.Id)
+	if err != nil {
+		return DocumentsOut{}, err
+	}
 
 	toDownload, err := fs.SelectFileCampsByIdCamps(ct.db, id)
 	if err != nil {
@@ -92,8 +111,9 @@ func (ct *Controller) getDocuments(id cps.IdCamp) (DocumentsOut, error) {
 	}
 
 	out := DocumentsOut{
-		Ready:  camp.DocumentsReady,
-		ToShow: camp.DocumentsToShow,
+		Ready:             camp.DocumentsReady,
+		ToShow:            camp.DocumentsToShow,
+		ConformiteValidee: conformiteValidee,
 	}
 	for _, link := range toDownload {
 		if link.IsLettre {
@@ -370,7 +390,7 @@ func (ct *Controller) deleteDemande(user cps.IdCamp, id fs.IdDemande) error {
 		filesToDelete = append(filesToDelete, file.Id)
 	}
 	return utils.InTx(ct.db, func(tx *sql.Tx) error {
-		_, err = fs.DeleteFilesByIDs(tx, filesToDelete...)
+		deleted, err := fs.DeleteFiles(tx, filesToDelete...)
 		if err != nil {
 			return err
 		}
@@ -378,7 +398,7 @@ func (ct *Controller) deleteDemande(user cps.IdCamp, id fs.IdDemande) error {
 		if err != nil {
 			return err
 		}
-		err = ct.files.Delete(filesToDelete...) // contenu
+		err = ct.files.Delete(tx, utils.MapValues(deleted)...) // contenu
 		if err != nil {
 			return err
 		}
@@ -466,6 +486,21 @@ func (ct *Controller) loadParticipantsFiles(id cps.IdCamp) (fsAPI.ParticipantsFi
 	return loader.For(id), nil
 }
 
+// DocumentsRevue accepte ou refuse un document envoyé
+// par un participant ou un équipier.
+func (ct *Controller) DocumentsRevue(c echo.Context) error {
+	user := JWTUser(c)
+	var args logic.RevueDocumentIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := logic.RevueDocument(ct.db, ct.key, ct.smtp, ct.asso, c.Request().Host, user, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
 // DocumentsStreamFiles télécharge tous les fichiers pour une [Demande],
 // dans une archive .ZIP
 func (ct *Controller) DocumentsStreamFiles(c echo.Context) error {
@@ -480,7 +515,7 @@ func (ct *Controller) DocumentsStreamFiles(c echo.Context) error {
 	}
 	return fsAPI.StreamZip(c.Response(), archiveName, func(yield func(fsAPI.ZipItem, error) bool) {
 		for _, file := range files {
-			content, err := ct.files.Load(file.file.Id, false)
+			content, err := ct.files.Load(file.file, false)
 			if err != nil {
 				yield(fsAPI.ZipItem{}, err)
 				return
@@ -562,7 +597,7 @@ func (ct *Controller) renderFichesSanitaires(user cps.IdCamp) ([]byte, string, e
 	}
 	fiches := tmp.ByIdPersonne()
 
-	responsables, err := pr.SelectPersonnes(ct.db, dossiers.IdResponsables()...)
+	responsables, err := pr.SelectPersonnes(ct.db, append(dossiers.IdResponsables(), dossiers.IdResponsable2s()...)...)
 	if err != nil {
 		return nil, "", utils.SQLError(err)
 	}
@@ -573,8 +608,12 @@ func (ct *Controller) renderFichesSanitaires(user cps.IdCamp) ([]byte, string, e
 		if !hasFiche || part.Personne.Age() >= 18 {
 			continue
 		}
-		responsable := responsables[dossiers[part.Participant.IdDossier].IdResponsable]
-		list = append(list, pdfcreator.FicheSanitaire{Personne: part.Personne.Identite, FicheSanitaire: fiche, Responsable: responsable.Identite})
+		dossier := dossiers[part.Participant.IdDossier]
+		page := pdfcreator.FicheSanitaire{Personne: part.Personne.Identite, FicheSanitaire: fiche, Responsable: responsables[dossier.IdResponsable].Identite}
+		if id := dossier.IdResponsable2; id.Valid {
+			page.Responsable2 = responsables[id.Id].Identite
+		}
+		list = append(list, page)
 	}
 	content, err := pdfcreator.CreateFicheSanitaires(ct.asso, list)
 	if err != nil {
@@ -635,6 +674,97 @@ func (ct *Controller) relanceDocuments(host string, idParticipants []cps.IdParti
 	return nil
 }
 
+// DocumentsCompletion renvoie l'état des documents de chaque inscrit.
+func (ct *Controller) DocumentsCompletion(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := ct.loadCompletion(user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) loadCompletion(id cps.IdCamp) (fsAPI.CompletionDocuments, error) {
+	loader, err := fsAPI.LoadParticipantsFiles(ct.db, ct.key, []cps.IdCamp{id})
+	if err != nil {
+		return fsAPI.CompletionDocuments{}, err
+	}
+	return loader.Completion(id), nil
+}
+
+// DocumentsRelanceIncomplets relance uniquement les familles
+// dont un document est manquant, périmé ou refusé, et renvoie
+// le nombre d'inscrits relancés.
+func (ct *Controller) DocumentsRelanceIncomplets(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := ct.relanceIncomplets(c.Request().Host, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) relanceIncomplets(host string, id cps.IdCamp) (int, error) {
+	completion, err := ct.loadCompletion(id)
+	if err != nil {
+		return 0, err
+	}
+	idParticipants := completion.ARelancer()
+	if len(idParticipants) == 0 {
+		return 0, nil
+	}
+	if err = ct.relanceDocuments(host, idParticipants); err != nil {
+		return 0, err
+	}
+	return len(idParticipants), nil
+}
+
+func (ct *Controller) DocumentsDownloadCompletion(c echo.Context) error {
+	user := JWTUser(c)
+	content, name, err := ct.renderCompletion(user)
+	if err != nil {
+		return err
+	}
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+var etatColors = [...]string{
+	fsAPI.Manquant: "#f8b7b7",
+	fsAPI.Envoye:   "#bde5b8",
+	fsAPI.Perime:   "#fbd9a5",
+	fsAPI.Refuse:   "#f8b7b7",
+}
+
+func (ct *Controller) renderCompletion(id cps.IdCamp) ([]byte, string, error) {
+	camp, err := cps.SelectCamp(ct.db, id)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	completion, err := ct.loadCompletion(id)
+	if err != nil {
+		return nil, "", err
+	}
+	headers := []string{"Participant"}
+	for _, colonne := range completion.Colonnes {
+		headers = append(headers, colonne.Title)
+	}
+	rows := make([][]sheets.Cell, len(completion.Lignes))
+	for i, ligne := range completion.Lignes {
+		row := []sheets.Cell{{Value: ligne.Personne, Bold: true}}
+		for _, etat := range ligne.Etats {
+			row = append(row, sheets.Cell{Value: etat.String(), Color: etatColors[etat]})
+		}
+		rows[i] = row
+	}
+	content, err := sheets.CreateTable(headers, rows)
+	if err != nil {
+		return nil, "", err
+	}
+	name := fmt.Sprintf("Documents %s.xlsx", camp.Label())
+	return content, name, nil
+}
+
 // Send API
 
 func (ct *Controller) DocumentsUnlock(c echo.Context) error {
@@ -644,6 +774,13 @@ func (ct *Controller) DocumentsUnlock(c echo.Context) error {
 	if err != nil {
 		return utils.SQLError(err)
 	}
+	validee, err := ct.isConformiteValidee(camp.Id)
+	if err != nil {
+		return err
+	}
+	if !validee {
+		return errConformiteNonValidee
+	}
 	// always unlock
 	camp.DocumentsReady = true
 	_, err = camp.Update(ct.db)
@@ -670,6 +807,14 @@ func (ct *Controller) unlockAndSendDocuments(host string, idCamp cps.IdCamp) (it
 		return nil, err
 	}
 
+	validee, err := ct.isConformiteValidee(idCamp)
+	if err != nil {
+		return nil, err
+	}
+	if !validee {
+		return nil, errConformiteNonValidee
+	}
+
 	// unlock
 	camp.Camp.DocumentsReady = true
 	_, err = camp.Camp.Update(ct.db)
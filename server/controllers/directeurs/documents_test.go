@@ -145,6 +145,15 @@ func TestDocuments(t *testing.T) {
 		files, _, err := ct.selectFilesForDemande(camp.Id, demande1.Demande.Id)
 		tu.AssertNoErr(t, err)
 		tu.Assert(t, len(files) == 2)
+
+		completion, err := ct.loadCompletion(camp.Id)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, len(completion.Colonnes) == 4) // fiche sanitaire + 3 demandes
+		tu.Assert(t, len(completion.Lignes) == 2)
+		tu.Assert(t, len(completion.ARelancer()) == 2) // vaccins manquants
+
+		_, _, err = ct.renderCompletion(camp.Id)
+		tu.AssertNoErr(t, err)
 	})
 
 	t.Run("relance", func(t *testing.T) {
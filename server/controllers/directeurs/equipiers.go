@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strings"
 
+	"registro/controllers/backoffice"
 	"registro/controllers/files"
 	"registro/crypto"
 	"registro/logic"
@@ -23,7 +24,7 @@ import (
 
 const (
 	EndpointDirecteur = "/directeurs"
-	EndpointEquipier  = "/equipier"
+	EndpointEquipier  = logic.EndpointEquipier
 )
 
 func (ct *Controller) EquipiersGet(c echo.Context) error {
@@ -38,8 +39,7 @@ func (ct *Controller) EquipiersGet(c echo.Context) error {
 }
 
 func equipierURL(key crypto.Encrypter, host string, id cps.IdEquipier) string {
-	token := crypto.EncryptID(key, id)
-	return utils.BuildUrl(host, EndpointEquipier, utils.QP("token", token))
+	return logic.EquipierURL(key, host, id)
 }
 
 type EquipierExt struct {
@@ -284,12 +284,18 @@ func (ct *Controller) deleteEquipier(id cps.IdEquipier, user cps.IdCamp) error {
 		return errors.New("access forbidden")
 	}
 
-	// Demandes will cascade
-	_, err = cps.DeleteEquipierById(ct.db, id)
-	if err != nil {
-		return utils.SQLError(err)
-	}
-	return nil
+	// Demandes and Depenses will cascade, but not the justificatifs
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		deleted, err := logic.DeleteJustificatifsDepenses(tx, id)
+		if err != nil {
+			return err
+		}
+		_, err = cps.DeleteEquipierById(tx, id)
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, utils.MapValues(deleted)...)
+	})
 }
 
 type DemandeState uint8
@@ -437,7 +443,7 @@ func (ct *Controller) EquipiersDownloadFiles(c echo.Context) error {
 }
 
 type fileAndPrefix struct {
-	id       fs.IdFile
+	file     fs.File
 	fullName string // including metadata from demande and equipier
 }
 
@@ -479,7 +485,7 @@ func (ct *Controller) compileFilesEquipiers(user cps.IdCamp) ([]fileAndPrefix, e
 			file := files[fileLink.IdFile]
 			demande := demandes[fileLink.IdDemande]
 			out = append(out, fileAndPrefix{
-				id:       fileLink.IdFile,
+				file:     file,
 				fullName: fmt.Sprintf("%s %s %s", demande.Categorie, personne.NOMPrenom(), file.NomClient),
 			})
 		}
@@ -503,7 +509,7 @@ func (ct *Controller) streamFilesEquipiers(user cps.IdCamp, resp http.ResponseWr
 
 	return files.StreamZip(resp, fmt.Sprintf("Documents Equipe %s.zip", camp.Label()), func(yield func(files.ZipItem, error) bool) {
 		for _, file := range toZip {
-			content, err := ct.files.Load(file.id, false)
+			content, err := ct.files.Load(file.file, false)
 			if err != nil {
 				yield(files.ZipItem{}, err)
 				return
@@ -514,3 +520,48 @@ func (ct *Controller) streamFilesEquipiers(user cps.IdCamp, resp http.ResponseWr
 		}
 	})
 }
+
+// EquipiersConformite renvoie le rapport de conformité de l'équipe
+// aux règles d'encadrement.
+func (ct *Controller) EquipiersConformite(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadEncadrementCamp(ct.db, user, ct.asso.Encadrement)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// EquipiersCertifications renvoie les certifications de l'équipe,
+// en signalant celles qui expirent avant la fin du séjour.
+func (ct *Controller) EquipiersCertifications(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadCertificationsCamp(ct.db, ct.key, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// EquipiersDeclaration renvoie les données de la déclaration du séjour
+// (TAM/SIAM), avec les informations manquantes.
+func (ct *Controller) EquipiersDeclaration(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadDeclarationCamp(ct.db, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// EquipiersDownloadDeclaration renvoie la déclaration du séjour,
+// au format Excel, ou CSV si le paramètre 'csv' vaut 'true'.
+func (ct *Controller) EquipiersDownloadDeclaration(c echo.Context) error {
+	user := JWTUser(c)
+	content, name, err := backoffice.ExportDeclarationCamp(ct.db, user, utils.QueryParamBool(c, "csv"))
+	if err != nil {
+		return err
+	}
+	mimeType := files.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
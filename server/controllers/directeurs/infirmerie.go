@@ -0,0 +1,53 @@
+package directeurs
+
+import (
+	"fmt"
+
+	fsAPI "registro/controllers/files"
+	"registro/generators/pdfcreator"
+	"registro/generators/sheets"
+	"registro/logic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InfirmerieGet renvoie les traitements des inscrits
+// et le cahier de soins rempli par l'assistant sanitaire.
+func (ct *Controller) InfirmerieGet(c echo.Context) error {
+	user := JWTUser(c)
+	out, err := logic.LoadRegistreInfirmerie(ct.db, user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) InfirmerieDownloadRegistre(c echo.Context) error {
+	user := JWTUser(c)
+	registre, err := logic.LoadRegistreInfirmerie(ct.db, user)
+	if err != nil {
+		return err
+	}
+	content, err := pdfcreator.CreateRegistreMedicaments(ct.asso, registre)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Registre des médicaments %s.pdf", registre.Camp.Label())
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+func (ct *Controller) InfirmerieDownloadCahier(c echo.Context) error {
+	user := JWTUser(c)
+	registre, err := logic.LoadRegistreInfirmerie(ct.db, user)
+	if err != nil {
+		return err
+	}
+	content, err := sheets.CahierSoins(registre)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Cahier de soins %s.xlsx", registre.Camp.Label())
+	mimeType := fsAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
@@ -180,13 +180,51 @@ func (ct *Controller) downloadFicheSanitaire(user cps.IdCamp, id cps.IdParticipa
 		return nil, "", utils.SQLError(err)
 	}
 
-	content, err := pdfcreator.CreateFicheSanitaires(ct.asso, []pdfcreator.FicheSanitaire{
-		{Personne: personne.Identite, FicheSanitaire: fiche, Responsable: responsable.Identite},
-	})
+	page := pdfcreator.FicheSanitaire{Personne: personne.Identite, FicheSanitaire: fiche, Responsable: responsable.Identite}
+	if id := dossier.IdResponsable2; id.Valid {
+		responsable2, err := pr.SelectPersonne(ct.db, id.Id)
+		if err != nil {
+			return nil, "", utils.SQLError(err)
+		}
+		page.Responsable2 = responsable2.Identite
+	}
+
+	content, err := pdfcreator.CreateFicheSanitaires(ct.asso, []pdfcreator.FicheSanitaire{page})
 	name := fmt.Sprintf("Fiche sanitaire %s.pdf", personne.NOMPrenom())
 	return content, name, nil
 }
 
+// ParticipantsFicheSanitaireHistorique renvoie les versions successives
+// de la fiche sanitaire d'un participant, avec les modifications apportées.
+func (ct *Controller) ParticipantsFicheSanitaireHistorique(c echo.Context) error {
+	user := JWTUser(c)
+	id, err := utils.QueryParamInt[cps.IdParticipant](c, "idParticipant")
+	if err != nil {
+		return err
+	}
+	out, err := ct.loadFicheSanitaireHistorique(user, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) loadFicheSanitaireHistorique(user cps.IdCamp, id cps.IdParticipant) ([]pr.VersionChangements, error) {
+	// check the access is legal
+	participant, err := cps.SelectParticipant(ct.db, id)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	if participant.IdCamp != user {
+		return nil, errors.New("access forbidden")
+	}
+	versions, err := pr.SelectFichesanitaireVersionsByIdPersonnes(ct.db, participant.IdPersonne)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	return versions.Historique(), nil
+}
+
 func (ct *Controller) ParticipantsMessagesLoad(c echo.Context) error {
 	user := JWTUser(c)
 	out, err := ct.loadMessages(user)
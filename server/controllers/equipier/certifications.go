@@ -0,0 +1,254 @@
+package equipier
+
+import (
+	"database/sql"
+	"errors"
+
+	filesAPI "registro/controllers/files"
+	"registro/crypto"
+	"registro/logic"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// certifications : saisies par l'équipier, avec leur justificatif,
+// et partagées entre ses différents séjours
+
+// loadCertification renvoie la certification [id] si elle appartient à l'équipier [idEquipier].
+func (ct *Controller) loadCertification(idEquipier cps.IdEquipier, id pr.IdCertification) (pr.Certification, error) {
+	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
+	if err != nil {
+		return pr.Certification{}, utils.SQLError(err)
+	}
+	certification, err := pr.SelectCertification(ct.db, id)
+	if err != nil {
+		return pr.Certification{}, utils.SQLError(err)
+	}
+	if certification.IdPersonne != equipier.IdPersonne {
+		return pr.Certification{}, errors.New("access forbidden")
+	}
+	return certification, nil
+}
+
+// CertificationsLoad renvoie les certifications de l'équipier.
+func (ct *Controller) CertificationsLoad(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	out, err := ct.loadCertifications(id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) loadCertifications(idEquipier cps.IdEquipier) (logic.CertificationsPersonne, error) {
+	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
+	if err != nil {
+		return logic.CertificationsPersonne{}, utils.SQLError(err)
+	}
+	return logic.LoadCertificationsPersonne(ct.db, ct.key, equipier.IdPersonne)
+}
+
+// CertificationsCreate ajoute une certification.
+func (ct *Controller) CertificationsCreate(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	var args pr.Certification
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.createCertification(id, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) createCertification(idEquipier cps.IdEquipier, args pr.Certification) (pr.Certification, error) {
+	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
+	if err != nil {
+		return pr.Certification{}, utils.SQLError(err)
+	}
+	certification, err := logic.CheckCertification(args)
+	if err != nil {
+		return pr.Certification{}, err
+	}
+	certification.IdPersonne = equipier.IdPersonne
+	certification, err = certification.Insert(ct.db)
+	if err != nil {
+		return pr.Certification{}, utils.SQLError(err)
+	}
+	return certification, nil
+}
+
+// CertificationsUpdate modifie une certification.
+func (ct *Controller) CertificationsUpdate(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	var args pr.Certification
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.updateCertification(id, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) updateCertification(idEquipier cps.IdEquipier, args pr.Certification) (pr.Certification, error) {
+	current, err := ct.loadCertification(idEquipier, args.Id)
+	if err != nil {
+		return pr.Certification{}, err
+	}
+	args, err = logic.CheckCertification(args)
+	if err != nil {
+		return pr.Certification{}, err
+	}
+	current.Kind = args.Kind
+	current.Label = args.Label
+	current.Obtention = args.Obtention
+	current.Expiration = args.Expiration
+	current, err = current.Update(ct.db)
+	if err != nil {
+		return pr.Certification{}, utils.SQLError(err)
+	}
+	return current, nil
+}
+
+// CertificationsDelete supprime une certification et son justificatif.
+func (ct *Controller) CertificationsDelete(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idCertification, err := utils.QueryParamInt[pr.IdCertification](c, "id")
+	if err != nil {
+		return err
+	}
+	err = ct.deleteCertification(id, idCertification)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) deleteCertification(idEquipier cps.IdEquipier, id pr.IdCertification) error {
+	if _, err := ct.loadCertification(idEquipier, id); err != nil {
+		return err
+	}
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		links, err := fs.DeleteFileCertificationsByIdCertifications(tx, id)
+		if err != nil {
+			return err
+		}
+		deleted, err := fs.DeleteFiles(tx, links.IdFiles()...)
+		if err != nil {
+			return err
+		}
+		_, err = pr.DeleteCertificationById(tx, id)
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, utils.MapValues(deleted)...)
+	})
+}
+
+// CertificationsUploadJustificatif ajoute ou remplace
+// le justificatif d'une certification.
+func (ct *Controller) CertificationsUploadJustificatif(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idCertification, err := utils.QueryParamInt[pr.IdCertification](c, "idCertification")
+	if err != nil {
+		return err
+	}
+	content, filename, err := filesAPI.ReadDocumentUploads(ct.files, c, "document")
+	if err != nil {
+		return err
+	}
+	out, err := ct.uploadCertificationJustificatif(id, idCertification, content, filename)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) uploadCertificationJustificatif(idEquipier cps.IdEquipier, idCertification pr.IdCertification, content []byte, filename string) (logic.PublicFile, error) {
+	if _, err := ct.loadCertification(idEquipier, idCertification); err != nil {
+		return logic.PublicFile{}, err
+	}
+	item, found, err := fs.SelectFileCertificationByIdCertification(ct.db, idCertification)
+	if err != nil {
+		return logic.PublicFile{}, utils.SQLError(err)
+	}
+	idFile := item.IdFile
+
+	var out logic.PublicFile
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		if !found { // create one file and a link
+			file, err := fs.File{}.Insert(tx)
+			if err != nil {
+				return err
+			}
+			err = fs.FileCertification{IdFile: file.Id, IdCertification: idCertification}.Insert(tx)
+			if err != nil {
+				return err
+			}
+			idFile = file.Id
+		}
+		file, err := fs.UploadFile(ct.files, tx, idFile, content, filename)
+		if err != nil {
+			return err
+		}
+		out = logic.NewPublicFile(ct.key, file)
+		return nil
+	})
+	return out, err
+}
+
+// CertificationsDeleteJustificatif supprime le justificatif d'une certification.
+func (ct *Controller) CertificationsDeleteJustificatif(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idCertification, err := utils.QueryParamInt[pr.IdCertification](c, "idCertification")
+	if err != nil {
+		return err
+	}
+	err = ct.deleteCertificationJustificatif(id, idCertification)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) deleteCertificationJustificatif(idEquipier cps.IdEquipier, idCertification pr.IdCertification) error {
+	if _, err := ct.loadCertification(idEquipier, idCertification); err != nil {
+		return err
+	}
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		links, err := fs.DeleteFileCertificationsByIdCertifications(tx, idCertification)
+		if err != nil {
+			return err
+		}
+		deleted, err := fs.DeleteFiles(tx, links.IdFiles()...)
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, utils.MapValues(deleted)...)
+	})
+}
@@ -3,6 +3,7 @@ package equipier
 import (
 	"database/sql"
 	"errors"
+	"slices"
 
 	"registro/config"
 	filesAPI "registro/controllers/files"
@@ -21,14 +22,14 @@ import (
 type Controller struct {
 	db *sql.DB
 
-	assoID string
+	asso   config.Asso
 	key    crypto.Encrypter
 	files  fs.FileSystem
 	immich config.Immich
 }
 
-func NewController(db *sql.DB, assoID string, key crypto.Encrypter, files fs.FileSystem, immich config.Immich) *Controller {
-	return &Controller{db, assoID, key, files, immich}
+func NewController(db *sql.DB, asso config.Asso, key crypto.Encrypter, files fs.FileSystem, immich config.Immich) *Controller {
+	return &Controller{db, asso, key, files, immich}
 }
 
 func (ct *Controller) Load(c echo.Context) error {
@@ -44,6 +45,27 @@ func (ct *Controller) Load(c echo.Context) error {
 	return c.JSON(200, out)
 }
 
+// checkRoles renvoie l'équipier du [token] s'il a
+// au moins l'un des [roles] demandés.
+func (ct *Controller) checkRoles(token string, roles ...cps.Role) (cps.Equipier, pr.Personne, error) {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, token)
+	if err != nil {
+		return cps.Equipier{}, pr.Personne{}, errors.New("Lien invalide.")
+	}
+	equipier, err := cps.SelectEquipier(ct.db, id)
+	if err != nil {
+		return cps.Equipier{}, pr.Personne{}, utils.SQLError(err)
+	}
+	if !slices.ContainsFunc(roles, equipier.Roles.Is) {
+		return cps.Equipier{}, pr.Personne{}, errors.New("access forbidden")
+	}
+	personne, err := pr.SelectPersonne(ct.db, equipier.IdPersonne)
+	if err != nil {
+		return cps.Equipier{}, pr.Personne{}, utils.SQLError(err)
+	}
+	return equipier, personne, nil
+}
+
 type Camp struct {
 	Nom       string
 	DateDebut shared.Date
@@ -54,6 +76,9 @@ type DemandeEquipier struct {
 	Demande     fs.Demande
 	Optionnelle bool
 	Files       []logic.PublicFile // uploaded by the user
+	// Manquant est true si aucun document valide (ni périmé, ni refusé)
+	// n'a été envoyé : le document doit être (re)envoyé.
+	Manquant bool
 }
 
 type EquipierExt struct {
@@ -65,6 +90,8 @@ type EquipierExt struct {
 	Camp Camp
 
 	Demandes []DemandeEquipier
+
+	Signatures []filesAPI.SignatureExt // documents signés électroniquement
 }
 
 func (ct *Controller) load(id cps.IdEquipier) (EquipierExt, error) {
@@ -100,14 +127,21 @@ func (ct *Controller) load(id cps.IdEquipier) (EquipierExt, error) {
 
 	demandes := make([]DemandeEquipier, len(links))
 	for i, link := range links {
+		files := files[equipier.IdPersonne][link.IdDemande]
 		demandes[i] = DemandeEquipier{
 			Demande:     demandesM[link.IdDemande],
 			Optionnelle: link.Optionnelle,
-			Files:       files[equipier.IdPersonne][link.IdDemande],
+			Files:       files,
+			Manquant:    filesAPI.IsDocumentManquant(files),
 		}
 	}
 
-	out := EquipierExt{equipier, personneBase.Identite, personneDetails, Camp{camp.Nom, camp.DateDebut, camp.Duree}, demandes}
+	signatures, err := filesAPI.LoadSignatures(ct.db, ct.key, equipier.IdPersonne)
+	if err != nil {
+		return EquipierExt{}, err
+	}
+
+	out := EquipierExt{equipier, personneBase.Identite, personneDetails, Camp{camp.Nom, camp.DateDebut, camp.Duree}, demandes, signatures}
 	return out, nil
 }
 
@@ -235,31 +269,58 @@ func (ct *Controller) update(id cps.IdEquipier, args UpdateIn) error {
 	})
 }
 
+type UpdateCharteIn struct {
+	Token  string
+	Accept bool
+	// Pour une acceptation, la version de la charte affichée et la signature
+	Version   filesAPI.VersionTexte
+	Signature filesAPI.SignatureIn
+}
+
 func (ct *Controller) UpdateCharte(c echo.Context) error {
-	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, token)
+	var args UpdateCharteIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, args.Token)
 	if err != nil {
 		return err
 	}
-	accept := utils.QueryParamBool(c, "accept")
-	err = ct.updateCharte(id, accept)
+	err = ct.updateCharte(id, args, c.RealIP())
 	if err != nil {
 		return err
 	}
 	return c.NoContent(200)
 }
 
-func (ct *Controller) updateCharte(id cps.IdEquipier, accept bool) error {
+func (ct *Controller) updateCharte(id cps.IdEquipier, args UpdateCharteIn, ip string) error {
 	equipier, err := cps.SelectEquipier(ct.db, id)
 	if err != nil {
 		return utils.SQLError(err)
 	}
-	equipier.AccepteCharte = sql.NullBool{Valid: true, Bool: accept}
-	_, err = equipier.Update(ct.db)
-	if err != nil {
-		return utils.SQLError(err)
+	var doc filesAPI.DocumentASigner
+	if args.Accept {
+		personne, err := pr.SelectPersonne(ct.db, equipier.IdPersonne)
+		if err != nil {
+			return utils.SQLError(err)
+		}
+		doc, err = filesAPI.TexteASigner(ct.asso, personne.Id, personne.PrenomNOM(), fs.CharteEquipier, args.Version)
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+	// la signature et l'acceptation sont enregistrées ensemble
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		if args.Accept {
+			_, _, err = filesAPI.Signe(tx, ct.files, ct.asso, doc, args.Signature, ip)
+			if err != nil {
+				return err
+			}
+		}
+		equipier.AccepteCharte = sql.NullBool{Valid: true, Bool: args.Accept}
+		_, err = equipier.Update(tx)
+		return err
+	})
 }
 
 func (ct *Controller) UploadDocument(c echo.Context) error {
@@ -272,34 +333,92 @@ func (ct *Controller) UploadDocument(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	header, err := c.FormFile("document")
+	content, filename, err := filesAPI.ReadDocumentUploads(ct.files, c, "document")
 	if err != nil {
 		return err
 	}
-	content, filename, err := filesAPI.ReadUpload(header)
+
+	filePub, err := ct.uploadDocument(idEquipier, idDemande, content, filename)
 	if err != nil {
 		return err
 	}
 
-	filePub, err := ct.uploadDocument(idEquipier, idDemande, content, filename)
+	return c.JSON(200, filePub)
+}
+
+func (ct *Controller) uploadDocument(idEquipier cps.IdEquipier, idDemande fs.IdDemande, content []byte, filename string) (filesAPI.UploadedFile, error) {
+	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
+	if err != nil {
+		return filesAPI.UploadedFile{}, utils.SQLError(err)
+	}
+
+	return filesAPI.UploadFileFor(ct.files, ct.db, ct.key, equipier.IdPersonne, idDemande, content, filename)
+}
+
+// LoadFormulaire renvoie les champs du document à remplir
+// en ligne pour une demande, pré-remplis pour l'équipier.
+func (ct *Controller) LoadFormulaire(c echo.Context) error {
+	token := c.QueryParam("token")
+	idEquipier, err := crypto.DecryptID[cps.IdEquipier](ct.key, token)
 	if err != nil {
 		return err
 	}
+	idDemande, err := utils.QueryParamInt[fs.IdDemande](c, "idDemande")
+	if err != nil {
+		return err
+	}
+	out, err := ct.loadFormulaire(idEquipier, idDemande)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
 
-	return c.JSON(200, filePub)
+func (ct *Controller) loadFormulaire(idEquipier cps.IdEquipier, idDemande fs.IdDemande) (filesAPI.Formulaire, error) {
+	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
+	if err != nil {
+		return filesAPI.Formulaire{}, utils.SQLError(err)
+	}
+	personne, err := pr.SelectPersonne(ct.db, equipier.IdPersonne)
+	if err != nil {
+		return filesAPI.Formulaire{}, utils.SQLError(err)
+	}
+	return filesAPI.LoadFormulaire(ct.db, ct.files, idDemande, personne.Identite)
+}
+
+type SaveFormulaireIn struct {
+	IdDemande fs.IdDemande
+	Valeurs   map[string]string // par nom de champ
+}
+
+// SaveFormulaire remplit le document de la demande et
+// l'enregistre comme document de l'équipier.
+func (ct *Controller) SaveFormulaire(c echo.Context) error {
+	token := c.QueryParam("token")
+	idEquipier, err := crypto.DecryptID[cps.IdEquipier](ct.key, token)
+	if err != nil {
+		return err
+	}
+	var args SaveFormulaireIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.saveFormulaire(idEquipier, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
 }
 
-func (ct *Controller) uploadDocument(idEquipier cps.IdEquipier, idDemande fs.IdDemande, content []byte, filename string) (logic.PublicFile, error) {
+func (ct *Controller) saveFormulaire(idEquipier cps.IdEquipier, args SaveFormulaireIn) (logic.PublicFile, error) {
 	equipier, err := cps.SelectEquipier(ct.db, idEquipier)
 	if err != nil {
 		return logic.PublicFile{}, utils.SQLError(err)
 	}
-
-	file, err := filesAPI.SaveFileFor(ct.files, ct.db, equipier.IdPersonne, idDemande, content, filename)
+	file, err := filesAPI.SaveFormulaireFor(ct.files, ct.db, equipier.IdPersonne, args.IdDemande, args.Valeurs)
 	if err != nil {
 		return logic.PublicFile{}, err
 	}
-
 	return logic.NewPublicFile(ct.key, file), nil
 }
 
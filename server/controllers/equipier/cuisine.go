@@ -0,0 +1,75 @@
+package equipier
+
+import (
+	"fmt"
+
+	filesAPI "registro/controllers/files"
+	"registro/generators/pdfcreator"
+	"registro/generators/sheets"
+	"registro/logic"
+	cps "registro/sql/camps"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// cuisine : régimes et allergies alimentaires, sans les autres données médicales
+
+// loadSyntheseCuisine vérifie que l'équipier a un rôle en cuisine
+// (ou de direction), et lit les paramètres optionnels "idGroupe" et "jours".
+func (ct *Controller) loadSyntheseCuisine(c echo.Context) (logic.SyntheseCuisine, error) {
+	equipier, _, err := ct.checkRoles(c.QueryParam("token"), cps.Cuisine, cps.Intendance, cps.Direction, cps.Adjoint)
+	if err != nil {
+		return logic.SyntheseCuisine{}, err
+	}
+	var filtre logic.FiltreCuisine
+	if c.QueryParam("idGroupe") != "" {
+		filtre.IdGroupe, err = utils.QueryParamInt[cps.IdGroupe](c, "idGroupe")
+		if err != nil {
+			return logic.SyntheseCuisine{}, err
+		}
+	}
+	filtre.Jours, err = utils.QueryParamInts[int32](c, "jours")
+	if err != nil {
+		return logic.SyntheseCuisine{}, err
+	}
+	return logic.LoadSyntheseCuisine(ct.db, equipier.IdCamp, filtre)
+}
+
+// CuisineLoad renvoie les régimes et allergies alimentaires
+// des inscrits, et le nombre de repas par jour.
+func (ct *Controller) CuisineLoad(c echo.Context) error {
+	out, err := ct.loadSyntheseCuisine(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) CuisineDownloadPDF(c echo.Context) error {
+	synthese, err := ct.loadSyntheseCuisine(c)
+	if err != nil {
+		return err
+	}
+	content, err := pdfcreator.CreateSyntheseCuisine(ct.asso, synthese)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Régimes et allergies %s.pdf", synthese.Camp)
+	mimeType := filesAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+func (ct *Controller) CuisineDownloadExcel(c echo.Context) error {
+	synthese, err := ct.loadSyntheseCuisine(c)
+	if err != nil {
+		return err
+	}
+	content, err := sheets.SyntheseCuisine(synthese)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Régimes et allergies %s.xlsx", synthese.Camp)
+	mimeType := filesAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
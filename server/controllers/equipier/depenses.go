@@ -0,0 +1,253 @@
+package equipier
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	filesAPI "registro/controllers/files"
+	"registro/crypto"
+	"registro/logic"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// notes de frais : saisie par l'équipier, avec les justificatifs
+
+var errDepenseTraitee = errors.New("La note de frais a déjà été traitée par le directeur.")
+
+// loadDepense renvoie la note de frais [id] si elle appartient à [idEquipier].
+func (ct *Controller) loadDepense(idEquipier cps.IdEquipier, id cps.IdDepense) (cps.Depense, error) {
+	depense, err := cps.SelectDepense(ct.db, id)
+	if err != nil {
+		return cps.Depense{}, utils.SQLError(err)
+	}
+	if depense.IdEquipier != idEquipier {
+		return cps.Depense{}, errors.New("access forbidden")
+	}
+	return depense, nil
+}
+
+// DepensesLoad renvoie les notes de frais de l'équipier.
+func (ct *Controller) DepensesLoad(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	out, err := logic.LoadDepensesEquipier(ct.db, ct.key, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// DepensesCreate dépose une nouvelle note de frais,
+// en attente de validation par le directeur.
+func (ct *Controller) DepensesCreate(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	var args cps.Depense
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.createDepense(id, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) createDepense(idEquipier cps.IdEquipier, args cps.Depense) (logic.DepenseExt, error) {
+	depense, err := logic.CheckDepense(args)
+	if err != nil {
+		return logic.DepenseExt{}, err
+	}
+	depense.IdEquipier = idEquipier
+	depense.Statut = cps.DepenseAttente
+	depense.Commentaire = ""
+	depense.Moment = time.Now()
+	depense, err = depense.Insert(ct.db)
+	if err != nil {
+		return logic.DepenseExt{}, utils.SQLError(err)
+	}
+	return logic.DepenseExt{Depense: depense}, nil
+}
+
+// DepensesUpdate modifie une note de frais, tant qu'elle
+// n'a pas été traitée.
+func (ct *Controller) DepensesUpdate(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	var args cps.Depense
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.updateDepense(id, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) updateDepense(idEquipier cps.IdEquipier, args cps.Depense) (cps.Depense, error) {
+	current, err := ct.loadDepense(idEquipier, args.Id)
+	if err != nil {
+		return cps.Depense{}, err
+	}
+	if !current.IsModifiable() {
+		return cps.Depense{}, errDepenseTraitee
+	}
+	args, err = logic.CheckDepense(args)
+	if err != nil {
+		return cps.Depense{}, err
+	}
+	current.Categorie = args.Categorie
+	current.Montant = args.Montant
+	current.Date = args.Date
+	current.Description = args.Description
+	current, err = current.Update(ct.db)
+	if err != nil {
+		return cps.Depense{}, utils.SQLError(err)
+	}
+	return current, nil
+}
+
+// DepensesDelete supprime une note de frais (et ses justificatifs),
+// tant qu'elle n'a pas été traitée.
+func (ct *Controller) DepensesDelete(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idDepense, err := utils.QueryParamInt[cps.IdDepense](c, "id")
+	if err != nil {
+		return err
+	}
+	err = ct.deleteDepense(id, idDepense)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) deleteDepense(idEquipier cps.IdEquipier, id cps.IdDepense) error {
+	depense, err := ct.loadDepense(idEquipier, id)
+	if err != nil {
+		return err
+	}
+	if !depense.IsModifiable() {
+		return errDepenseTraitee
+	}
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		links, err := fs.DeleteFileDepensesByIdDepenses(tx, id)
+		if err != nil {
+			return err
+		}
+		deleted, err := fs.DeleteFiles(tx, links.IdFiles()...)
+		if err != nil {
+			return err
+		}
+		_, err = cps.DeleteDepenseById(tx, id)
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, utils.MapValues(deleted)...)
+	})
+}
+
+// DepensesUploadJustificatif ajoute un justificatif à une note de frais.
+func (ct *Controller) DepensesUploadJustificatif(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idDepense, err := utils.QueryParamInt[cps.IdDepense](c, "idDepense")
+	if err != nil {
+		return err
+	}
+	content, filename, err := filesAPI.ReadDocumentUploads(ct.files, c, "document")
+	if err != nil {
+		return err
+	}
+	out, err := ct.uploadJustificatif(id, idDepense, content, filename)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) uploadJustificatif(idEquipier cps.IdEquipier, idDepense cps.IdDepense, content []byte, filename string) (logic.PublicFile, error) {
+	depense, err := ct.loadDepense(idEquipier, idDepense)
+	if err != nil {
+		return logic.PublicFile{}, err
+	}
+	if !depense.IsModifiable() {
+		return logic.PublicFile{}, errDepenseTraitee
+	}
+	var out logic.PublicFile
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		file, err := fs.File{}.Insert(tx)
+		if err != nil {
+			return err
+		}
+		err = fs.FileDepense{IdFile: file.Id, IdDepense: idDepense}.Insert(tx)
+		if err != nil {
+			return err
+		}
+		file, err = fs.UploadFile(ct.files, tx, file.Id, content, filename)
+		if err != nil {
+			return err
+		}
+		out = logic.NewPublicFile(ct.key, file)
+		return nil
+	})
+	return out, err
+}
+
+// DepensesDeleteJustificatif supprime un justificatif.
+func (ct *Controller) DepensesDeleteJustificatif(c echo.Context) error {
+	id, err := crypto.DecryptID[cps.IdEquipier](ct.key, c.QueryParam("token"))
+	if err != nil {
+		return errors.New("Lien invalide.")
+	}
+	idFile, err := crypto.DecryptID[fs.IdFile](ct.key, c.QueryParam("key"))
+	if err != nil {
+		return err
+	}
+	err = ct.deleteJustificatif(id, idFile)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) deleteJustificatif(idEquipier cps.IdEquipier, idFile fs.IdFile) error {
+	link, found, err := fs.SelectFileDepenseByIdFile(ct.db, idFile)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if !found {
+		return errors.New("access forbidden")
+	}
+	depense, err := ct.loadDepense(idEquipier, link.IdDepense)
+	if err != nil {
+		return err
+	}
+	if !depense.IsModifiable() {
+		return errDepenseTraitee
+	}
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		file, err := fs.DeleteFileById(tx, idFile) // cascade sur le lien
+		if err != nil {
+			return err
+		}
+		return ct.files.Delete(tx, file)
+	})
+}
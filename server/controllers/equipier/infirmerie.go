@@ -0,0 +1,145 @@
+package equipier
+
+import (
+	"errors"
+	"fmt"
+
+	filesAPI "registro/controllers/files"
+	"registro/generators/pdfcreator"
+	"registro/generators/sheets"
+	"registro/logic"
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+// infirmerie : registre des médicaments et cahier de soins
+
+// checkInfirmerie renvoie l'équipier du [token] s'il a accès au registre
+// de l'infirmerie (assistant sanitaire ou direction).
+func (ct *Controller) checkInfirmerie(token string) (cps.Equipier, pr.Personne, error) {
+	return ct.checkRoles(token, cps.Infirmerie, cps.Direction, cps.Adjoint)
+}
+
+// InfirmerieLoad renvoie les traitements des inscrits
+// et le cahier de soins du séjour.
+func (ct *Controller) InfirmerieLoad(c echo.Context) error {
+	equipier, _, err := ct.checkInfirmerie(c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	out, err := logic.LoadRegistreInfirmerie(ct.db, equipier.IdCamp)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// InfirmerieCreateSoin ajoute une entrée au cahier de soins.
+func (ct *Controller) InfirmerieCreateSoin(c echo.Context) error {
+	equipier, personne, err := ct.checkInfirmerie(c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	var args cps.Soin
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.createSoin(equipier, personne, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) createSoin(equipier cps.Equipier, auteur pr.Personne, args cps.Soin) (cps.Soin, error) {
+	soin, err := logic.CheckSoin(ct.db, equipier.IdCamp, args)
+	if err != nil {
+		return cps.Soin{}, err
+	}
+	soin.Auteur = auteur.PrenomNOM()
+	soin, err = soin.Insert(ct.db)
+	if err != nil {
+		return cps.Soin{}, utils.SQLError(err)
+	}
+	return soin, nil
+}
+
+// InfirmerieUpdateSoin corrige une entrée du cahier de soins.
+// Les entrées ne peuvent pas être supprimées.
+func (ct *Controller) InfirmerieUpdateSoin(c echo.Context) error {
+	equipier, _, err := ct.checkInfirmerie(c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	var args cps.Soin
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	err = ct.updateSoin(equipier, args)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
+
+func (ct *Controller) updateSoin(equipier cps.Equipier, args cps.Soin) error {
+	current, err := cps.SelectSoin(ct.db, args.Id)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if current.IdCamp != equipier.IdCamp {
+		return errors.New("access forbidden")
+	}
+	soin, err := logic.CheckSoin(ct.db, equipier.IdCamp, args)
+	if err != nil {
+		return err
+	}
+	soin.Auteur = current.Auteur // conservé pour la traçabilité
+	_, err = soin.Update(ct.db)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	return nil
+}
+
+// InfirmerieDownloadRegistre renvoie la grille imprimable
+// des médicaments à administrer.
+func (ct *Controller) InfirmerieDownloadRegistre(c echo.Context) error {
+	equipier, _, err := ct.checkInfirmerie(c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	registre, err := logic.LoadRegistreInfirmerie(ct.db, equipier.IdCamp)
+	if err != nil {
+		return err
+	}
+	content, err := pdfcreator.CreateRegistreMedicaments(ct.asso, registre)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Registre des médicaments %s.pdf", registre.Camp.Label())
+	mimeType := filesAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
+
+// InfirmerieDownloadCahier renvoie le cahier de soins au format Excel.
+func (ct *Controller) InfirmerieDownloadCahier(c echo.Context) error {
+	equipier, _, err := ct.checkInfirmerie(c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	registre, err := logic.LoadRegistreInfirmerie(ct.db, equipier.IdCamp)
+	if err != nil {
+		return err
+	}
+	content, err := sheets.CahierSoins(registre)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("Cahier de soins %s.xlsx", registre.Camp.Label())
+	mimeType := filesAPI.SetBlobHeader(c, content, name)
+	return c.Blob(200, mimeType, content)
+}
@@ -49,14 +49,32 @@ func NewController(db *sql.DB, key crypto.Encrypter, smtp config.SMTP, asso conf
 	return &Controller{db, key, smtp, asso, fs, immich}
 }
 
+// checkAcces décode [token] et vérifie les droits du second
+// responsable légal, le cas échéant : si [write] est true,
+// un accès en lecture seule est refusé.
+func (ct *Controller) checkAcces(token string, write bool) (logic.EspacePersoAcces, error) {
+	acces, err := logic.DecryptEspacePersoToken(ct.key, token)
+	if err != nil {
+		return acces, err
+	}
+	if !acces.IsResponsable2() {
+		return acces, nil
+	}
+	dossier, err := ds.SelectDossier(ct.db, acces.IdDossier)
+	if err != nil {
+		return acces, utils.SQLError(err)
+	}
+	return acces, logic.CheckAccesResponsable2(dossier, acces, write)
+}
+
 func (ct *Controller) Load(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
-		return errors.New("Lien invalide.")
+		return err
 	}
 
-	out, err := ct.load(id)
+	out, err := ct.load(acces)
 	if err != nil {
 		return err
 	}
@@ -74,6 +92,12 @@ type Data struct {
 	IsPaiementOpen bool
 	// EnableJustificatifs is true when all the camps have started (or ended)
 	EnableJustificatifs bool
+
+	// IsResponsable2 is true when the espace perso is accessed
+	// by the second responsable
+	IsResponsable2 bool
+	// IsReadOnly is true when modifications are not allowed
+	IsReadOnly bool
 }
 
 // PaiementSettings exposes the instruction to
@@ -86,7 +110,8 @@ type PaiementSettings struct {
 	Cheques                config.ChequeSettings
 }
 
-func (ct *Controller) load(id ds.IdDossier) (Data, error) {
+func (ct *Controller) load(acces logic.EspacePersoAcces) (Data, error) {
+	id := acces.IdDossier
 	dossier, err := logic.LoadDossiersFinance(ct.db, id)
 	if err != nil {
 		return Data{}, err
@@ -110,6 +135,8 @@ func (ct *Controller) load(id ds.IdDossier) (Data, error) {
 		ct.asso.SupportBonsCAF,
 		dossier.IsPaiementOpen(),
 		campInscritsStarted,
+		acces.IsResponsable2(),
+		logic.CheckAccesResponsable2(dossier.Dossier.Dossier, acces, true) != nil,
 	}, nil
 }
 
@@ -127,19 +154,23 @@ func (ct *Controller) SendMessage(c echo.Context) error {
 	if err := c.Bind(&args); err != nil {
 		return err
 	}
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, args.Token)
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
 		return err
 	}
-	out, err := ct.sendMessage(c.Request().Host, id, args.Message, args.OnlyToFondSoutien)
+	out, err := ct.sendMessage(c.Request().Host, acces, args.Message, args.OnlyToFondSoutien)
 	if err != nil {
 		return err
 	}
 	return c.JSON(200, out)
 }
 
-func (ct *Controller) sendMessage(host string, id ds.IdDossier, contenu string, onlyToFondsSoutien bool) (logic.Event, error) {
-	event, message, err := evs.CreateMessage(ct.db, id, time.Now(), evs.EventMessage{Contenu: contenu, Origine: evs.Espaceperso, OnlyToFondSoutien: onlyToFondsSoutien})
+func (ct *Controller) sendMessage(host string, acces logic.EspacePersoAcces, contenu string, onlyToFondsSoutien bool) (logic.Event, error) {
+	id := acces.IdDossier
+	event, message, err := evs.CreateMessage(ct.db, id, time.Now(), evs.EventMessage{
+		Contenu: contenu, Origine: evs.Espaceperso, OnlyToFondSoutien: onlyToFondsSoutien,
+		OrigineResponsable2: acces.IsResponsable2(),
+	})
 	if err != nil {
 		return logic.Event{}, utils.SQLError(err)
 	}
@@ -165,9 +196,13 @@ func (ct *Controller) notifieMessageByMail(host string, idDossier ds.IdDossier,
 	if err != nil {
 		return err
 	}
+	auteur := dossier.Responsable()
+	if resp2, has := dossier.Responsable2(); has && message.OrigineResponsable2 {
+		auteur = resp2
+	}
 	if message.OnlyToFondSoutien {
 		// notifie fonds soutien
-		html, err := mails.NotifieMessageFondsSoutien(ct.asso, dossier.Responsable().PrenomNOM(), message.Contenu, utils.BuildUrl(host, "/backoffice"))
+		html, err := mails.NotifieMessageFondsSoutien(ct.asso, auteur.PrenomNOM(), message.Contenu, utils.BuildUrl(host, "/backoffice"))
 		if err != nil {
 			return err
 		}
@@ -186,7 +221,7 @@ func (ct *Controller) notifieMessageByMail(host string, idDossier ds.IdDossier,
 		for _, dir := range equipiers.Direction() {
 			tos.Add(personnes[dir.IdPersonne].Mail)
 		}
-		html, err := mails.NotifieMessageDirecteurs(ct.asso, dossier.Responsable().PrenomNOM(), message.Contenu, utils.BuildUrl(host, "/directeurs"))
+		html, err := mails.NotifieMessageDirecteurs(ct.asso, auteur.PrenomNOM(), message.Contenu, utils.BuildUrl(host, "/directeurs"))
 		if err != nil {
 			return err
 		}
@@ -221,10 +256,11 @@ func (ct *Controller) UpdateParticipants(c echo.Context) error {
 }
 
 func (ct *Controller) updateParticipants(host string, args UpdateParticipantsIn) error {
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, args.Token)
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 	participants, err := cps.SelectParticipantsByIdDossiers(ct.db, id)
 	if err != nil {
 		return utils.SQLError(err)
@@ -296,10 +332,11 @@ func (ct *Controller) GetStructureaides(c echo.Context) error {
 // CreateAide déclare une aide (non validée).
 func (ct *Controller) CreateAide(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, true)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 
 	var args cps.Aide
 	err = utils.FormValueJSON(c, "aide", &args)
@@ -373,10 +410,11 @@ type PhotoAlbum struct {
 
 func (ct *Controller) LoadPhotos(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 	out, err := ct.loadPhotos(id)
 	if err != nil {
 		return err
@@ -424,10 +462,11 @@ func (ct *Controller) loadPhotos(id ds.IdDossier) ([]PhotoAlbum, error) {
 
 func (ct *Controller) LoadSondages(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 	out, err := ct.loadSondages(id)
 	if err != nil {
 		return err
@@ -501,10 +540,11 @@ func (ct *Controller) UpdateSondages(c echo.Context) error {
 	if err := c.Bind(&args); err != nil {
 		return err
 	}
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, args.Token)
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
 		return err
 	}
+	idDossier := acces.IdDossier
 	err = ct.updateSondage(idDossier, args.Id, args.IdCamp, args.Reponse)
 	if err != nil {
 		return err
@@ -541,10 +581,11 @@ func (ct *Controller) updateSondage(idDossier ds.IdDossier, idSondage cps.IdSond
 
 func (ct *Controller) DownloadAttestationPresence(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 	content, err := ct.renderAttestationPresence(id)
 	if err != nil {
 		return err
@@ -578,10 +619,11 @@ func (ct *Controller) renderAttestationPresence(id ds.IdDossier) ([]byte, error)
 
 func (ct *Controller) DownloadFacture(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
 		return err
 	}
+	id := acces.IdDossier
 	content, err := ct.renderFacture(id)
 	if err != nil {
 		return err
@@ -602,6 +644,9 @@ func (ct *Controller) renderFacture(id ds.IdDossier) ([]byte, error) {
 		CodePostal: responsable.CodePostal,
 		Ville:      responsable.Ville,
 	}
+	if responsable2, has := dossier.Responsable2(); has {
+		destinataire.CoResponsable = responsable2.NOMPrenom()
+	}
 	filtered, allStarted := dossier.ParticipantsExtReal() // restrict to inscrits with started camp
 	if !allStarted {
 		return nil, fmt.Errorf("internal error: some camps have not started")
@@ -624,10 +669,11 @@ func (ct *Controller) renderFacture(id ds.IdDossier) ([]byte, error) {
 
 func (ct *Controller) AcceptePlaceLiberee(c echo.Context) error {
 	token := c.QueryParam("token")
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, true)
 	if err != nil {
 		return err
 	}
+	idDossier := acces.IdDossier
 	idEvent, err := utils.QueryParamInt[evs.IdEvent](c, "idEvent")
 	if err != nil {
 		return err
@@ -300,10 +300,10 @@ func Test_sendMessage(t *testing.T) {
 
 	ct := Controller{db: db.DB, asso: asso, smtp: smtp}
 
-	_, err = ct.sendMessage("localhost", dossier.Id, "test \n sdlsmkdm", true)
+	_, err = ct.sendMessage("localhost", logic.EspacePersoAcces{IdDossier: dossier.Id}, "test \n sdlsmkdm", true)
 	tu.AssertNoErr(t, err)
 
-	_, err = ct.sendMessage("localhost", dossier.Id, "test \n sdlsmkdm", false)
+	_, err = ct.sendMessage("localhost", logic.EspacePersoAcces{IdDossier: dossier.Id}, "test \n sdlsmkdm", false)
 	tu.AssertNoErr(t, err)
 
 	time.Sleep(200 * time.Millisecond) // finish notification
@@ -1,6 +1,7 @@
 package espaceperso
 
 import (
+	"database/sql"
 	"errors"
 	"slices"
 	"time"
@@ -28,6 +29,7 @@ type Documents struct {
 	FilesToUpload []DemandesPersonne // including vaccins
 	Fiches        []FichesanitaireExt
 	Chartes       []Charte
+	Signatures    []filesAPI.SignatureExt // documents signés électroniquement
 
 	NewCount int
 }
@@ -36,7 +38,7 @@ func (docs *Documents) setCounts(lastLoaded time.Time, events logic.Events) {
 	toFill := 0
 	for _, personne := range docs.FilesToUpload {
 		for _, demande := range personne.Demandes {
-			if len(demande.Uploaded) == 0 {
+			if demande.isMissing() {
 				toFill++
 			}
 		}
@@ -98,12 +100,17 @@ type DemandePersonne struct {
 	Uploaded    []logic.PublicFile
 }
 
+// isMissing renvoie true si aucun document valide (ni périmé, ni refusé)
+// n'a été envoyé.
+func (dp DemandePersonne) isMissing() bool { return filesAPI.IsDocumentManquant(dp.Uploaded) }
+
 func (ct *Controller) LoadDocuments(c echo.Context) error {
 	token := c.QueryParam("token")
-	id, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, false)
 	if err != nil {
-		return errors.New("Lien invalide.")
+		return err
 	}
+	id := acces.IdDossier
 	out, err := ct.markAndloadDocuments(id)
 	if err != nil {
 		return err
@@ -192,7 +199,18 @@ func loadDocuments(db ds.DB, key crypto.Encrypter, dossier logic.Dossier) (Docum
 		return Documents{}, err
 	}
 
-	idsDemandes := append(links2.IdDemandes(), demandeVaccin.Id)
+	// and the PAI document, when declared in the fiche sanitaire
+	demandePAI, err := filesAPI.DemandePAI(db)
+	if err != nil {
+		return Documents{}, err
+	}
+	fiches, err := pr.SelectFichesanitairesByIdPersonnes(db, dossier.Participants.IdPersonnes()...)
+	if err != nil {
+		return Documents{}, utils.SQLError(err)
+	}
+	fichesByPersonne := fiches.ByIdPersonne()
+
+	idsDemandes := append(links2.IdDemandes(), demandeVaccin.Id, demandePAI.Id)
 	personnesFiles, demandes, err := filesAPI.LoadFilesPersonnes(db, key, idsDemandes, dossier.Participants.IdPersonnes()...)
 	if err != nil {
 		return Documents{}, err
@@ -229,6 +247,12 @@ func loadDocuments(db ds.DB, key crypto.Encrypter, dossier logic.Dossier) (Docum
 					Demande:  demandeVaccin,
 					Uploaded: personnesFiles[personne.Id][demandeVaccin.Id],
 				})
+				if fichesByPersonne[personne.Id].PAI {
+					item.Demandes = append(item.Demandes, DemandePersonne{
+						Demande:  demandePAI,
+						Uploaded: personnesFiles[personne.Id][demandePAI.Id],
+					})
+				}
 			}
 		}
 		// do not include empty lists
@@ -263,16 +287,22 @@ func loadDocuments(db ds.DB, key crypto.Encrypter, dossier logic.Dossier) (Docum
 		})
 	}
 
+	out.Signatures, err = filesAPI.LoadSignatures(db, key, dossier.Participants.IdPersonnes()...)
+	if err != nil {
+		return Documents{}, err
+	}
+
 	out.setCounts(dossier.Dossier.LastLoadDocuments, dossier.Events)
 	return out, nil
 }
 
 func (ct *Controller) UploadDocument(c echo.Context) error {
 	token := c.QueryParam("token")
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, true)
 	if err != nil {
-		return errors.New("Lien invalide.")
+		return err
 	}
+	idDossier := acces.IdDossier
 	idDemande, err := utils.QueryParamInt[fs.IdDemande](c, "idDemande")
 	if err != nil {
 		return err
@@ -281,11 +311,7 @@ func (ct *Controller) UploadDocument(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	header, err := c.FormFile("file")
-	if err != nil {
-		return err
-	}
-	content, filename, err := filesAPI.ReadUpload(header)
+	content, filename, err := filesAPI.ReadDocumentUploads(ct.files, c, "file")
 	if err != nil {
 		return err
 	}
@@ -298,16 +324,100 @@ func (ct *Controller) UploadDocument(c echo.Context) error {
 
 func (ct *Controller) uploadDocument(idDossier ds.IdDossier, idDemande fs.IdDemande, idPersonne pr.IdPersonne,
 	content []byte, filename string,
-) (logic.PublicFile, error) {
+) (filesAPI.UploadedFile, error) {
 	dossier, err := logic.LoadDossier(ct.db, idDossier)
 	if err != nil {
-		return logic.PublicFile{}, err
+		return filesAPI.UploadedFile{}, err
 	}
 	// basic security check
 	if hasPersonne := slices.Contains(dossier.Participants.IdPersonnes(), idPersonne); !hasPersonne {
-		return logic.PublicFile{}, errors.New("access forbidden")
+		return filesAPI.UploadedFile{}, errors.New("access forbidden")
+	}
+	return filesAPI.UploadFileFor(ct.files, ct.db, ct.key, idPersonne, idDemande, content, filename)
+}
+
+// LoadFormulaire renvoie les champs du document à remplir
+// en ligne pour une demande, pré-remplis pour le participant.
+func (ct *Controller) LoadFormulaire(c echo.Context) error {
+	token := c.QueryParam("token")
+	acces, err := ct.checkAcces(token, true)
+	if err != nil {
+		return err
+	}
+	idDemande, err := utils.QueryParamInt[fs.IdDemande](c, "idDemande")
+	if err != nil {
+		return err
+	}
+	idPersonne, err := utils.QueryParamInt[pr.IdPersonne](c, "idPersonne")
+	if err != nil {
+		return err
+	}
+	out, err := ct.loadFormulaire(acces.IdDossier, idDemande, idPersonne)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+// participantFor vérifie que la personne est un participant du dossier
+func participantFor(dossier logic.Dossier, idPersonne pr.IdPersonne) (pr.Personne, error) {
+	if hasPersonne := slices.Contains(dossier.Participants.IdPersonnes(), idPersonne); !hasPersonne {
+		return pr.Personne{}, errors.New("access forbidden")
+	}
+	for _, personne := range dossier.Personnes() {
+		if personne.Id == idPersonne {
+			return personne, nil
+		}
+	}
+	return pr.Personne{}, errors.New("access forbidden")
+}
+
+func (ct *Controller) loadFormulaire(idDossier ds.IdDossier, idDemande fs.IdDemande, idPersonne pr.IdPersonne) (filesAPI.Formulaire, error) {
+	dossier, err := logic.LoadDossier(ct.db, idDossier)
+	if err != nil {
+		return filesAPI.Formulaire{}, err
 	}
-	file, err := filesAPI.SaveFileFor(ct.files, ct.db, idPersonne, idDemande, content, filename)
+	personne, err := participantFor(dossier, idPersonne)
+	if err != nil {
+		return filesAPI.Formulaire{}, err
+	}
+	return filesAPI.LoadFormulaire(ct.db, ct.files, idDemande, personne.Identite)
+}
+
+type SaveFormulaireIn struct {
+	IdPersonne pr.IdPersonne
+	IdDemande  fs.IdDemande
+	Valeurs    map[string]string // par nom de champ
+}
+
+// SaveFormulaire remplit le document de la demande et
+// l'enregistre comme document du participant.
+func (ct *Controller) SaveFormulaire(c echo.Context) error {
+	token := c.QueryParam("token")
+	acces, err := ct.checkAcces(token, true)
+	if err != nil {
+		return err
+	}
+	var args SaveFormulaireIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.saveFormulaire(acces.IdDossier, args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) saveFormulaire(idDossier ds.IdDossier, args SaveFormulaireIn) (logic.PublicFile, error) {
+	dossier, err := logic.LoadDossier(ct.db, idDossier)
+	if err != nil {
+		return logic.PublicFile{}, err
+	}
+	if _, err = participantFor(dossier, args.IdPersonne); err != nil {
+		return logic.PublicFile{}, err
+	}
+	file, err := filesAPI.SaveFormulaireFor(ct.files, ct.db, args.IdPersonne, args.IdDemande, args.Valeurs)
 	if err != nil {
 		return logic.PublicFile{}, err
 	}
@@ -315,48 +425,139 @@ func (ct *Controller) uploadDocument(idDossier ds.IdDossier, idDemande fs.IdDema
 }
 
 func (ct *Controller) DeleteDocument(c echo.Context) error {
+	token := c.QueryParam("token")
+	acces, err := ct.checkAcces(token, true)
+	if err != nil {
+		return err
+	}
 	key := c.QueryParam("key")
-	_, err := filesAPI.Delete(ct.db, ct.key, ct.files, key)
+	err = ct.deleteDocument(acces.IdDossier, key)
 	if err != nil {
 		return err
 	}
 	return c.NoContent(200)
 }
 
+func (ct *Controller) deleteDocument(idDossier ds.IdDossier, key string) error {
+	idFile, err := crypto.DecryptID[fs.IdFile](ct.key, key)
+	if err != nil {
+		return err
+	}
+	link, found, err := fs.SelectFilePersonneByIdFile(ct.db, idFile)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	dossier, err := logic.LoadDossier(ct.db, idDossier)
+	if err != nil {
+		return err
+	}
+	// basic security check
+	if hasPersonne := slices.Contains(dossier.Participants.IdPersonnes(), link.IdPersonne); !found || !hasPersonne {
+		return errors.New("access forbidden")
+	}
+	_, err = filesAPI.Delete(ct.db, ct.key, ct.files, key)
+	return err
+}
+
+// SigneTexteIn signe électroniquement un document texte
+// (charte, autorisation parentale) pour un participant.
+type SigneTexteIn struct {
+	Token      string
+	IdPersonne pr.IdPersonne
+	Version    filesAPI.VersionTexte // version du document affichée et acceptée
+	Signature  filesAPI.SignatureIn
+}
+
+// AccepteCharte enregistre la signature de la charte du participant.
 func (ct *Controller) AccepteCharte(c echo.Context) error {
-	token := c.QueryParam("token")
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	var args SigneTexteIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
-		return errors.New("Lien invalide.")
+		return err
 	}
-	idPersonne, err := utils.QueryParamInt[pr.IdPersonne](c, "idPersonne")
+	out, err := ct.accepteCharte(acces.IdDossier, args, c.RealIP())
 	if err != nil {
 		return err
 	}
-	err = ct.accepteCharte(idDossier, idPersonne)
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) accepteCharte(idDossier ds.IdDossier, args SigneTexteIn, ip string) (filesAPI.SignatureExt, error) {
+	doc, err := ct.texteASigner(idDossier, fs.CharteParticipant, args)
 	if err != nil {
+		return filesAPI.SignatureExt{}, err
+	}
+	// la signature et l'acceptation sont enregistrées ensemble
+	var (
+		file      fs.File
+		signature fs.Signature
+	)
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		file, signature, err = filesAPI.Signe(tx, ct.files, ct.asso, doc, args.Signature, ip)
+		if err != nil {
+			return err
+		}
+		personne, err := pr.SelectPersonne(tx, doc.IdPersonne)
+		if err != nil {
+			return err
+		}
+		personne.CharteAccepted = signature.Moment
+		_, err = personne.Update(tx)
 		return err
+	})
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
 	}
-	return c.NoContent(200)
+	return filesAPI.NewSignatureExt(ct.key, signature, file), nil
 }
 
-func (ct *Controller) accepteCharte(idDossier ds.IdDossier, idPersonne pr.IdPersonne) error {
-	dossier, err := logic.LoadDossier(ct.db, idDossier)
+// SigneAutorisation enregistre la signature de l'autorisation parentale.
+func (ct *Controller) SigneAutorisation(c echo.Context) error {
+	var args SigneTexteIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
 		return err
 	}
-	// check Id is valid
-	if !slices.Contains(dossier.Participants.IdPersonnes(), idPersonne) {
-		return errors.New("access forbidden")
+	doc, err := ct.texteASigner(acces.IdDossier, fs.AutorisationParentale, args)
+	if err != nil {
+		return err
 	}
-	personne, err := pr.SelectPersonne(ct.db, idPersonne)
+	out, err := ct.signe(doc, args.Signature, c.RealIP())
 	if err != nil {
-		return utils.SQLError(err)
+		return err
 	}
-	personne.CharteAccepted = time.Now()
-	_, err = personne.Update(ct.db)
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) texteASigner(idDossier ds.IdDossier, document fs.DocumentSigne, args SigneTexteIn) (filesAPI.DocumentASigner, error) {
+	dossier, err := logic.LoadDossier(ct.db, idDossier)
 	if err != nil {
-		return utils.SQLError(err)
+		return filesAPI.DocumentASigner{}, err
+	}
+	personne, err := participantFor(dossier, args.IdPersonne)
+	if err != nil {
+		return filesAPI.DocumentASigner{}, err
+	}
+	return filesAPI.TexteASigner(ct.asso, personne.Id, personne.PrenomNOM(), document, args.Version)
+}
+
+func (ct *Controller) signe(doc filesAPI.DocumentASigner, args filesAPI.SignatureIn, ip string) (filesAPI.SignatureExt, error) {
+	var (
+		file      fs.File
+		signature fs.Signature
+	)
+	err := utils.InTx(ct.db, func(tx *sql.Tx) (err error) {
+		file, signature, err = filesAPI.Signe(tx, ct.files, ct.asso, doc, args, ip)
+		return err
+	})
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
 	}
-	return nil
+	return filesAPI.NewSignatureExt(ct.key, signature, file), nil
 }
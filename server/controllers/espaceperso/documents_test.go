@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"registro/config"
+	filesAPI "registro/controllers/files"
 	"registro/crypto"
+	"registro/generators/pdfcreator"
 	cps "registro/sql/camps"
 	ds "registro/sql/dossiers"
 	fs "registro/sql/files"
@@ -16,6 +18,9 @@ import (
 )
 
 func TestDocuments(t *testing.T) {
+	err := pdfcreator.Init(os.TempDir(), "../../assets")
+	tu.AssertNoErr(t, err)
+
 	db := tu.NewTestDB(t, "../../migrations/create_1_tables.sql",
 		"../../migrations/create_2_json_funcs.sql", "../../migrations/create_3_constraints.sql",
 		"../../migrations/init.sql")
@@ -71,11 +76,33 @@ func TestDocuments(t *testing.T) {
 	_, err = ct.uploadDocument(dossier.Id, d1.Id, pe1.Id, tu.PngData, "test.png")
 	tu.AssertNoErr(t, err)
 
-	err = ct.accepteCharte(dossier.Id, pe1.Id)
+	charte := SigneTexteIn{IdPersonne: pe1.Id, Version: "charte-participant-v1"}
+	_, err = ct.accepteCharte(dossier.Id, charte, "127.0.0.1")
+	tu.AssertErr(t, err) // missing signature
+
+	charte.Version = "charte-equipier-acve-v1"
+	charte.Signature = filesAPI.SignatureIn{Signataire: "Marie Dupont", Consentement: true}
+	_, err = ct.accepteCharte(dossier.Id, charte, "127.0.0.1")
+	tu.AssertErr(t, err) // invalid version
+	charte.Version = "charte-participant-v1"
+
+	signature, err := ct.accepteCharte(dossier.Id, charte, "127.0.0.1")
 	tu.AssertNoErr(t, err)
+	tu.Assert(t, signature.Document == fs.CharteParticipant)
 
 	docs, err = ct.markAndloadDocuments(dossier.Id)
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, len(docs.FilesToUpload[0].Demandes[0].Uploaded) == 1)
+	tu.Assert(t, len(docs.Signatures) == 1)
 	tu.Assert(t, docs.NewCount == 3+1+1)
+
+	// the file must belong to the dossier
+	other, err := ds.Dossier{IdTaux: 1, IdResponsable: pe4.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	uploaded, err := ct.uploadDocument(dossier.Id, d1.Id, pe2.Id, tu.PngData, "test.png")
+	tu.AssertNoErr(t, err)
+	err = ct.deleteDocument(other.Id, uploaded.File.Key)
+	tu.AssertErr(t, err)
+	err = ct.deleteDocument(dossier.Id, uploaded.File.Key)
+	tu.AssertNoErr(t, err)
 }
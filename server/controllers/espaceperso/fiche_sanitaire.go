@@ -2,16 +2,22 @@ package espaceperso
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"log"
 	"slices"
 	"strings"
 	"time"
 
+	"registro/controllers/directeurs"
+	filesAPI "registro/controllers/files"
 	"registro/controllers/services"
-	"registro/crypto"
+	"registro/generators/pdfcreator"
 	"registro/logic"
 	"registro/mails"
+	cps "registro/sql/camps"
 	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
 	pr "registro/sql/personnes"
 	"registro/utils"
 
@@ -37,10 +43,20 @@ type FichesanitaireExt struct {
 
 	ResponsableNom  string
 	ResponsableTels pr.Tels
+
+	Responsable2Nom  string // optionnel
+	Responsable2Tels pr.Tels
 }
 
 func loadFichesanitaires(db ds.DB, dossier logic.Dossier) (out []FichesanitaireExt, _ error) {
 	responsable := dossier.Responsable()
+	var (
+		responsable2Nom  string
+		responsable2Tels pr.Tels
+	)
+	if responsable2, has := dossier.Responsable2(); has {
+		responsable2Nom, responsable2Tels = responsable2.PrenomNOM(), responsable2.Tels
+	}
 	idPersonnes := dossier.Participants.IdPersonnes()
 
 	fiches, err := pr.SelectFichesanitairesByIdPersonnes(db, idPersonnes...)
@@ -61,11 +77,13 @@ func loadFichesanitaires(db ds.DB, dossier logic.Dossier) (out []FichesanitaireE
 		fiche.IdPersonne = pers.Id // init ID for empty fiche
 		fsExt := FichesanitaireExt{
 			pers.PrenomN(),
-			isFichesanitaireLocked(responsable.Mail, fiche.Owners),
+			isFichesanitaireLockedFor(dossier, fiche.Owners),
 			fiche.State(dossier.Dossier.MomentInscription),
 			fiche,
 			responsable.PrenomNOM(),
 			responsable.Tels,
+			responsable2Nom,
+			responsable2Tels,
 		}
 		if fsExt.IsLocked { // hide sensitive information
 			fsExt.Fichesanitaire = pr.Fichesanitaire{
@@ -89,7 +107,7 @@ func (ct *Controller) UpdateFichesanitaire(c echo.Context) error {
 	if err := c.Bind(&args); err != nil {
 		return err
 	}
-	err := ct.updateFichesanitaire(args)
+	err := ct.updateFichesanitaire(c.Request().Host, args)
 	if err != nil {
 		return err
 	}
@@ -108,16 +126,28 @@ func isFichesanitaireLocked(mailRespo string, mails []string) bool {
 	return true
 }
 
-func (ct *Controller) updateFichesanitaire(args UpdateFichesanitaireIn) error {
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, args.Token)
+// isFichesanitaireLockedFor returns true if none of the
+// responsables of the dossier owns the fiche.
+func isFichesanitaireLockedFor(dossier logic.Dossier, mails []string) bool {
+	locked := isFichesanitaireLocked(dossier.Responsable().Mail, mails)
+	if responsable2, has := dossier.Responsable2(); has {
+		locked = locked && isFichesanitaireLocked(responsable2.Mail, mails)
+	}
+	return locked
+}
+
+// updateFichesanitaire enregistre la fiche et sa nouvelle version.
+// Les directeurs des séjours proches sont prévenus des modifications.
+func (ct *Controller) updateFichesanitaire(host string, args UpdateFichesanitaireIn) error {
+	acces, err := ct.checkAcces(args.Token, true)
 	if err != nil {
 		return err
 	}
-	dossier, err := logic.LoadDossier(ct.db, idDossier)
+	dossier, err := logic.LoadDossier(ct.db, acces.IdDossier)
 	if err != nil {
 		return err
 	}
-	responsable := dossier.Responsable()
+	responsable := dossier.ResponsableFor(acces)
 	idPersonne := args.Fichesanitaire.IdPersonne
 	// check Id is valid
 	if !slices.Contains(dossier.Participants.IdPersonnes(), idPersonne) {
@@ -129,7 +159,7 @@ func (ct *Controller) updateFichesanitaire(args UpdateFichesanitaireIn) error {
 		return utils.SQLError(err)
 	}
 
-	if isFichesanitaireLocked(responsable.Mail, fs.Owners) {
+	if isFichesanitaireLockedFor(dossier, fs.Owners) {
 		return errors.New("access forbidden")
 	}
 
@@ -140,20 +170,94 @@ func (ct *Controller) updateFichesanitaire(args UpdateFichesanitaireIn) error {
 	args.Fichesanitaire.Owners = fs.Owners
 	args.Fichesanitaire.Modified = time.Now()
 
-	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+	version := args.Fichesanitaire.Version(responsable.Mail)
+	changements := version.Diff(fs.Version(""))
+
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
 		_, err = pr.DeleteFichesanitairesByIdPersonnes(tx, idPersonne)
 		if err != nil {
 			return err
 		}
 		err = args.Fichesanitaire.Insert(tx)
+		if err != nil {
+			return err
+		}
+		_, err = version.Insert(tx)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	if len(changements) == 0 {
+		return nil
+	}
+	go func() {
+		err := ct.notifieModificationFiche(host, idPersonne, changements)
+		if err != nil {
+			log.Println("espaceperso.Controller.notifieModificationFiche", idPersonne, err)
+		}
+	}()
+	return nil
+}
+
+// notifieModificationFiche envoie un mail aux directeurs des séjours
+// commençant dans moins de [updateLimitation], où [idPersonne] est inscrite.
+func (ct *Controller) notifieModificationFiche(host string, idPersonne pr.IdPersonne, changements []pr.ChangementFiche) error {
+	participants, err := cps.SelectParticipantsByIdPersonnes(ct.db, idPersonne)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	camps, err := cps.SelectCamps(ct.db, participants.IdCamps()...)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	tmp, err := cps.SelectEquipiersByIdCamps(ct.db, camps.IDs()...)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	equipiersbyCamp := tmp.ByIdCamp()
+	personnes, err := pr.SelectPersonnes(ct.db, append(tmp.IdPersonnes(), idPersonne)...)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+
+	rubriques := make([]string, len(changements))
+	for i, changement := range changements {
+		rubriques[i] = changement.Rubrique
+	}
+	urlDirecteur := utils.BuildUrl(host, directeurs.EndpointDirecteur)
+	participant := personnes[idPersonne].PrenomNOM()
+
+	for _, part := range participants {
+		if part.Statut != cps.Inscrit {
+			continue
+		}
+		camp := camps[part.IdCamp]
+		if camp.IsPassedBy(0) || time.Until(camp.DateDebut.Time()) >= updateLimitation {
+			continue
+		}
+		dir, hasDir := equipiersbyCamp[camp.Id].Directeur()
+		if !hasDir {
+			continue
+		}
+		directeur := personnes[dir.IdPersonne]
+		html, err := mails.NotifieModificationFiche(ct.asso, directeur.Identite, camp.Label(), participant, rubriques, urlDirecteur)
+		if err != nil {
+			return err
+		}
+		err = mails.NewMailer(ct.smtp, ct.asso.MailsSettings).SendMail(directeur.Mail, "Modification d'une fiche sanitaire", html, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // TransfertFicheSanitaire envoie un mail de demande de transfert
 func (ct *Controller) TransfertFicheSanitaire(c echo.Context) error {
 	token := c.QueryParam("token")
-	idDossier, err := crypto.DecryptID[ds.IdDossier](ct.key, token)
+	acces, err := ct.checkAcces(token, true)
 	if err != nil {
 		return err
 	}
@@ -161,7 +265,7 @@ func (ct *Controller) TransfertFicheSanitaire(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	err = ct.transfertFicheSanitaire(c.Request().Host, idDossier, idPersonne)
+	err = ct.transfertFicheSanitaire(c.Request().Host, acces, idPersonne)
 	if err != nil {
 		return err
 	}
@@ -173,8 +277,8 @@ type transfertFicheSanitaireArgs struct {
 	NewMail    string        // le nouvel accès à autoriser
 }
 
-func (ct *Controller) transfertFicheSanitaire(host string, idDossier ds.IdDossier, idPersonne pr.IdPersonne) error {
-	dossier, err := logic.LoadDossier(ct.db, idDossier)
+func (ct *Controller) transfertFicheSanitaire(host string, acces logic.EspacePersoAcces, idPersonne pr.IdPersonne) error {
+	dossier, err := logic.LoadDossier(ct.db, acces.IdDossier)
 	if err != nil {
 		return err
 	}
@@ -193,7 +297,7 @@ func (ct *Controller) transfertFicheSanitaire(host string, idDossier ds.IdDossie
 	if !ok {
 		return errors.New("internal error: missing Fichesanitaire")
 	}
-	newMail := dossier.Responsable().Mail
+	newMail := dossier.ResponsableFor(acces).Mail
 	token, err := ct.key.EncryptJSON(transfertFicheSanitaireArgs{idPersonne, newMail})
 	if err != nil {
 		return err
@@ -254,3 +358,69 @@ func (ct *Controller) valideTransfertFicheSanitaire(args transfertFicheSanitaire
 		return err
 	})
 }
+
+type SigneFichesanitaireIn struct {
+	IdPersonne pr.IdPersonne
+	Signature  filesAPI.SignatureIn
+}
+
+// SigneFichesanitaire enregistre la signature électronique
+// de la fiche sanitaire, dans son état actuel.
+func (ct *Controller) SigneFichesanitaire(c echo.Context) error {
+	token := c.QueryParam("token")
+	acces, err := ct.checkAcces(token, true)
+	if err != nil {
+		return err
+	}
+	var args SigneFichesanitaireIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.signeFichesanitaire(acces.IdDossier, args, c.RealIP())
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) signeFichesanitaire(idDossier ds.IdDossier, args SigneFichesanitaireIn, ip string) (filesAPI.SignatureExt, error) {
+	dossier, err := logic.LoadDossier(ct.db, idDossier)
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
+	}
+	personne, err := participantFor(dossier, args.IdPersonne)
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
+	}
+	fiche, found, err := pr.SelectFichesanitaireByIdPersonne(ct.db, personne.Id)
+	if err != nil {
+		return filesAPI.SignatureExt{}, utils.SQLError(err)
+	}
+	if !found {
+		return filesAPI.SignatureExt{}, errors.New("Merci de remplir la fiche sanitaire avant de la signer.")
+	}
+	if isFichesanitaireLockedFor(dossier, fiche.Owners) {
+		return filesAPI.SignatureExt{}, errors.New("access forbidden")
+	}
+
+	page := pdfcreator.FicheSanitaire{Personne: personne.Identite, FicheSanitaire: fiche, Responsable: dossier.Responsable().Identite}
+	if responsable2, has := dossier.Responsable2(); has {
+		page.Responsable2 = responsable2.Identite
+	}
+	content, err := pdfcreator.CreateFicheSanitaires(ct.asso, []pdfcreator.FicheSanitaire{page})
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
+	}
+	// l'empreinte porte sur les données de la fiche
+	donnees, err := json.Marshal(fiche)
+	if err != nil {
+		return filesAPI.SignatureExt{}, err
+	}
+	return ct.signe(filesAPI.DocumentASigner{
+		IdPersonne: personne.Id,
+		Personne:   personne.PrenomNOM(),
+		Document:   fs.FicheSanitaire,
+		Donnees:    donnees,
+		Content:    content,
+	}, args.Signature, ip)
+}
@@ -0,0 +1,191 @@
+package files
+
+import (
+	"slices"
+	"strings"
+
+	"registro/logic"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+)
+
+// EtatDocument est l'état d'une case de la matrice [CompletionDocuments].
+type EtatDocument uint8
+
+const (
+	NonDemande EtatDocument = iota // Non demandé
+	Manquant                       // Manquant
+	Envoye                         // Envoyé
+	Perime                         // Périmé
+	Refuse                         // Refusé
+)
+
+func (e EtatDocument) String() string {
+	switch e {
+	case Manquant:
+		return "Manquant"
+	case Envoye:
+		return "Envoyé"
+	case Perime:
+		return "Périmé"
+	case Refuse:
+		return "Refusé"
+	default:
+		return ""
+	}
+}
+
+// IsComplete renvoie false si le document doit être (re)envoyé.
+func (e EtatDocument) IsComplete() bool { return e == NonDemande || e == Envoye }
+
+// etatFiles résume les fichiers envoyés pour une demande :
+// un document valide suffit.
+func etatFiles(files []logic.PublicFile) EtatDocument {
+	if len(files) == 0 {
+		return Manquant
+	}
+	out := Refuse
+	for _, file := range files {
+		switch {
+		case file.Revue == fs.Refuse:
+		case file.Perime:
+			out = Perime
+		default:
+			return Envoye
+		}
+	}
+	return out
+}
+
+// IsDocumentManquant renvoie true si aucun document valide
+// (ni périmé, ni refusé) n'a été envoyé parmi [files].
+func IsDocumentManquant(files []logic.PublicFile) bool { return !etatFiles(files).IsComplete() }
+
+func etatFichesanitaire(state pr.FichesanitaireState) EtatDocument {
+	switch state {
+	case pr.UpToDate:
+		return Envoye
+	case pr.Outdated:
+		return Perime
+	default:
+		return Manquant
+	}
+}
+
+// ColonneCompletion est une colonne de la matrice [CompletionDocuments] :
+// une [fs.Demande], la fiche sanitaire ou la charte.
+type ColonneCompletion struct {
+	IdDemande fs.IdDemande // 0 pour la fiche sanitaire et la charte
+	Title     string
+}
+
+type LigneCompletion struct {
+	Id       cps.IdParticipant
+	Personne string
+	Etats    []EtatDocument // dans l'ordre de [CompletionDocuments.Colonnes]
+}
+
+// IsComplete renvoie true si aucun document n'est à (re)envoyer.
+func (l LigneCompletion) IsComplete() bool {
+	for _, etat := range l.Etats {
+		if !etat.IsComplete() {
+			return false
+		}
+	}
+	return true
+}
+
+// CompletionDocuments est une matrice inscrits x documents demandés,
+// donnant l'état de chaque document.
+type CompletionDocuments struct {
+	Colonnes []ColonneCompletion
+	Lignes   []LigneCompletion
+}
+
+// ARelancer renvoie les inscrits ayant au moins un document
+// manquant, périmé ou refusé.
+func (cd CompletionDocuments) ARelancer() []cps.IdParticipant {
+	var out []cps.IdParticipant
+	for _, ligne := range cd.Lignes {
+		if !ligne.IsComplete() {
+			out = append(out, ligne.Id)
+		}
+	}
+	return out
+}
+
+// Completion renvoie la matrice de complétion des documents du séjour [id] :
+// fiche sanitaire, PAI (si besoin), charte (si demandée), vaccins puis demandes du séjour.
+func (ld ParticipantsFilesLoader) Completion(id cps.IdCamp) CompletionDocuments {
+	files := ld.For(id)
+	camp := ld.camps.For(id)
+	withCharte := camp.Camp.DocumentsToShow.CharteParticipant
+
+	participants := camp.Participants(true)
+	personnes := make(map[cps.IdParticipant]cps.ParticipantPersonne, len(participants))
+	withPAI := false
+	for _, participant := range participants {
+		personnes[participant.Participant.Id] = participant
+		if ld.fiches[participant.Personne.Id].PAI {
+			withPAI = true
+		}
+	}
+
+	// vaccins d'abord, puis par titre
+	demandes := make([]fs.Demande, 0, len(files.Demandes))
+	for _, demande := range files.Demandes {
+		demandes = append(demandes, demande)
+	}
+	slices.SortFunc(demandes, func(a, b fs.Demande) int {
+		if isVaccinA, isVaccinB := a.Id == ld.idVaccin, b.Id == ld.idVaccin; isVaccinA != isVaccinB {
+			if isVaccinA {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Title(), b.Title())
+	})
+
+	var out CompletionDocuments
+	out.Colonnes = append(out.Colonnes, ColonneCompletion{Title: "Fiche sanitaire"})
+	if withPAI {
+		out.Colonnes = append(out.Colonnes, ColonneCompletion{IdDemande: ld.idPAI, Title: "PAI"})
+	}
+	if withCharte {
+		out.Colonnes = append(out.Colonnes, ColonneCompletion{Title: "Charte"})
+	}
+	for _, demande := range demandes {
+		out.Colonnes = append(out.Colonnes, ColonneCompletion{IdDemande: demande.Id, Title: demande.Title()})
+	}
+
+	for _, participant := range files.Participants {
+		ligne := LigneCompletion{Id: participant.Id, Personne: participant.Personne}
+		inscrit := personnes[participant.Id]
+		ligne.Etats = append(ligne.Etats, etatFichesanitaire(participant.Fichesanitaire))
+		if withPAI {
+			etat := NonDemande
+			if ld.fiches[inscrit.Personne.Id].PAI {
+				etat = etatFiles(ld.files[inscrit.Personne.Id][ld.idPAI])
+			}
+			ligne.Etats = append(ligne.Etats, etat)
+		}
+		if withCharte {
+			// même règle que l'espace personnel
+			etat := NonDemande
+			if camp.Camp.AgeDebutCamp(inscrit.Personne.DateNaissance) >= 12 {
+				etat = Manquant
+				dossier := ld.dossiers[inscrit.Participant.IdDossier]
+				if inscrit.Personne.CharteAccepted.After(dossier.MomentInscription) {
+					etat = Envoye
+				}
+			}
+			ligne.Etats = append(ligne.Etats, etat)
+		}
+		for _, demande := range demandes {
+			ligne.Etats = append(ligne.Etats, etatFiles(participant.Files[demande.Id]))
+		}
+		out.Lignes = append(out.Lignes, ligne)
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package files
+
+import (
+	"testing"
+
+	"registro/logic"
+	fs "registro/sql/files"
+	tu "registro/utils/testutils"
+)
+
+func TestEtatFiles(t *testing.T) {
+	file := func(revue fs.Revue, perime bool) logic.PublicFile {
+		return logic.PublicFile{File: fs.File{Revue: revue, Perime: perime}}
+	}
+	tu.Assert(t, etatFiles(nil) == Manquant)
+	tu.Assert(t, etatFiles([]logic.PublicFile{file(fs.EnAttente, false)}) == Envoye)
+	tu.Assert(t, etatFiles([]logic.PublicFile{file(fs.Refuse, false)}) == Refuse)
+	tu.Assert(t, etatFiles([]logic.PublicFile{file(fs.Accepte, true)}) == Perime)
+	tu.Assert(t, etatFiles([]logic.PublicFile{file(fs.Refuse, false), file(fs.Accepte, true)}) == Perime)
+	tu.Assert(t, etatFiles([]logic.PublicFile{file(fs.Accepte, true), file(fs.Accepte, false)}) == Envoye)
+
+	tu.Assert(t, IsDocumentManquant(nil))
+	tu.Assert(t, IsDocumentManquant([]logic.PublicFile{file(fs.Accepte, true)}))
+	tu.Assert(t, IsDocumentManquant([]logic.PublicFile{file(fs.Refuse, false)}))
+	tu.Assert(t, !IsDocumentManquant([]logic.PublicFile{file(fs.Accepte, true), file(fs.EnAttente, false)}))
+
+	cd := CompletionDocuments{Lignes: []LigneCompletion{
+		{Id: 1, Etats: []EtatDocument{Envoye, NonDemande}},
+		{Id: 2, Etats: []EtatDocument{Envoye, Perime}},
+		{Id: 3, Etats: []EtatDocument{Manquant, Envoye}},
+	}}
+	tu.Assert(t, len(cd.ARelancer()) == 2)
+}
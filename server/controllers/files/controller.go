@@ -55,12 +55,13 @@ func (ct *Controller) LoadDocument(c echo.Context) error {
 	if err != nil {
 		return utils.SQLError(err)
 	}
-	content, err := ct.files.Load(id, false)
+	content, err := ct.files.Open(file, false)
 	if err != nil {
 		return err
 	}
-	mimeType := SetBlobHeader(c, content, file.NomClient)
-	return c.Blob(200, mimeType, content)
+	defer content.Close()
+	mimeType := setAttachmentHeader(c, file.Taille, file.NomClient)
+	return c.Stream(200, mimeType, content)
 }
 
 // LoadMiniature returns a placeholder image on error
@@ -71,7 +72,12 @@ func (ct *Controller) LoadMiniature(c echo.Context) error {
 		log.Println(err)
 		return c.Blob(200, mime.TypeByExtension(".png"), assets.DefaultMiniaturePNG)
 	}
-	content, err := ct.files.Load(id, true)
+	file, err := fs.SelectFile(ct.db, id)
+	if err != nil {
+		log.Println(err)
+		return c.Blob(200, mime.TypeByExtension(".png"), assets.DefaultMiniaturePNG)
+	}
+	content, err := ct.files.Load(file, true)
 	if err != nil {
 		log.Println(err)
 		return c.Blob(200, mime.TypeByExtension(".png"), assets.DefaultMiniaturePNG)
@@ -82,10 +88,14 @@ func (ct *Controller) LoadMiniature(c echo.Context) error {
 // SetBlobHeader sets Content-Disposition and Content-Length headers
 // and returns the mime type
 func SetBlobHeader(c echo.Context, content []byte, name string) string {
+	return setAttachmentHeader(c, len(content), name)
+}
+
+func setAttachmentHeader(c echo.Context, size int, name string) string {
 	u := url.URL{Path: name}
 	name = u.String()
 	c.Response().Header().Set("Content-Disposition", "attachment; filename="+name)
-	c.Response().Header().Set("Content-Length", strconv.Itoa(len(content)))
+	c.Response().Header().Set("Content-Length", strconv.Itoa(size))
 	return mime.TypeByExtension(path.Ext(name))
 }
 
@@ -134,11 +144,11 @@ func Delete(db *sql.DB, enc crypto.Encrypter, files fs.FileSystem, key string) (
 		return 0, err
 	}
 	err = utils.InTx(db, func(tx *sql.Tx) error {
-		_, err = fs.DeleteFileById(tx, id)
+		file, err := fs.DeleteFileById(tx, id)
 		if err != nil {
 			return err
 		}
-		err = files.Delete(id)
+		err = files.Delete(tx, file)
 		if err != nil {
 			return err
 		}
@@ -150,9 +160,21 @@ func Delete(db *sql.DB, enc crypto.Encrypter, files fs.FileSystem, key string) (
 	return id, nil
 }
 
-// ReadUpload checks the file size and reads its content.
+// ReadUpload checks the file size, reads its content and
+// checks its format (see [fs.CheckFormat]).
 // The size of the file is checked against the max 5MB
 func ReadUpload(fileHeader *multipart.FileHeader) (content []byte, filename string, err error) {
+	content, filename, err = readUpload(fileHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = fs.CheckFormat(filename, content); err != nil {
+		return nil, "", err
+	}
+	return content, filename, nil
+}
+
+func readUpload(fileHeader *multipart.FileHeader) (content []byte, filename string, err error) {
 	const MB = 1000000
 	const maxSize = 5 * MB
 	if fileHeader.Size > maxSize {
@@ -175,19 +197,51 @@ func ReadUpload(fileHeader *multipart.FileHeader) (content []byte, filename stri
 	return content, fileHeader.Filename, nil
 }
 
-func DemandeVaccin(db fs.DB) (fs.Demande, error) {
+// ReadDocumentUploads lit les fichiers envoyés dans le champ [field]
+// (un document PDF ou Word, ou une ou plusieurs images) et les convertit en
+// un unique document PDF (voir [fs.FileSystem.NormaliseToPDF]).
+func ReadDocumentUploads(files fs.FileSystem, c echo.Context, field string) (content []byte, filename string, err error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, "", err
+	}
+	headers := form.File[field]
+	if len(headers) == 0 {
+		return nil, "", fmt.Errorf("missing file %s", field)
+	}
+	uploads := make([]fs.Upload, len(headers))
+	for i, header := range headers {
+		uploads[i].Content, uploads[i].Filename, err = readUpload(header)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	out, err := files.NormaliseToPDF(uploads)
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Content, out.Filename, nil
+}
+
+func demandeBuiltin(db fs.DB, categorie fs.Categorie) (fs.Demande, error) {
 	demandes, err := fs.SelectAllDemandes(db)
 	if err != nil {
 		return fs.Demande{}, utils.SQLError(err)
 	}
 	for _, demande := range demandes {
-		if demande.Categorie == fs.Vaccins {
+		if demande.Categorie == categorie {
 			return demande, nil
 		}
 	}
-	return fs.Demande{}, errors.New("missing Demande for categorie <Vaccins>")
+	return fs.Demande{}, fmt.Errorf("missing Demande for categorie <%s>", categorie)
 }
 
+func DemandeVaccin(db fs.DB) (fs.Demande, error) { return demandeBuiltin(db, fs.Vaccins) }
+
+// DemandePAI renvoie la demande utilisée pour le document
+// des participants ayant un PAI (voir [pr.Fichesanitaire.PAI])
+func DemandePAI(db fs.DB) (fs.Demande, error) { return demandeBuiltin(db, fs.PAI) }
+
 func LoadFilesPersonnes(db fs.DB, key crypto.Encrypter, demandes []fs.IdDemande, personnes ...pr.IdPersonne) (map[pr.IdPersonne]map[fs.IdDemande][]logic.PublicFile, fs.Demandes,
 	error,
 ) {
@@ -250,6 +304,7 @@ type ParticipantsFilesLoader struct {
 
 	demandes       fs.Demandes
 	idVaccin       fs.IdDemande
+	idPAI          fs.IdDemande
 	demandesByCamp map[cps.IdCamp]fs.DemandeCamps
 
 	fiches map[pr.IdPersonne]pr.Fichesanitaire
@@ -273,11 +328,15 @@ func LoadParticipantsFiles(db fs.DB, key crypto.Encrypter, ids []cps.IdCamp) (Pa
 	if err != nil {
 		return ParticipantsFilesLoader{}, err
 	}
+	paiDemande, err := DemandePAI(db)
+	if err != nil {
+		return ParticipantsFilesLoader{}, err
+	}
 	tmp, err := fs.SelectDemandeCampsByIdCamps(db, ids...)
 	if err != nil {
 		return ParticipantsFilesLoader{}, utils.SQLError(err)
 	}
-	idDemandes := append(tmp.IdDemandes(), vaccinDemande.Id)
+	idDemandes := append(tmp.IdDemandes(), vaccinDemande.Id, paiDemande.Id)
 
 	// personnes et fichiers
 	personnes := camps.Personnes(true)
@@ -292,7 +351,7 @@ func LoadParticipantsFiles(db fs.DB, key crypto.Encrypter, ids []cps.IdCamp) (Pa
 		return ParticipantsFilesLoader{}, err
 	}
 
-	return ParticipantsFilesLoader{camps, dossiers, demandes, vaccinDemande.Id, tmp.ByIdCamp(), fiches, files}, nil
+	return ParticipantsFilesLoader{camps, dossiers, demandes, vaccinDemande.Id, paiDemande.Id, tmp.ByIdCamp(), fiches, files}, nil
 }
 
 type ParticipantFiles struct {
@@ -363,7 +422,8 @@ func (files ParticipantsFiles) Stats() (out []DemandeStat) {
 	for _, demande := range files.Demandes {
 		uploadedCount := 0
 		for _, participant := range files.Participants {
-			if hasFile := len(participant.Files[demande.Id]) > 0; hasFile {
+			hasFile := slices.ContainsFunc(participant.Files[demande.Id], func(file logic.PublicFile) bool { return file.Revue != fs.Refuse })
+			if hasFile {
 				uploadedCount += 1
 			}
 		}
@@ -0,0 +1,68 @@
+package files
+
+import (
+	"database/sql"
+	"slices"
+
+	"registro/crypto"
+	"registro/logic"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// UploadedFile est renvoyé après l'envoi d'un document.
+type UploadedFile struct {
+	File logic.PublicFile
+	// Autres demandes pour lesquelles la personne a déjà
+	// envoyé ce document, à signaler à l'utilisateur
+	Doublons []string
+}
+
+// doublons renvoie le titre des autres demandes pour lesquelles
+// [idPersonne] a déjà envoyé un document identique à [file].
+func doublons(db fs.DB, file fs.File, idPersonne pr.IdPersonne, idDemande fs.IdDemande) ([]string, error) {
+	if file.Empreinte == "" {
+		return nil, nil
+	}
+	sameContent, err := fs.SelectFilesByEmpreinte(db, file.Empreinte)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	delete(sameContent, file.Id)
+	links, err := fs.SelectFilePersonnesByIdFiles(db, sameContent.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	var ids []fs.IdDemande
+	for _, link := range links {
+		if link.IdPersonne == idPersonne && link.IdDemande != idDemande && !slices.Contains(ids, link.IdDemande) {
+			ids = append(ids, link.IdDemande)
+		}
+	}
+	demandes, err := fs.SelectDemandes(db, ids...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	var out []string
+	for _, demande := range demandes {
+		out = append(out, demande.Title())
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// UploadFileFor enregistre le document (voir [SaveFileFor]) et
+// signale les doublons déjà envoyés pour d'autres demandes.
+func UploadFileFor(files fs.FileSystem, db *sql.DB, key crypto.Encrypter, idPersonne pr.IdPersonne, idDemande fs.IdDemande, content []byte, filename string) (UploadedFile, error) {
+	file, err := SaveFileFor(files, db, idPersonne, idDemande, content, filename)
+	if err != nil {
+		return UploadedFile{}, err
+	}
+	out := UploadedFile{File: logic.NewPublicFile(key, file)}
+	out.Doublons, err = doublons(db, file, idPersonne, idDemande)
+	if err != nil {
+		return UploadedFile{}, err
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package files
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// Formulaire est le document modèle (PDF) d'une [fs.Demande],
+// à remplir en ligne.
+type Formulaire struct {
+	IdDemande fs.IdDemande
+	Champs    []fs.ChampFormulaire
+}
+
+// normalizeChamp ne conserve que les lettres et chiffres, sans accents
+func normalizeChamp(nom string) string {
+	nom = string(utils.RemoveAccents([]byte(strings.ToLower(nom))))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, nom)
+}
+
+// valeursIdentite associe aux noms de champ usuels (normalisés)
+// les valeurs de l'identité
+func valeursIdentite(identite pr.Identite) map[string]string {
+	dateNaissance := ""
+	if !identite.DateNaissance.Time().IsZero() {
+		dateNaissance = identite.DateNaissance.String()
+	}
+	tel := ""
+	if len(identite.Tels) != 0 {
+		tel = identite.Tels[0]
+	}
+	return map[string]string{
+		"nom":             identite.FNom(),
+		"prenom":          identite.FPrenom(),
+		"nomprenom":       identite.NOMPrenom(),
+		"prenomnom":       identite.PrenomNOM(),
+		"sexe":            identite.Sexe.String(),
+		"datenaissance":   dateNaissance,
+		"datedenaissance": dateNaissance,
+		"naissance":       dateNaissance,
+		"mail":            identite.Mail,
+		"email":           identite.Mail,
+		"courriel":        identite.Mail,
+		"tel":             tel,
+		"telephone":       tel,
+		"adresse":         identite.Adresse,
+		"codepostal":      identite.CodePostal,
+		"cp":              identite.CodePostal,
+		"ville":           identite.Ville,
+	}
+}
+
+// preRemplit complète les champs texte vides dont le nom
+// (ou le nom alternatif) correspond à un champ de l'identité
+func preRemplit(champs []fs.ChampFormulaire, identite pr.Identite) {
+	valeurs := valeursIdentite(identite)
+	for i, champ := range champs {
+		if champ.Type != fs.ChampTexte || champ.Valeur != "" {
+			continue
+		}
+		valeur, ok := valeurs[normalizeChamp(champ.Nom)]
+		if !ok {
+			valeur = valeurs[normalizeChamp(champ.Label)]
+		}
+		if champ.MaxLen > 0 && len([]rune(valeur)) > champ.MaxLen {
+			continue
+		}
+		champs[i].Valeur = valeur
+	}
+}
+
+// loadModele renvoie la demande et le contenu de son document modèle
+func loadModele(db fs.DB, files fs.FileSystem, idDemande fs.IdDemande) (fs.Demande, []byte, error) {
+	demande, err := fs.SelectDemande(db, idDemande)
+	if err != nil {
+		return fs.Demande{}, nil, utils.SQLError(err)
+	}
+	if !demande.IdFile.Valid {
+		return fs.Demande{}, nil, errors.New("Aucun document n'est associé à cette demande.")
+	}
+	file, err := fs.SelectFile(db, demande.IdFile.Id)
+	if err != nil {
+		return fs.Demande{}, nil, utils.SQLError(err)
+	}
+	content, err := files.Load(file, false)
+	return demande, content, err
+}
+
+// LoadFormulaire renvoie les champs du document modèle de la demande,
+// pré-remplis avec l'[identite] lorsque les noms des champs correspondent.
+func LoadFormulaire(db fs.DB, files fs.FileSystem, idDemande fs.IdDemande, identite pr.Identite) (Formulaire, error) {
+	_, content, err := loadModele(db, files, idDemande)
+	if err != nil {
+		return Formulaire{}, err
+	}
+	champs, err := fs.ChampsFormulaire(content)
+	if err != nil {
+		return Formulaire{}, err
+	}
+	preRemplit(champs, identite)
+	return Formulaire{IdDemande: idDemande, Champs: champs}, nil
+}
+
+// SaveFormulaireFor remplit le document modèle de la demande avec les [valeurs]
+// et enregistre le PDF obtenu comme document de la personne.
+func SaveFormulaireFor(files fs.FileSystem, db *sql.DB, idPersonne pr.IdPersonne, idDemande fs.IdDemande, valeurs map[string]string) (fs.File, error) {
+	demande, content, err := loadModele(db, files, idDemande)
+	if err != nil {
+		return fs.File{}, err
+	}
+	filled, err := fs.RemplitFormulaire(content, valeurs)
+	if err != nil {
+		return fs.File{}, err
+	}
+	return SaveFileFor(files, db, idPersonne, idDemande, filled, demande.Title()+".pdf")
+}
@@ -0,0 +1,36 @@
+package files
+
+import (
+	"testing"
+
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestPreRemplit(t *testing.T) {
+	tu.Assert(t, normalizeChamp("Date de naissance :") == "datedenaissance")
+	tu.Assert(t, normalizeChamp("Prénom") == "prenom")
+
+	champs := []fs.ChampFormulaire{
+		{Nom: "Nom", Type: fs.ChampTexte},
+		{Nom: "z1", Label: "Prénom de l'enfant", Type: fs.ChampTexte},
+		{Nom: "z2", Label: "Prénom", Type: fs.ChampTexte},
+		{Nom: "Date_Naissance", Type: fs.ChampTexte},
+		{Nom: "Ville", Type: fs.ChampTexte, Valeur: "déjà rempli"},
+		{Nom: "CP", Type: fs.ChampTexte, MaxLen: 2},
+		{Nom: "Mail", Type: fs.ChampCase},
+	}
+	preRemplit(champs, pr.Identite{
+		Nom: "kugler", Prenom: "benoit", DateNaissance: shared.NewDate(2000, 1, 5),
+		Ville: "Paris", CodePostal: "75000", Mail: "x@free.fr",
+	})
+	tu.Assert(t, champs[0].Valeur == "KUGLER")
+	tu.Assert(t, champs[1].Valeur == "")
+	tu.Assert(t, champs[2].Valeur == "Benoit")
+	tu.Assert(t, champs[3].Valeur == shared.NewDate(2000, 1, 5).String())
+	tu.Assert(t, champs[4].Valeur == "déjà rempli")
+	tu.Assert(t, champs[5].Valeur == "") // trop long
+	tu.Assert(t, champs[6].Valeur == "")
+}
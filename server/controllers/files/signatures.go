@@ -0,0 +1,158 @@
+package files
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"registro/config"
+	"registro/crypto"
+	"registro/generators/pdfcreator"
+	"registro/logic"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// SignatureIn est envoyé par le signataire : il saisit son nom
+// et coche la case de consentement.
+type SignatureIn struct {
+	Signataire   string
+	Consentement bool
+}
+
+func (args SignatureIn) check() error {
+	if strings.TrimSpace(args.Signataire) == "" {
+		return errors.New("Merci de saisir votre nom pour signer.")
+	}
+	if !args.Consentement {
+		return errors.New("Merci de cocher la case de consentement pour signer.")
+	}
+	return nil
+}
+
+// empreinte renvoie le hash SHA-256 (hexadécimal) de [content]
+func empreinte(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// DocumentASigner est un document présenté au signataire.
+type DocumentASigner struct {
+	IdPersonne pr.IdPersonne // personne concernée
+	Personne   string        // nom de la personne concernée
+	Document   fs.DocumentSigne
+	Donnees    []byte // version exacte acceptée, utilisée pour l'empreinte
+	Content    []byte // rendu PDF du document
+}
+
+// TexteASigner renvoie le document affichant le texte de référence
+// de la version [version], telle que lue et acceptée par le signataire.
+// L'empreinte porte sur ce texte, et non sur une copie envoyée par le client.
+func TexteASigner(asso config.Asso, idPersonne pr.IdPersonne, personne string, document fs.DocumentSigne, version VersionTexte) (DocumentASigner, error) {
+	texte, err := texteReference(asso, document, version)
+	if err != nil {
+		return DocumentASigner{}, err
+	}
+	titre := fmt.Sprintf("%s (version %s)", document, version)
+	content, err := pdfcreator.CreateTexteSigne(asso, titre, texte)
+	if err != nil {
+		return DocumentASigner{}, err
+	}
+	return DocumentASigner{
+		IdPersonne: idPersonne,
+		Personne:   personne,
+		Document:   document,
+		Donnees:    []byte(texte),
+		Content:    content,
+	}, nil
+}
+
+// Signe enregistre la signature électronique du document [doc] :
+// un PDF complété par une page d'audit (signataire, horodatage,
+// adresse IP et empreinte) est conservé comme fichier.
+// La transaction [tx] permet à l'appelant d'enregistrer, avec la signature,
+// ses conséquences (charte acceptée, etc.).
+func Signe(tx *sql.Tx, files fs.FileSystem, asso config.Asso, doc DocumentASigner, args SignatureIn, ip string) (fs.File, fs.Signature, error) {
+	if err := args.check(); err != nil {
+		return fs.File{}, fs.Signature{}, err
+	}
+	signature := fs.Signature{
+		IdPersonne: doc.IdPersonne,
+		Document:   doc.Document,
+		Signataire: strings.TrimSpace(args.Signataire),
+		Moment:     time.Now().Truncate(time.Second),
+		IP:         ip,
+		Empreinte:  empreinte(doc.Donnees),
+	}
+	content, err := pdfcreator.AppendAuditSignature(asso, doc.Content, pdfcreator.AuditSignature{
+		Document:   doc.Document.String(),
+		Personne:   doc.Personne,
+		Signataire: signature.Signataire,
+		Moment:     signature.Moment,
+		IP:         signature.IP,
+		Empreinte:  signature.Empreinte,
+	})
+	if err != nil {
+		return fs.File{}, fs.Signature{}, err
+	}
+	filename := doc.Document.String() + " - " + doc.Personne + ".pdf"
+
+	file, err := fs.File{}.Insert(tx)
+	if err != nil {
+		return fs.File{}, fs.Signature{}, err
+	}
+	file, err = fs.UploadFile(files, tx, file.Id, content, filename)
+	if err != nil {
+		return fs.File{}, fs.Signature{}, err
+	}
+	signature.IdFile = file.Id
+	signature, err = signature.Insert(tx)
+	if err != nil {
+		return fs.File{}, fs.Signature{}, err
+	}
+	return file, signature, nil
+}
+
+// SignatureExt est un document signé électroniquement
+type SignatureExt struct {
+	IdPersonne pr.IdPersonne
+	Document   fs.DocumentSigne
+	Signataire string
+	Moment     time.Time
+	File       logic.PublicFile // PDF signé, avec la page d'audit
+}
+
+func NewSignatureExt(key crypto.Encrypter, signature fs.Signature, file fs.File) SignatureExt {
+	return SignatureExt{
+		IdPersonne: signature.IdPersonne,
+		Document:   signature.Document,
+		Signataire: signature.Signataire,
+		Moment:     signature.Moment,
+		File:       logic.NewPublicFile(key, file),
+	}
+}
+
+// LoadSignatures renvoie les documents signés par (ou pour) les personnes données,
+// du plus récent au plus ancien.
+func LoadSignatures(db fs.DB, key crypto.Encrypter, idPersonnes ...pr.IdPersonne) ([]SignatureExt, error) {
+	signatures, err := fs.SelectSignaturesByIdPersonnes(db, idPersonnes...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	files, err := fs.SelectFiles(db, signatures.IdFiles()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	out := make([]SignatureExt, 0, len(signatures))
+	for _, signature := range signatures {
+		out = append(out, NewSignatureExt(key, signature, files[signature.IdFile]))
+	}
+	slices.SortFunc(out, func(a, b SignatureExt) int { return b.Moment.Compare(a.Moment) })
+	return out, nil
+}
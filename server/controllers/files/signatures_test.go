@@ -0,0 +1,39 @@
+package files
+
+import (
+	"testing"
+
+	"registro/config"
+	fs "registro/sql/files"
+	tu "registro/utils/testutils"
+)
+
+func TestSignatureIn(t *testing.T) {
+	tu.AssertErr(t, SignatureIn{}.check())
+	tu.AssertErr(t, SignatureIn{Signataire: "  ", Consentement: true}.check())
+	tu.AssertErr(t, SignatureIn{Signataire: "Marie Dupont"}.check())
+	tu.AssertNoErr(t, SignatureIn{Signataire: "Marie Dupont", Consentement: true}.check())
+}
+
+func TestEmpreinte(t *testing.T) {
+	tu.Assert(t, empreinte(nil) == "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	tu.Assert(t, empreinte([]byte("charte v1")) != empreinte([]byte("charte v2")))
+	tu.Assert(t, len(empreinte([]byte("charte"))) == 64)
+}
+
+func TestTexteReference(t *testing.T) {
+	acve := config.Asso{ID: "acve"}
+	for version, texte := range textesSignes {
+		asso := config.Asso{ID: texte.asso}
+		content, err := texteReference(asso, texte.document, version)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, content != "")
+	}
+
+	_, err := texteReference(acve, fs.CharteEquipier, "charte-equipier-repere-v1")
+	tu.AssertErr(t, err) // wrong asso
+	_, err = texteReference(acve, fs.CharteParticipant, "charte-equipier-acve-v1")
+	tu.AssertErr(t, err) // wrong document
+	_, err = texteReference(acve, fs.CharteParticipant, "")
+	tu.AssertErr(t, err)
+}
@@ -0,0 +1,46 @@
+package files
+
+import (
+	"embed"
+	"errors"
+	"strings"
+
+	"registro/config"
+	fs "registro/sql/files"
+)
+
+//go:embed textes/*
+var textesFS embed.FS
+
+// VersionTexte identifie la version d'un document texte à signer
+// (charte, autorisation parentale).
+// Le texte de référence est conservé par le serveur, dans textes/<version>.txt :
+// un texte publié n'est jamais modifié, toute évolution (y compris sur les
+// clients) doit donner lieu à une nouvelle version.
+type VersionTexte string
+
+type texteSigne struct {
+	document fs.DocumentSigne
+	asso     string // vide si le texte est commun aux associations
+}
+
+var textesSignes = map[VersionTexte]texteSigne{
+	"charte-participant-v1":     {document: fs.CharteParticipant},
+	"charte-equipier-acve-v1":   {document: fs.CharteEquipier, asso: "acve"},
+	"charte-equipier-repere-v1": {document: fs.CharteEquipier, asso: "repere"},
+	"autorisation-parentale-v1": {document: fs.AutorisationParentale},
+}
+
+// texteReference renvoie le texte de référence de la version [version],
+// après avoir vérifié qu'il correspond au [document] et à l'association.
+func texteReference(asso config.Asso, document fs.DocumentSigne, version VersionTexte) (string, error) {
+	texte, has := textesSignes[version]
+	if !has || texte.document != document || (texte.asso != "" && texte.asso != asso.ID) {
+		return "", errors.New("internal error: invalid document version")
+	}
+	content, err := textesFS.ReadFile("textes/" + string(version) + ".txt")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,22 @@
+package inscriptions
+
+import (
+	"registro/logic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SaveCandidature enregistre une candidature équipier,
+// envoyée par le formulaire public "Devenir animateur".
+// Les séjours ouverts sont donnés par [GetCamps].
+func (ct *Controller) SaveCandidature(c echo.Context) error {
+	var args logic.CandidatureIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	_, err := logic.SaveCandidature(ct.db, args)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(200)
+}
@@ -55,6 +55,20 @@ func (ct *Controller) searchMailAndSend(host string, mail string) (SearchMailOut
 			URL:         template.HTML(url),
 		})
 	}
+	// also send the links for second responsables
+	dossiers2, err := logic.LoadByMailResponsable2(ct.db, mail)
+	if err != nil {
+		return SearchMailOut{}, err
+	}
+	for _, dossier := range dossiers2.Dossiers {
+		loader := dossiers2.For(dossier.Id)
+		url := logic.EspacePersoURLResponsable2(ct.key, host, dossier.Id, dossier.IdResponsable2.Id)
+		out = append(out, mails.ResumeDossier{
+			Responsable: loader.Responsable().NOMPrenom(),
+			CampsMap:    loader.Camps(),
+			URL:         template.HTML(url),
+		})
+	}
 
 	if len(out) != 0 {
 		body, err := mails.RenvoieEspacePersoURL(ct.asso, mail, out)
@@ -23,6 +23,10 @@ var (
 	lettreDirecteurTmpl     *template.Template
 	attestationPresenceTmpl *template.Template
 	factureTmpl             *template.Template
+	texteSigneTmpl          *template.Template
+	auditSignatureTmpl      *template.Template
+	registreMedicamentsTmpl *template.Template
+	syntheseCuisineTmpl     *template.Template
 )
 
 func init() {
@@ -32,6 +36,10 @@ func init() {
 	lettreDirecteurTmpl = parseTemplate("templates/lettreDirecteur.html")
 	attestationPresenceTmpl = parseTemplate("templates/attestationPresence.html")
 	factureTmpl = parseTemplate("templates/facture.html")
+	texteSigneTmpl = parseTemplate("templates/texteSigne.html")
+	auditSignatureTmpl = parseTemplate("templates/auditSignature.html")
+	registreMedicamentsTmpl = parseTemplate("templates/registreMedicaments.html")
+	syntheseCuisineTmpl = parseTemplate("templates/syntheseCuisine.html")
 }
 
 func parseTemplate(templateFile string) *template.Template {
@@ -49,6 +57,7 @@ type FicheSanitaire struct {
 	Personne       pr.Identite
 	FicheSanitaire pr.Fichesanitaire
 	Responsable    pr.Identite
+	Responsable2   pr.Identite // optionnel
 }
 
 // CreateFicheSanitaires returns a PDF document, one "fiche sanitaire" per page.
@@ -108,6 +117,8 @@ type Destinataire struct {
 	Adresse    string
 	CodePostal string
 	Ville      string
+
+	CoResponsable string // optionnel, second responsable légal
 }
 
 // CreateAttestationPresence returns a PDF document.
@@ -201,3 +212,44 @@ func CreateLettreDirecteur(cfg config.Asso, lettre cps.Lettredirecteur, directeu
 	}
 	return templateToPDF(lettreDirecteurTmpl, args)
 }
+
+// CreateSyntheseCuisine returns a PDF document, with the number of
+// meals per day and the list of participants with a special diet.
+func CreateSyntheseCuisine(cfg config.Asso, synthese logic.SyntheseCuisine) ([]byte, error) {
+	type repas struct {
+		Jour      string
+		Total     int
+		Regimes   []int
+		Allergies int
+	}
+	var regimes []string
+	for _, regime := range logic.RegimesCuisine {
+		regimes = append(regimes, regime.String())
+	}
+	var (
+		jours    []repas
+		convives []logic.ConviveCuisine
+	)
+	for _, r := range synthese.Repas {
+		jours = append(jours, repas{r.Jour.ShortString(), r.Total, r.Regimes[:], r.Allergies})
+	}
+	for _, convive := range synthese.Convives {
+		if convive.HasParticularite() {
+			convives = append(convives, convive)
+		}
+	}
+	args := struct {
+		Asso     config.Asso
+		Camp     string
+		Regimes  []string
+		Repas    []repas
+		Convives []logic.ConviveCuisine
+	}{
+		Asso:     cfg,
+		Camp:     synthese.Camp,
+		Regimes:  regimes,
+		Repas:    jours,
+		Convives: convives,
+	}
+	return templateToPDF(syntheseCuisineTmpl, args)
+}
@@ -50,9 +50,21 @@ func randStringOrEmpty() string {
 
 func randFicheSanitaire() FicheSanitaire {
 	fs := pr.Fichesanitaire{
-		DifficultesSante:      randStringOrEmpty(),
-		AllergiesAlimentaires: randStringOrEmpty(),
-		TraitementMedical:     randStringOrEmpty(),
+		DifficultesSante: randStringOrEmpty(),
+		Allergies: pr.Allergies{
+			Alimentaires:   randBool(),
+			Aliments:       randStringOrEmpty(),
+			Precisions:     randStringOrEmpty(),
+			ConduiteATenir: randStringOrEmpty(),
+		},
+		Traitements: pr.Traitements{
+			{Medicament: "Doliprane", Dose: "500 mg", Horaire: "si fièvre", Duree: "tout le séjour"},
+		},
+		Regimes: pr.Regimes{pr.SansPorc, pr.SansGluten},
+		PAI:     randBool(),
+		Vaccinations: pr.Vaccinations{
+			{Vaccin: "DT Polio", Date: shared.NewDate(2020, time.March, 4)},
+		},
 		AutreContact: pr.NomTel{
 			Nom: utils.RandString(30, true),
 			Tel: pr.Tel(utils.RandString(30, true)),
@@ -78,7 +90,15 @@ func randFicheSanitaire() FicheSanitaire {
 		Pays:       pr.Pays(utils.RandString(2, false)),
 		Tels:       []string{"7987987979", "897779897998789"},
 	}
-	return FicheSanitaire{pers, fs, resp}
+	var resp2 pr.Identite
+	if randBool() {
+		resp2 = pr.Identite{
+			Nom:    utils.RandString(25, true),
+			Prenom: utils.RandString(25, true),
+			Tels:   []string{"0601020304"},
+		}
+	}
+	return FicheSanitaire{pers, fs, resp, resp2}
 }
 
 func randFicheSanitaires() []FicheSanitaire {
@@ -212,6 +232,8 @@ func TestFacture(t *testing.T) {
 		Adresse:    "200, Route de Dieulefit",
 		CodePostal: "07568",
 		Ville:      "Montélimar",
+
+		CoResponsable: "Kugler Marie",
 	}, []camps.ParticipantCamp{
 		{Camp: camp, ParticipantPersonne: camps.ParticipantPersonne{Participant: camps.Participant{Id: 1}, Personne: pr.Personne{Identite: personne}}},
 		{Camp: camp, ParticipantPersonne: camps.ParticipantPersonne{Participant: camps.Participant{Id: 2}, Personne: pr.Personne{Identite: personne}}},
@@ -296,3 +318,67 @@ func TestLettreDirecteur(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Write(t, "LettreDirecteur_2.pdf", content)
 }
+
+func TestTexteSigne(t *testing.T) {
+	content, err := CreateTexteSigne(asso, "Charte du participant", "Je m'engage à respecter les règles de vie du séjour.\n\n - Règle 1 <b>importante</b>\n - Règle 2")
+	tu.AssertNoErr(t, err)
+
+	content, err = AppendAuditSignature(asso, content, AuditSignature{
+		Document:   "Charte du participant",
+		Personne:   "Benoit KUGLER",
+		Signataire: "Marie Kugler",
+		Moment:     time.Now(),
+		IP:         "192.168.1.10",
+		Empreinte:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	})
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "TexteSigne.pdf", content)
+
+	_, err = AppendAuditSignature(asso, []byte("invalid"), AuditSignature{})
+	tu.Assert(t, err != nil)
+}
+
+func TestRegistreMedicaments(t *testing.T) {
+	debut := shared.NewDate(2024, time.July, 8)
+	registre := logic.RegistreInfirmerie{
+		Camp: camps.Camp{Nom: "Vive la vie", DateDebut: debut, Duree: 10},
+		Participants: []logic.ParticipantSante{
+			{IdPersonne: 1, Personne: "DURAND Paul", Traitements: pr.Traitements{
+				{Medicament: "Doliprane", Dose: "1 comprimé", Horaire: "matin et soir"},
+				{Medicament: "Ventoline", Dose: "2 bouffées", Horaire: "si besoin"},
+			}},
+			{IdPersonne: 2, Personne: "MARTIN Léa"},
+		},
+		Soins: []camps.Soin{
+			{IdPersonne: 1, Kind: camps.Administration, Moment: debut.Time().Add(9 * time.Hour), Creneau: camps.Matin, Medicament: "doliprane", Auteur: "AS"},
+		},
+	}
+	semaines := semainesRegistre(registre)
+	tu.Assert(t, len(semaines) == 2)
+	tu.Assert(t, len(semaines[0].Jours) == 7 && len(semaines[1].Jours) == 3)
+	tu.Assert(t, len(semaines[0].Lignes) == 2+lignesLibres)
+	tu.Assert(t, semaines[0].Lignes[0].Cases[0] == "✓ AS")
+	tu.Assert(t, semaines[0].Lignes[0].Cases[1] == "")
+	tu.Assert(t, len(semaines[1].Lignes[0].Cases) == 3*int(camps.NbCreneaux))
+
+	content, err := CreateRegistreMedicaments(asso, registre)
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "RegistreMedicaments.pdf", content)
+}
+
+func TestSyntheseCuisine(t *testing.T) {
+	synthese := logic.SyntheseCuisine{
+		Camp: "Vive la vie 2024",
+		Convives: []logic.ConviveCuisine{
+			{Personne: "DURAND Paul", Groupe: "Petits", Presence: "Tout le séjour", Regimes: pr.Regimes{pr.Vegetarien, pr.SansGluten}},
+			{Personne: "MARTIN Léa", Presence: "Mer 10; Jeu 11", AllergiesAlimentaires: "Arachides"},
+			{Personne: "PETIT Jean", Presence: "Tout le séjour"},
+		},
+	}
+	for i := range 5 {
+		synthese.Repas = append(synthese.Repas, logic.RepasJour{Jour: shared.NewDate(2024, time.July, 8+i), Total: 3, Allergies: 1})
+	}
+	content, err := CreateSyntheseCuisine(asso, synthese)
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "SyntheseCuisine.pdf", content)
+}
@@ -0,0 +1,88 @@
+package pdfcreator
+
+import (
+	"registro/config"
+	"registro/logic"
+	cps "registro/sql/camps"
+	"registro/sql/shared"
+)
+
+// lignesLibres est le nombre de lignes vierges ajoutées à chaque semaine,
+// pour les traitements prescrits pendant le séjour.
+const lignesLibres = 5
+
+type ligneRegistre struct {
+	Personne   string
+	Medicament string
+	Posologie  string // dose et horaire
+	Cases      []string
+}
+
+type semaineRegistre struct {
+	Numero int // à partir de 1
+	Jours  []string
+	Lignes []ligneRegistre
+}
+
+// semainesRegistre découpe le séjour en semaines, une ligne par traitement d'un inscrit.
+// Les prises enregistrées sont marquées par l'auteur de l'entrée.
+func semainesRegistre(registre logic.RegistreInfirmerie) []semaineRegistre {
+	duree := max(registre.Camp.Duree, 1)
+	var out []semaineRegistre
+	for debut := 0; debut < duree; debut += 7 {
+		var jours []shared.Date
+		for i := debut; i < min(debut+7, duree); i++ {
+			jours = append(jours, registre.Camp.DateDebut.AddDays(i))
+		}
+		semaine := semaineRegistre{Numero: debut/7 + 1}
+		for _, jour := range jours {
+			semaine.Jours = append(semaine.Jours, jour.ShortString())
+		}
+		for _, participant := range registre.Participants {
+			for _, traitement := range participant.Traitements {
+				ligne := ligneRegistre{
+					Personne:   participant.Personne,
+					Medicament: traitement.Medicament,
+					Posologie:  traitement.Dose + " " + traitement.Horaire,
+				}
+				for _, jour := range jours {
+					for creneau := range cps.NbCreneaux {
+						soin, ok := registre.Administration(participant.IdPersonne, traitement.Medicament, jour, creneau)
+						if ok {
+							ligne.Cases = append(ligne.Cases, "✓ "+soin.Auteur)
+						} else {
+							ligne.Cases = append(ligne.Cases, "")
+						}
+					}
+				}
+				semaine.Lignes = append(semaine.Lignes, ligne)
+			}
+		}
+		for range lignesLibres {
+			semaine.Lignes = append(semaine.Lignes, ligneRegistre{Cases: make([]string, len(jours)*int(cps.NbCreneaux))})
+		}
+		out = append(out, semaine)
+	}
+	return out
+}
+
+// CreateRegistreMedicaments returns a PDF document (landscape),
+// with one grid participant x jour x créneau per week of the camp.
+func CreateRegistreMedicaments(cfg config.Asso, registre logic.RegistreInfirmerie) ([]byte, error) {
+	var creneaux []string
+	for creneau := range cps.NbCreneaux {
+		creneaux = append(creneaux, creneau.String())
+	}
+	args := struct {
+		Asso     config.Asso
+		Camp     string
+		Creneaux []string
+		Semaines []semaineRegistre
+	}{
+		Asso:     cfg,
+		Camp:     registre.Camp.Label(),
+		Creneaux: creneaux,
+		Semaines: semainesRegistre(registre),
+	}
+	return templateToPDF(registreMedicamentsTmpl, args)
+}
@@ -0,0 +1,76 @@
+package pdfcreator
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"registro/config"
+
+	"github.com/benoitkugler/pdf/reader"
+)
+
+// AuditSignature regroupe les informations
+// enregistrées lors d'une signature électronique.
+type AuditSignature struct {
+	Document   string // type de document
+	Personne   string // personne concernée
+	Signataire string // nom saisi
+	Moment     time.Time
+	IP         string
+	Empreinte  string // SHA-256 de la version signée
+}
+
+// CreateTexteSigne returns a PDF document displaying [texte].
+func CreateTexteSigne(cfg config.Asso, titre, texte string) ([]byte, error) {
+	args := struct {
+		Asso  config.Asso
+		Titre string
+		Texte string
+	}{
+		Asso:  cfg,
+		Titre: titre,
+		Texte: texte,
+	}
+	return templateToPDF(texteSigneTmpl, args)
+}
+
+// AppendAuditSignature returns the PDF document [content],
+// completed by a page describing the signature.
+func AppendAuditSignature(cfg config.Asso, content []byte, audit AuditSignature) ([]byte, error) {
+	args := struct {
+		Asso   config.Asso
+		Audit  AuditSignature
+		Moment string
+	}{
+		Asso:   cfg,
+		Audit:  audit,
+		Moment: audit.Moment.Format("02/01/2006 à 15:04:05 (MST)"),
+	}
+	auditPage, err := templateToPDF(auditSignatureTmpl, args)
+	if err != nil {
+		return nil, err
+	}
+	return appendPages(content, auditPage)
+}
+
+// appendPages adds the pages of [other] at the end of [content]
+func appendPages(content, other []byte) ([]byte, error) {
+	doc, _, err := reader.ParsePDFReader(bytes.NewReader(content), reader.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid PDF file: %s", err)
+	}
+	otherDoc, _, err := reader.ParsePDFReader(bytes.NewReader(other), reader.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid PDF file: %s", err)
+	}
+	// resolve the inherited resources before moving the pages
+	for _, page := range otherDoc.Catalog.Pages.FlattenInherit() {
+		doc.Catalog.Pages.Kids = append(doc.Catalog.Pages.Kids, &page)
+	}
+	var out bytes.Buffer
+	if err = doc.Write(&out, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
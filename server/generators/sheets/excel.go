@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"registro/logic"
@@ -605,3 +606,152 @@ func ListeParticipantsCamps(participants []cps.ParticipantCamp, dossiers logic.D
 	}
 	return f.Bytes(), nil
 }
+
+// CahierSoins renvoie un document Excel du registre de l'infirmerie
+// d'un séjour, par ordre chronologique.
+func CahierSoins(registre logic.RegistreInfirmerie) ([]byte, error) {
+	headers := []string{
+		"Date",
+		"Participant",
+		"Type",
+		"Créneau",
+		"Médicament",
+		"Dose",
+		"Observations",
+		"Auteur",
+	}
+	rows := make([][]Cell, len(registre.Soins))
+	for i, soin := range registre.Soins {
+		creneau := ""
+		if soin.Kind == cps.Administration {
+			creneau = soin.Creneau.String()
+		}
+		rows[i] = []Cell{
+			{Value: formatTime(soin.Moment)},
+			{Value: registre.Personnes[soin.IdPersonne], Bold: true},
+			{Value: soin.Kind.String()},
+			{Value: creneau},
+			{Value: soin.Medicament},
+			{Value: soin.Dose},
+			{Value: soin.Description},
+			{Value: soin.Auteur},
+		}
+	}
+	return CreateTable(headers, rows)
+}
+
+// SyntheseCuisine renvoie un document Excel listant, pour chaque inscrit,
+// ses jours de présence, régimes et allergies alimentaires,
+// suivi du décompte des repas par jour.
+func SyntheseCuisine(synthese logic.SyntheseCuisine) ([]byte, error) {
+	headers := []string{"Participant", "Groupe", "Régimes", "Allergies alimentaires"}
+	for _, repas := range synthese.Repas {
+		headers = append(headers, repas.Jour.ShortString())
+	}
+	const colorParticularite = "#FFE0B2"
+
+	var rows [][]Cell
+	for _, convive := range synthese.Convives {
+		color := ""
+		if convive.HasParticularite() {
+			color = colorParticularite
+		}
+		row := []Cell{
+			{Value: convive.Personne, Bold: true},
+			{Value: convive.Groupe},
+			{Value: convive.Regimes.String()},
+			{Value: convive.AllergiesAlimentaires},
+		}
+		for _, repas := range synthese.Repas {
+			var cell Cell
+			if slices.Contains(convive.Jours, int32(repas.Index)) {
+				cell = Cell{Value: "X", Color: color}
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+
+	// décompte par jour
+	rows = append(rows, make([]Cell, len(headers)))
+	total := []Cell{{Value: "Présents", Bold: true}, {}, {}, {}}
+	for _, repas := range synthese.Repas {
+		total = append(total, intCell(repas.Total))
+	}
+	rows = append(rows, total)
+	for i, regime := range logic.RegimesCuisine {
+		row := []Cell{{Value: regime.String(), Bold: true}, {}, {}, {}}
+		for _, repas := range synthese.Repas {
+			row = append(row, intCell(repas.Regimes[i]))
+		}
+		rows = append(rows, row)
+	}
+	allergies := []Cell{{Value: "Allergies alimentaires", Bold: true}, {}, {}, {}}
+	for _, repas := range synthese.Repas {
+		allergies = append(allergies, intCell(repas.Allergies))
+	}
+	rows = append(rows, allergies)
+
+	return CreateTable(headers, rows)
+}
+
+// declarationRows renvoie la liste de l'équipe suivie des effectifs par tranche d'âge.
+func declarationRows(declaration logic.DeclarationCamp) (headers []string, rows [][]Cell) {
+	headers = []string{
+		"Nom", "Prénom", "Sexe", "Date de naissance", "Département de naissance", "Commune de naissance",
+		"Fonctions", "Diplôme", "Approfondissement", "Arrivée", "Départ", "Informations manquantes",
+	}
+	const colorManquant = "#FFCDD2"
+	for _, equipier := range declaration.Equipiers {
+		color := ""
+		if len(equipier.Manquants) != 0 {
+			color = colorManquant
+		}
+		rows = append(rows, []Cell{
+			{Value: equipier.Nom, Bold: true},
+			{Value: equipier.Prenom},
+			{Value: equipier.Sexe.String()},
+			{Value: equipier.DateNaissance.String()},
+			{Value: equipier.DepartementNaissance},
+			{Value: equipier.CommuneNaissance},
+			{Value: equipier.Fonctions},
+			{Value: equipier.Diplome.String()},
+			{Value: equipier.Approfondissement.String()},
+			{Value: equipier.Presence.From.String()},
+			{Value: equipier.Presence.To().String()},
+			{Value: strings.Join(equipier.Manquants, " ; "), Color: color},
+		})
+	}
+	// les lignes ont toutes la largeur de l'en-tête
+	rows = append(rows, make([]Cell, len(headers)))
+	for _, effectif := range declaration.Effectifs {
+		row := make([]Cell, len(headers))
+		row[0], row[1] = Cell{Value: effectif.Label, Bold: true}, intCell(effectif.Effectif)
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// DeclarationCamp renvoie un document Excel contenant les informations
+// de la déclaration du séjour (équipe et effectifs).
+func DeclarationCamp(declaration logic.DeclarationCamp) ([]byte, error) {
+	return CreateTable(declarationRows(declaration))
+}
+
+// DeclarationCampCsv renvoie les informations de la déclaration
+// du séjour au format CSV.
+func DeclarationCampCsv(declaration logic.DeclarationCamp) ([]byte, error) {
+	headers, rows := declarationRows(declaration)
+	liste := [][]string{headers}
+	for _, row := range rows {
+		line := make([]string, len(row))
+		for i, cell := range row {
+			line[i] = cell.Value
+			if cell.NumFormat != 0 {
+				line[i] = strconv.Itoa(int(cell.ValueF))
+			}
+		}
+		liste = append(liste, line)
+	}
+	return CreateCsv(liste)
+}
@@ -1,6 +1,7 @@
 package sheets
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -139,3 +140,49 @@ func TestListeParticipantsCamps(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Write(t, "ListeParticipantsCamps_2.xlsx", content)
 }
+
+func TestCahierSoins(t *testing.T) {
+	registre := logic.RegistreInfirmerie{
+		Soins: []cps.Soin{
+			{IdPersonne: 1, Kind: cps.Administration, Moment: time.Now(), Creneau: cps.Soir, Medicament: "Doliprane", Dose: "1 comprimé", Auteur: "Léa"},
+			{IdPersonne: 1, Kind: cps.Incident, Moment: time.Now(), Description: "Chute, genou désinfecté", Auteur: "Léa"},
+		},
+		Personnes: map[pr.IdPersonne]string{1: "DURAND Paul"},
+	}
+	content, err := CahierSoins(registre)
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "CahierSoins.xlsx", content)
+}
+
+func TestSyntheseCuisine(t *testing.T) {
+	synthese := logic.SyntheseCuisine{
+		Convives: []logic.ConviveCuisine{
+			{Personne: "DURAND Paul", Groupe: "Petits", Jours: cps.Jours{0, 1}, Regimes: pr.Regimes{pr.Vegetarien}},
+			{Personne: "MARTIN Léa", Jours: cps.Jours{1}, AllergiesAlimentaires: "Arachides"},
+		},
+		Repas: []logic.RepasJour{
+			{Index: 0, Jour: shared.NewDate(2024, time.July, 8), Total: 1, Regimes: [5]int{1}},
+			{Index: 1, Jour: shared.NewDate(2024, time.July, 9), Total: 2, Regimes: [5]int{1}, Allergies: 1},
+		},
+	}
+	content, err := SyntheseCuisine(synthese)
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "SyntheseCuisine.xlsx", content)
+}
+
+func TestDeclarationCamp(t *testing.T) {
+	declaration := logic.DeclarationCamp{
+		Equipiers: []logic.DeclarationEquipier{
+			{Nom: "DURAND", Prenom: "Paul", Sexe: pr.Man, DateNaissance: shared.NewDate(2000, time.May, 1), DepartementNaissance: "Yvelines", CommuneNaissance: "Versailles", Fonctions: "Direction", Diplome: pr.DBafd},
+			{Nom: "MARTIN", Prenom: "Léa", Fonctions: "Animation", Manquants: []string{"Diplôme", "Numéro de sécurité sociale (lieu de naissance)"}},
+		},
+		Effectifs: []logic.EffectifTranche{{Label: "Moins de 6 ans", Effectif: 3}, {Label: "6 à 13 ans", Effectif: 24}},
+	}
+	content, err := DeclarationCamp(declaration)
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "DeclarationCamp.xlsx", content)
+
+	content, err = DeclarationCampCsv(declaration)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, bytes.Contains(content, []byte("6 à 13 ans,24")))
+}
@@ -0,0 +1,334 @@
+package logic
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"registro/config"
+	"registro/mails"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// Le recrutement des équipiers se déroule en 3 temps :
+//	- [SaveCandidature] : le candidat remplit le formulaire public "Devenir animateur",
+//	  pour un ou plusieurs séjours : un profil temporaire est créé
+//	- [SetStatutCandidature] : les directeurs (ou le centre) présélectionnent les candidats
+//	- [AccepteCandidature] ou [RefuseCandidature] : le candidat est ajouté à l'équipe
+//	  (après identification de son profil), ou prévenu par mail
+
+// CandidatureCamp est un séjour demandé par un candidat.
+type CandidatureCamp struct {
+	IdCamp cps.IdCamp
+	Role   cps.Role // rôle souhaité
+}
+
+// CandidatureIn est envoyé par le formulaire public.
+type CandidatureIn struct {
+	Identite pr.Identite
+	Camps    []CandidatureCamp
+
+	Motivation string
+
+	Diplome                 pr.Diplome
+	Approfondissement       pr.Approfondissement
+	Formation               string
+	Profession              string
+	ExperienceTravailJeunes string
+}
+
+func (args CandidatureIn) check(open cps.Camps) error {
+	if strings.TrimSpace(args.Identite.Nom) == "" {
+		return errors.New("missing Nom")
+	}
+	if strings.TrimSpace(args.Identite.Prenom) == "" {
+		return errors.New("missing Prenom")
+	}
+	if strings.TrimSpace(args.Identite.Mail) == "" {
+		return errors.New("missing Mail")
+	}
+	if args.Identite.DateNaissance.Time().IsZero() {
+		return errors.New("missing DateNaissance")
+	}
+	if len(args.Camps) == 0 {
+		return errors.New("missing Camps")
+	}
+	seen := utils.Set[cps.IdCamp]{}
+	for _, camp := range args.Camps {
+		if _, isOpen := open[camp.IdCamp]; !isOpen {
+			return errors.New("Ce séjour n'est plus ouvert aux candidatures.")
+		}
+		if seen.Has(camp.IdCamp) {
+			return errors.New("invalid Camps (duplicate)")
+		}
+		seen.Add(camp.IdCamp)
+		if camp.Role >= cps.NbRoles {
+			return errors.New("invalid Role")
+		}
+	}
+	return nil
+}
+
+// SaveCandidature enregistre une candidature pour chacun des séjours demandés,
+// avec un profil temporaire, à identifier lors de l'acceptation.
+func SaveCandidature(db *sql.DB, args CandidatureIn) (out []cps.Candidature, _ error) {
+	camps, err := cps.SelectAllCamps(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	camps.RestrictOpen()
+	if err = args.check(camps); err != nil {
+		return nil, err
+	}
+
+	err = utils.InTx(db, func(tx *sql.Tx) error {
+		personne, err := pr.Personne{Identite: args.Identite, IsTemp: true}.Insert(tx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, camp := range args.Camps {
+			candidature, err := cps.Candidature{
+				IdCamp:                  camp.IdCamp,
+				IdPersonne:              personne.Id,
+				Role:                    camp.Role,
+				Statut:                  cps.CandidatureAttente,
+				Moment:                  now,
+				Motivation:              strings.TrimSpace(args.Motivation),
+				Diplome:                 args.Diplome,
+				Approfondissement:       args.Approfondissement,
+				Formation:               strings.TrimSpace(args.Formation),
+				Profession:              strings.TrimSpace(args.Profession),
+				ExperienceTravailJeunes: strings.TrimSpace(args.ExperienceTravailJeunes),
+			}.Insert(tx)
+			if err != nil {
+				return err
+			}
+			out = append(out, candidature)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// CandidatureExt ajoute le profil du candidat.
+type CandidatureExt struct {
+	Candidature cps.Candidature
+	Camp        string // label
+	Personne    pr.Personne
+}
+
+// LoadCandidatures renvoie les candidatures des séjours donnés,
+// les plus récentes en premier.
+func LoadCandidatures(db cps.DB, idCamps ...cps.IdCamp) ([]CandidatureExt, error) {
+	candidatures, err := cps.SelectCandidaturesByIdCamps(db, idCamps...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	camps, err := cps.SelectCamps(db, candidatures.IdCamps()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	personnes, err := pr.SelectPersonnes(db, candidatures.IdPersonnes()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	out := make([]CandidatureExt, 0, len(candidatures))
+	for _, candidature := range candidatures {
+		out = append(out, CandidatureExt{candidature, camps[candidature.IdCamp].Label(), personnes[candidature.IdPersonne]})
+	}
+	slices.SortFunc(out, func(a, b CandidatureExt) int {
+		if c := b.Candidature.Moment.Compare(a.Candidature.Moment); c != 0 {
+			return c
+		}
+		return int(b.Candidature.Id - a.Candidature.Id)
+	})
+	return out, nil
+}
+
+// loadCandidature vérifie que [acteur] (s'il est défini)
+// a accès à la candidature [id].
+func loadCandidature(db cps.DB, id cps.IdCandidature, acteur cps.OptIdCamp) (cps.Candidature, error) {
+	candidature, err := cps.SelectCandidature(db, id)
+	if err != nil {
+		return candidature, utils.SQLError(err)
+	}
+	if acteur.Valid && !acteur.Is(candidature.IdCamp) {
+		return candidature, errors.New("access forbidden")
+	}
+	return candidature, nil
+}
+
+// SetStatutCandidature présélectionne la candidature [id]
+// ou la remet en attente.
+// Voir [AccepteCandidature] et [RefuseCandidature] pour les autres statuts.
+func SetStatutCandidature(db cps.DB, id cps.IdCandidature, statut cps.StatutCandidature, acteur cps.OptIdCamp) error {
+	candidature, err := loadCandidature(db, id, acteur)
+	if err != nil {
+		return err
+	}
+	if statut != cps.CandidatureAttente && statut != cps.Preselectionnee {
+		return errors.New("internal error: invalid Statut")
+	}
+	if candidature.Statut == cps.Acceptee {
+		return errors.New("La candidature a déjà été acceptée.")
+	}
+	candidature.Statut = statut
+	_, err = candidature.Update(db)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	return nil
+}
+
+// AccepteCandidatureIn indique la candidature à accepter
+// et comment identifier le profil (temporaire) du candidat.
+// Voir [IdentTarget] pour les champs [Rattache] et [RattacheTo].
+type AccepteCandidatureIn struct {
+	IdCandidature cps.IdCandidature
+
+	Rattache   bool
+	RattacheTo pr.IdPersonne
+}
+
+// AccepteCandidature identifie le profil du candidat puis l'ajoute à l'équipe
+// du séjour, avec le rôle demandé. La fiche équipier est créée (ou complétée)
+// avec les informations de la candidature.
+// Toutes les modifications sont effectuées dans une unique transaction.
+func AccepteCandidature(db *sql.DB, builtins fs.Builtins, asso string, args AccepteCandidatureIn, acteur cps.OptIdCamp) (cps.Equipier, error) {
+	candidature, err := loadCandidature(db, args.IdCandidature, acteur)
+	if err != nil {
+		return cps.Equipier{}, err
+	}
+	if candidature.Statut == cps.Acceptee {
+		return cps.Equipier{}, errors.New("La candidature a déjà été acceptée.")
+	}
+	personne, err := pr.SelectPersonne(db, candidature.IdPersonne)
+	if err != nil {
+		return cps.Equipier{}, utils.SQLError(err)
+	}
+	// profil retenu après identification
+	if personne.IsTemp && args.Rattache {
+		candidature.IdPersonne = args.RattacheTo
+	}
+
+	equipiers, err := cps.SelectEquipiersByIdCamps(db, candidature.IdCamp)
+	if err != nil {
+		return cps.Equipier{}, utils.SQLError(err)
+	}
+	if _, is := equipiers.ByIdPersonne()[candidature.IdPersonne]; is {
+		return cps.Equipier{}, errors.New("Ce profil est déjà dans la liste des équipiers du séjour.")
+	}
+	if _, hasDirecteur := equipiers.Directeur(); candidature.Role == cps.Direction && hasDirecteur {
+		return cps.Equipier{}, errors.New("Le séjour a déjà un directeur.")
+	}
+
+	var equipier cps.Equipier
+	err = utils.InTx(db, func(tx *sql.Tx) error {
+		if personne.IsTemp {
+			// les autres candidatures du profil sont aussi redirigées
+			_, err = identifiePersonne(tx, IdentTarget{IdTemporaire: personne.Id, Rattache: args.Rattache, RattacheTo: args.RattacheTo})
+			if err != nil {
+				return err
+			}
+		}
+
+		equipier, err = cps.Equipier{IdCamp: candidature.IdCamp, IdPersonne: candidature.IdPersonne, Roles: cps.Roles{candidature.Role}}.Insert(tx)
+		if err != nil {
+			return err
+		}
+		demandes := builtins.Defaut(equipier.Id, equipier.Roles, asso)
+		if err = fs.InsertManyDemandeEquipiers(tx, demandes...); err != nil {
+			return err
+		}
+
+		fiche, _, err := pr.SelectFicheequipierByIdPersonne(tx, candidature.IdPersonne)
+		if err != nil {
+			return err
+		}
+		fiche.IdPersonne = candidature.IdPersonne
+		completeFiche(&fiche, candidature)
+		if _, err = pr.DeleteFicheequipiersByIdPersonnes(tx, fiche.IdPersonne); err != nil {
+			return err
+		}
+		if err = fiche.Insert(tx); err != nil {
+			return err
+		}
+
+		candidature.Statut = cps.Acceptee
+		_, err = candidature.Update(tx)
+		return err
+	})
+	if err != nil {
+		return cps.Equipier{}, err
+	}
+	return equipier, nil
+}
+
+// completeFiche remplit les champs vides de la fiche
+// avec le contenu de la candidature.
+func completeFiche(fiche *pr.Ficheequipier, candidature cps.Candidature) {
+	if fiche.Diplome == pr.DAucun {
+		fiche.Diplome = candidature.Diplome
+	}
+	if fiche.Approfondissement == pr.AAucun {
+		fiche.Approfondissement = candidature.Approfondissement
+	}
+	if fiche.Formation == "" {
+		fiche.Formation = candidature.Formation
+	}
+	if fiche.Profession == "" {
+		fiche.Profession = candidature.Profession
+	}
+	if fiche.ExperienceTravailJeunes == "" {
+		fiche.ExperienceTravailJeunes = candidature.ExperienceTravailJeunes
+	}
+}
+
+// RefuseCandidatureIn indique la candidature à décliner.
+type RefuseCandidatureIn struct {
+	IdCandidature cps.IdCandidature
+	Message       string // optionnel, ajouté au mail
+	SendMail      bool
+}
+
+// RefuseCandidature décline la candidature et prévient le candidat par mail,
+// si demandé.
+func RefuseCandidature(db *sql.DB, smtp config.SMTP, asso config.Asso, args RefuseCandidatureIn, acteur cps.OptIdCamp) error {
+	candidature, err := loadCandidature(db, args.IdCandidature, acteur)
+	if err != nil {
+		return err
+	}
+	if candidature.Statut == cps.Acceptee {
+		return errors.New("La candidature a déjà été acceptée.")
+	}
+	camp, err := cps.SelectCamp(db, candidature.IdCamp)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	personne, err := pr.SelectPersonne(db, candidature.IdPersonne)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+
+	return utils.InTx(db, func(tx *sql.Tx) error {
+		candidature.Statut = cps.Refusee
+		_, err = candidature.Update(tx)
+		if err != nil {
+			return err
+		}
+		if !args.SendMail {
+			return nil
+		}
+		html, err := mails.RefuseCandidature(asso, mails.NewContact(&personne), camp.Label(), strings.TrimSpace(args.Message))
+		if err != nil {
+			return err
+		}
+		return mails.NewMailer(smtp, asso.MailsSettings).SendMail(personne.Mail, "Candidature équipier", html, nil, nil)
+	})
+}
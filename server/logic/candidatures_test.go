@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestCandidatureCheck(t *testing.T) {
+	open := cps.Camps{1: {Id: 1}}
+	args := CandidatureIn{
+		Identite: pr.Identite{Nom: "Durand", Prenom: "Lucie", Mail: "lucie@free.fr", DateNaissance: shared.NewDate(2000, time.March, 4)},
+		Camps:    []CandidatureCamp{{IdCamp: 1, Role: cps.Animation}},
+	}
+	tu.AssertNoErr(t, args.check(open))
+
+	args.Camps = []CandidatureCamp{{IdCamp: 2, Role: cps.Animation}}
+	tu.AssertErr(t, args.check(open)) // séjour fermé
+	args.Camps = []CandidatureCamp{{IdCamp: 1, Role: cps.Animation}, {IdCamp: 1, Role: cps.Cuisine}}
+	tu.AssertErr(t, args.check(open))
+	args.Camps = nil
+	tu.AssertErr(t, args.check(open))
+
+	fiche := pr.Ficheequipier{Formation: "BAFA"}
+	completeFiche(&fiche, cps.Candidature{Diplome: pr.DBafa, Formation: "Autre", Profession: "Étudiante"})
+	tu.Assert(t, fiche.Diplome == pr.DBafa && fiche.Formation == "BAFA" && fiche.Profession == "Étudiante")
+}
+
+func TestCandidatures(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	debut := shared.NewDateFrom(time.Now().Add(30 * 24 * time.Hour))
+	camp1, err := cps.Camp{IdTaux: 1, DateDebut: debut, Duree: 5, Statut: cps.VisibleFerme}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp2, err := cps.Camp{IdTaux: 1, DateDebut: debut, Duree: 5, Statut: cps.VisibleFerme}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	candidatures, err := SaveCandidature(db.DB, CandidatureIn{
+		Identite: pr.Identite{Nom: "Durand", Prenom: "Lucie", Mail: "lucie@free.fr", DateNaissance: shared.NewDate(2000, time.March, 4)},
+		Camps:    []CandidatureCamp{{IdCamp: camp1.Id, Role: cps.Animation}, {IdCamp: camp2.Id, Role: cps.Cuisine}},
+		Diplome:  pr.DBafa,
+	})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(candidatures) == 2)
+	temp, err := pr.SelectPersonne(db, candidatures[0].IdPersonne)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, temp.IsTemp)
+
+	l, err := LoadCandidatures(db, camp1.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1 && l[0].Personne.Nom == "Durand")
+
+	// les directeurs n'ont accès qu'à leur séjour
+	err = SetStatutCandidature(db, candidatures[1].Id, cps.Preselectionnee, camp1.Id.Opt())
+	tu.AssertErr(t, err)
+	err = SetStatutCandidature(db, candidatures[0].Id, cps.Preselectionnee, camp1.Id.Opt())
+	tu.AssertNoErr(t, err)
+
+	builtins, err := fs.LoadBuiltins(db)
+	tu.AssertNoErr(t, err)
+	existant, err := pr.Personne{Identite: pr.Identite{Nom: "Durand", Prenom: "Lucie"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	equipier, err := AccepteCandidature(db.DB, builtins, "acve", AccepteCandidatureIn{
+		IdCandidature: candidatures[0].Id, Rattache: true, RattacheTo: existant.Id,
+	}, camp1.Id.Opt())
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, equipier.IdPersonne == existant.Id && equipier.Roles.Is(cps.Animation))
+
+	fiche, found, err := pr.SelectFicheequipierByIdPersonne(db, existant.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && fiche.Diplome == pr.DBafa)
+
+	// l'autre candidature suit le profil identifié
+	other, err := cps.SelectCandidature(db, candidatures[1].Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, other.IdPersonne == existant.Id)
+
+	_, err = AccepteCandidature(db.DB, builtins, "acve", AccepteCandidatureIn{IdCandidature: candidatures[0].Id}, cps.OptIdCamp{})
+	tu.AssertErr(t, err) // déjà acceptée
+
+	// nouveau profil : la fiche équipier est créée
+	candidatures, err = SaveCandidature(db.DB, CandidatureIn{
+		Identite:   pr.Identite{Nom: "Martin", Prenom: "Paul", Mail: "paul@free.fr", DateNaissance: shared.NewDate(1990, time.May, 2)},
+		Camps:      []CandidatureCamp{{IdCamp: camp2.Id, Role: cps.Direction}},
+		Profession: "Professeur",
+	})
+	tu.AssertNoErr(t, err)
+	equipier, err = AccepteCandidature(db.DB, builtins, "acve", AccepteCandidatureIn{IdCandidature: candidatures[0].Id}, cps.OptIdCamp{})
+	tu.AssertNoErr(t, err)
+	personne, err := pr.SelectPersonne(db, equipier.IdPersonne)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !personne.IsTemp)
+	fiche, found, err = pr.SelectFicheequipierByIdPersonne(db, equipier.IdPersonne)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && fiche.Profession == "Professeur")
+}
@@ -0,0 +1,182 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"registro/crypto"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	sh "registro/sql/shared"
+	"registro/utils"
+)
+
+// certifications des équipiers (formations, permis), avec leurs dates
+// de validité, et alertes pour les directeurs
+
+// CertificationsPersonne regroupe les certifications d'une personne
+// et leurs justificatifs.
+type CertificationsPersonne struct {
+	Certifications []pr.Certification                // par date d'obtention
+	Justificatifs  map[pr.IdCertification]PublicFile // optionnel
+}
+
+// CheckCertification normalise et vérifie une certification
+// saisie par un équipier.
+func CheckCertification(certification pr.Certification) (pr.Certification, error) {
+	certification.Label = strings.TrimSpace(certification.Label)
+	if certification.Kind == pr.CAutre && certification.Label == "" {
+		return certification, errors.New("Merci de préciser l'intitulé de la certification.")
+	}
+	obtention, expiration := certification.Obtention.Time(), certification.Expiration.Time()
+	if obtention.IsZero() {
+		return certification, errors.New("La date d'obtention est requise.")
+	}
+	if !expiration.IsZero() && expiration.Before(obtention) {
+		return certification, errors.New("La date d'expiration doit suivre la date d'obtention.")
+	}
+	return certification, nil
+}
+
+func loadCertifications(db cps.DB, key crypto.Encrypter, idPersonnes ...pr.IdPersonne) (map[pr.IdPersonne]CertificationsPersonne, error) {
+	certifications, err := pr.SelectCertificationsByIdPersonnes(db, idPersonnes...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	links, err := fs.SelectFileCertificationsByIdCertifications(db, certifications.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	files, err := fs.SelectFiles(db, links.IdFiles()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	byCertification := links.ByIdCertification()
+
+	out := make(map[pr.IdPersonne]CertificationsPersonne)
+	for idPersonne, certifications := range certifications.ByIdPersonne() {
+		item := CertificationsPersonne{
+			Certifications: utils.MapValues(certifications),
+			Justificatifs:  make(map[pr.IdCertification]PublicFile),
+		}
+		slices.SortFunc(item.Certifications, func(a, b pr.Certification) int {
+			return a.Obtention.Time().Compare(b.Obtention.Time())
+		})
+		for _, certification := range item.Certifications {
+			if link, has := byCertification[certification.Id]; has {
+				item.Justificatifs[certification.Id] = NewPublicFile(key, files[link.IdFile])
+			}
+		}
+		out[idPersonne] = item
+	}
+	return out, nil
+}
+
+// LoadCertificationsPersonne renvoie les certifications de la personne.
+func LoadCertificationsPersonne(db cps.DB, key crypto.Encrypter, idPersonne pr.IdPersonne) (CertificationsPersonne, error) {
+	all, err := loadCertifications(db, key, idPersonne)
+	if err != nil {
+		return CertificationsPersonne{}, err
+	}
+	return all[idPersonne], nil
+}
+
+// AlerteCertification signale une certification d'un équipier
+// expirant avant la fin du séjour, ou manquante pour son rôle.
+type AlerteCertification struct {
+	IdEquipier cps.IdEquipier
+	Equipier   string
+	Kind       pr.CertificationKind
+	Expiration sh.Date // zéro si la certification est manquante
+	Message    string
+}
+
+// alertesCertifications vérifie les [certifications] de l'équipier
+// à la date [fin] du séjour.
+func alertesCertifications(fin time.Time, equipier cps.Equipier, nom string, certifications []pr.Certification) (out []AlerteCertification) {
+	// une certification renouvelée n'est signalée que si
+	// aucune version n'est valide
+	byKind := make(map[pr.CertificationKind][]pr.Certification)
+	for _, certification := range certifications {
+		if certification.Kind == pr.CAutre {
+			continue
+		}
+		byKind[certification.Kind] = append(byKind[certification.Kind], certification)
+	}
+	isValide := func(kind pr.CertificationKind) bool {
+		return slices.ContainsFunc(byKind[kind], func(c pr.Certification) bool { return c.IsValideAu(fin) })
+	}
+
+	kinds := utils.MapKeys(byKind)
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		if isValide(kind) {
+			continue
+		}
+		last := slices.MaxFunc(byKind[kind], func(a, b pr.Certification) int {
+			return a.Expiration.Time().Compare(b.Expiration.Time())
+		})
+		out = append(out, AlerteCertification{
+			IdEquipier: equipier.Id, Equipier: nom, Kind: kind, Expiration: last.Expiration,
+			Message: fmt.Sprintf("%s : %s expire le %s, avant la fin du séjour.", nom, kind, last.Expiration),
+		})
+	}
+
+	if equipier.Roles.Is(cps.Chauffeur) && !isValide(pr.CPermisB) && !isValide(pr.CPermisD) {
+		out = append(out, AlerteCertification{
+			IdEquipier: equipier.Id, Equipier: nom, Kind: pr.CPermisB,
+			Message: fmt.Sprintf("%s : aucun permis de conduire valide pour le rôle de chauffeur.", nom),
+		})
+	}
+	return out
+}
+
+// CertificationsEquipier ajoute l'équipier à ses certifications.
+type CertificationsEquipier struct {
+	IdEquipier cps.IdEquipier
+	Equipier   string
+	Roles      cps.Roles
+	CertificationsPersonne
+}
+
+// CertificationsCamp regroupe les certifications de l'équipe
+// d'un séjour, et les alertes associées.
+type CertificationsCamp struct {
+	Equipiers []CertificationsEquipier // par ordre alphabétique
+	Alertes   []AlerteCertification
+}
+
+// LoadCertificationsCamp renvoie les certifications des équipiers du séjour [idCamp],
+// en signalant celles qui expirent avant la fin du séjour.
+func LoadCertificationsCamp(db cps.DB, key crypto.Encrypter, idCamp cps.IdCamp) (CertificationsCamp, error) {
+	camp, err := cps.SelectCamp(db, idCamp)
+	if err != nil {
+		return CertificationsCamp{}, utils.SQLError(err)
+	}
+	equipiers, personnes, _, err := cps.LoadEquipiersByCamps(db, idCamp)
+	if err != nil {
+		return CertificationsCamp{}, err
+	}
+	certifications, err := loadCertifications(db, key, equipiers.IdPersonnes()...)
+	if err != nil {
+		return CertificationsCamp{}, err
+	}
+
+	fin := camp.Plage().To().Time()
+	var out CertificationsCamp
+	for _, equipier := range equipiers {
+		nom := personnes[equipier.IdPersonne].NOMPrenom()
+		item := certifications[equipier.IdPersonne]
+		out.Equipiers = append(out.Equipiers, CertificationsEquipier{equipier.Id, nom, equipier.Roles, item})
+	}
+	slices.SortFunc(out.Equipiers, func(a, b CertificationsEquipier) int { return strings.Compare(a.Equipier, b.Equipier) })
+	for _, item := range out.Equipiers {
+		equipier := equipiers[item.IdEquipier]
+		out.Alertes = append(out.Alertes, alertesCertifications(fin, equipier, item.Equipier, item.Certifications)...)
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestCheckCertification(t *testing.T) {
+	obtention := shared.NewDate(2022, time.May, 2)
+	for _, test := range []struct {
+		certification pr.Certification
+		ok            bool
+	}{
+		{pr.Certification{Kind: pr.CBafa, Obtention: obtention}, true},
+		{pr.Certification{Kind: pr.CBafa}, false},
+		{pr.Certification{Kind: pr.CPsc1, Obtention: obtention, Expiration: shared.NewDate(2021, time.May, 2)}, false},
+		{pr.Certification{Kind: pr.CPsc1, Obtention: obtention, Expiration: shared.NewDate(2025, time.May, 2)}, true},
+		{pr.Certification{Kind: pr.CAutre, Obtention: obtention, Label: "  "}, false},
+		{pr.Certification{Kind: pr.CAutre, Obtention: obtention, Label: " Brevet de voile "}, true},
+	} {
+		out, err := CheckCertification(test.certification)
+		tu.Assert(t, (err == nil) == test.ok)
+		if test.ok && test.certification.Kind == pr.CAutre {
+			tu.Assert(t, out.Label == "Brevet de voile")
+		}
+	}
+}
+
+func TestAlertesCertifications(t *testing.T) {
+	fin := time.Date(2024, time.July, 20, 0, 0, 0, 0, time.UTC)
+	equipier := cps.Equipier{Id: 1, Roles: cps.Roles{cps.Animation}}
+	obtention := shared.NewDate(2020, time.January, 1)
+
+	// sans date d'expiration
+	alertes := alertesCertifications(fin, equipier, "A", []pr.Certification{
+		{Kind: pr.CBafa, Obtention: obtention},
+	})
+	tu.Assert(t, len(alertes) == 0)
+
+	// expirée avant la fin du séjour
+	expiree := pr.Certification{Kind: pr.CSurveillantBaignade, Obtention: obtention, Expiration: shared.NewDate(2024, time.July, 15)}
+	alertes = alertesCertifications(fin, equipier, "A", []pr.Certification{expiree})
+	tu.Assert(t, len(alertes) == 1)
+	tu.Assert(t, alertes[0].Kind == pr.CSurveillantBaignade && alertes[0].Expiration == expiree.Expiration)
+
+	// renouvelée
+	renouvelee := pr.Certification{Kind: pr.CSurveillantBaignade, Obtention: shared.NewDate(2024, time.June, 1), Expiration: shared.NewDate(2029, time.June, 1)}
+	alertes = alertesCertifications(fin, equipier, "A", []pr.Certification{expiree, renouvelee})
+	tu.Assert(t, len(alertes) == 0)
+
+	// les certifications libres ne sont pas vérifiées
+	alertes = alertesCertifications(fin, equipier, "A", []pr.Certification{
+		{Kind: pr.CAutre, Label: "Voile", Obtention: obtention, Expiration: shared.NewDate(2021, time.January, 1)},
+	})
+	tu.Assert(t, len(alertes) == 0)
+
+	// chauffeur sans permis
+	chauffeur := cps.Equipier{Id: 2, Roles: cps.Roles{cps.Chauffeur}}
+	alertes = alertesCertifications(fin, chauffeur, "B", nil)
+	tu.Assert(t, len(alertes) == 1 && alertes[0].IdEquipier == 2)
+
+	alertes = alertesCertifications(fin, chauffeur, "B", []pr.Certification{
+		{Kind: pr.CPermisD, Obtention: obtention, Expiration: shared.NewDate(2024, time.July, 1)},
+	})
+	tu.Assert(t, len(alertes) == 2) // permis expiré + rôle de chauffeur
+
+	alertes = alertesCertifications(fin, chauffeur, "B", []pr.Certification{
+		{Kind: pr.CPermisB, Obtention: obtention},
+	})
+	tu.Assert(t, len(alertes) == 0)
+}
@@ -0,0 +1,174 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+)
+
+// RegimesCuisine est la liste des régimes décomptés pour l'équipe cuisine.
+var RegimesCuisine = [...]pr.RegimeAlimentaire{pr.Vegetarien, pr.Vegetalien, pr.SansPorc, pr.SansGluten, pr.SansLactose}
+
+// FiltreCuisine restreint la synthèse à un groupe
+// et à certains jours du séjour.
+type FiltreCuisine struct {
+	IdGroupe cps.IdGroupe // 0 pour tous les groupes
+	Jours    cps.Jours    // vide pour tout le séjour
+}
+
+// ConviveCuisine ne contient que les informations utiles
+// à l'équipe cuisine : les autres données médicales ne sont pas exposées.
+type ConviveCuisine struct {
+	Personne string // NOM Prénom
+	Groupe   string
+	Jours    cps.Jours // jours de présence (0-based), filtrés
+	Presence string    // description de [Jours]
+	Regimes  pr.Regimes
+	// AllergiesAlimentaires contient les allergènes concernés,
+	// ou "Oui" s'ils ne sont pas précisés
+	AllergiesAlimentaires string
+}
+
+// HasParticularite renvoie true si le convive suit un régime
+// ou a une allergie alimentaire.
+func (cv ConviveCuisine) HasParticularite() bool {
+	return len(cv.Regimes) != 0 || cv.AllergiesAlimentaires != ""
+}
+
+// RepasJour décompte les convives d'une journée du séjour.
+type RepasJour struct {
+	Index     int // jour du séjour (0-based)
+	Jour      shared.Date
+	Total     int
+	Regimes   [len(RegimesCuisine)]int // dans l'ordre de [RegimesCuisine]
+	Allergies int                      // allergies alimentaires
+}
+
+// SyntheseCuisine résume les régimes et allergies alimentaires
+// des inscrits d'un séjour, à destination de l'équipe cuisine.
+type SyntheseCuisine struct {
+	Camp    string      // label
+	Groupes cps.Groupes // pour le filtre
+
+	Convives []ConviveCuisine // par ordre alphabétique
+	Repas    []RepasJour      // un par jour sélectionné
+}
+
+// joursPresence renvoie les jours (0-based) de présence du participant,
+// en prenant en compte une éventuelle option à la journée.
+func joursPresence(camp cps.Camp, participant cps.Participant) []int {
+	if camp.OptionPrix.Active == cps.PrixJour && len(participant.OptionPrix.Jour) != 0 {
+		out := make([]int, 0, len(participant.OptionPrix.Jour))
+		for _, jour := range participant.OptionPrix.Jour {
+			out = append(out, int(jour))
+		}
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	out := make([]int, camp.Duree)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func allergiesAlimentaires(allergies pr.Allergies) string {
+	if !allergies.Alimentaires {
+		return ""
+	}
+	if aliments := strings.TrimSpace(allergies.Aliments); aliments != "" {
+		return aliments
+	}
+	return "Oui"
+}
+
+// LoadSyntheseCuisine charge les fiches sanitaires des inscrits du séjour [idCamp]
+// et renvoie leurs régimes et allergies alimentaires.
+func LoadSyntheseCuisine(db cps.DB, idCamp cps.IdCamp, filtre FiltreCuisine) (SyntheseCuisine, error) {
+	camp, err := cps.LoadCamp(db, idCamp)
+	if err != nil {
+		return SyntheseCuisine{}, err
+	}
+	fiches, err := pr.SelectFichesanitairesByIdPersonnes(db, camp.Personnes(true).IDs()...)
+	if err != nil {
+		return SyntheseCuisine{}, utils.SQLError(err)
+	}
+	groupes, err := cps.SelectGroupesByIdCamps(db, idCamp)
+	if err != nil {
+		return SyntheseCuisine{}, utils.SQLError(err)
+	}
+	links, err := cps.SelectGroupeParticipantsByIdCamps(db, idCamp)
+	if err != nil {
+		return SyntheseCuisine{}, utils.SQLError(err)
+	}
+	return newSyntheseCuisine(camp.Camp, camp.Participants(true), fiches.ByIdPersonne(), groupes, links.ByIdParticipant(), filtre), nil
+}
+
+func newSyntheseCuisine(camp cps.Camp, inscrits []cps.ParticipantPersonne, fiches map[pr.IdPersonne]pr.Fichesanitaire,
+	groupes cps.Groupes, links map[cps.IdParticipant]cps.GroupeParticipant, filtre FiltreCuisine,
+) SyntheseCuisine {
+	selected := make([]bool, camp.Duree)
+	for i := range selected {
+		selected[i] = len(filtre.Jours) == 0 || slices.Contains(filtre.Jours, int32(i))
+	}
+
+	out := SyntheseCuisine{Camp: camp.Label(), Groupes: groupes}
+	for i := range selected {
+		if selected[i] {
+			out.Repas = append(out.Repas, RepasJour{Index: i, Jour: camp.DateDebut.AddDays(i)})
+		}
+	}
+	// index des jours sélectionnés dans [out.Repas]
+	repasIndex := make([]int, camp.Duree)
+	index := 0
+	for i := range selected {
+		repasIndex[i] = index
+		if selected[i] {
+			index++
+		}
+	}
+
+	for _, inscrit := range inscrits {
+		link, hasGroupe := links[inscrit.Participant.Id]
+		if filtre.IdGroupe != 0 && (!hasGroupe || link.IdGroupe != filtre.IdGroupe) {
+			continue
+		}
+		fiche := fiches[inscrit.Personne.Id]
+		convive := ConviveCuisine{
+			Personne:              inscrit.Personne.NOMPrenom(),
+			Regimes:               fiche.Regimes,
+			AllergiesAlimentaires: allergiesAlimentaires(fiche.Allergies),
+		}
+		if hasGroupe {
+			convive.Groupe = groupes[link.IdGroupe].Nom
+		}
+		for _, jour := range joursPresence(camp, inscrit.Participant) {
+			if jour >= camp.Duree || !selected[jour] {
+				continue
+			}
+			convive.Jours = append(convive.Jours, int32(jour))
+
+			repas := &out.Repas[repasIndex[jour]]
+			repas.Total++
+			for i, regime := range RegimesCuisine {
+				if slices.Contains(convive.Regimes, regime) {
+					repas.Regimes[i]++
+				}
+			}
+			if convive.AllergiesAlimentaires != "" {
+				repas.Allergies++
+			}
+		}
+		if len(convive.Jours) == 0 { // absent sur la période
+			continue
+		}
+		convive.Presence = convive.Jours.Description(camp.Plage())
+		out.Convives = append(out.Convives, convive)
+	}
+	slices.SortFunc(out.Convives, func(a, b ConviveCuisine) int { return strings.Compare(a.Personne, b.Personne) })
+	return out
+}
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestSyntheseCuisine(t *testing.T) {
+	camp := cps.Camp{DateDebut: shared.NewDate(2024, time.July, 8), Duree: 4, OptionPrix: cps.OptionPrixCamp{Active: cps.PrixJour}}
+	inscrits := []cps.ParticipantPersonne{
+		{Participant: cps.Participant{Id: 1}, Personne: pr.Personne{Id: 1, Identite: pr.Identite{Nom: "B"}}},
+		{Participant: cps.Participant{Id: 2, OptionPrix: cps.OptionPrixParticipant{Jour: cps.Jours{2, 3}}}, Personne: pr.Personne{Id: 2, Identite: pr.Identite{Nom: "A"}}},
+		{Participant: cps.Participant{Id: 3}, Personne: pr.Personne{Id: 3, Identite: pr.Identite{Nom: "C"}}},
+	}
+	fiches := map[pr.IdPersonne]pr.Fichesanitaire{
+		1: {Regimes: pr.Regimes{pr.Vegetarien, pr.SansGluten}},
+		2: {
+			Allergies:        pr.Allergies{Alimentaires: true, Medicamenteuses: true, Aliments: "arachides", Precisions: "secret", ConduiteATenir: "secret"},
+			DifficultesSante: "secret",
+		},
+		3: {Allergies: pr.Allergies{Asthme: true}},
+	}
+	groupes := cps.Groupes{1: {Id: 1, Nom: "Petits"}, 2: {Id: 2, Nom: "Grands"}}
+	links := map[cps.IdParticipant]cps.GroupeParticipant{1: {IdParticipant: 1, IdGroupe: 1}, 2: {IdParticipant: 2, IdGroupe: 2}}
+
+	out := newSyntheseCuisine(camp, inscrits, fiches, groupes, links, FiltreCuisine{})
+	tu.Assert(t, len(out.Convives) == 3 && len(out.Repas) == 4)
+	tu.Assert(t, out.Convives[0].Personne == "A " && out.Convives[0].AllergiesAlimentaires == "arachides")
+	tu.Assert(t, out.Convives[0].Groupe == "Grands" && len(out.Convives[0].Jours) == 2)
+	tu.Assert(t, !out.Convives[2].HasParticularite()) // l'asthme n'est pas exposé
+	tu.Assert(t, out.Repas[0].Total == 2 && out.Repas[0].Allergies == 0 && out.Repas[0].Regimes[0] == 1)
+	tu.Assert(t, out.Repas[3].Total == 3 && out.Repas[3].Allergies == 1 && out.Repas[3].Regimes[3] == 1)
+
+	out = newSyntheseCuisine(camp, inscrits, fiches, groupes, links, FiltreCuisine{IdGroupe: 1})
+	tu.Assert(t, len(out.Convives) == 1 && out.Convives[0].Personne == "B ")
+
+	out = newSyntheseCuisine(camp, inscrits, fiches, groupes, links, FiltreCuisine{Jours: cps.Jours{0, 1}})
+	tu.Assert(t, len(out.Convives) == 2 && len(out.Repas) == 2)
+	tu.Assert(t, out.Repas[1].Jour == camp.DateDebut.AddDays(1) && out.Repas[1].Total == 2)
+}
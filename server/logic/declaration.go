@@ -0,0 +1,179 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	sh "registro/sql/shared"
+)
+
+// déclaration du séjour auprès de Jeunesse et Sport (téléprocédure TAM/SIAM) :
+// liste de l'équipe (fiche complémentaire) et effectifs par tranche d'âge
+
+// DeclarationEquipier est une ligne de la liste de l'équipe.
+type DeclarationEquipier struct {
+	IdEquipier cps.IdEquipier
+
+	Nom, Prenom                            string
+	Sexe                                   pr.Sexe
+	DateNaissance                          sh.Date
+	DepartementNaissance, CommuneNaissance string // déduits du numéro de sécurité sociale
+
+	Fonctions         string
+	Diplome           pr.Diplome
+	Approfondissement pr.Approfondissement
+	Presence          sh.Plage
+
+	Manquants []string // champs manquants ou invalides
+}
+
+// EffectifTranche est le nombre d'inscrits d'une tranche d'âge.
+type EffectifTranche struct {
+	Label    string
+	Effectif int
+}
+
+// tranches utilisées par la déclaration : moins de 6 ans, 6-13 ans, 14-17 ans, majeurs
+var tranchesDeclaration = [...]struct {
+	label  string
+	ageMax int // inclusif
+}{
+	{"Moins de 6 ans", 5},
+	{"6 à 13 ans", 13},
+	{"14 à 17 ans", 17},
+	{"18 ans et plus", 1000},
+}
+
+// DeclarationCamp rassemble les données attendues
+// par la déclaration d'un séjour.
+type DeclarationCamp struct {
+	IdCamp   cps.IdCamp
+	Camp     string // label
+	Agrement string
+	Lieu     string
+	Dates    sh.Plage
+
+	Equipiers []DeclarationEquipier // par ordre alphabétique
+	Effectifs []EffectifTranche
+
+	Manquants []string // informations du séjour manquantes
+}
+
+// IsComplete renvoie true si aucune information ne manque.
+func (dc DeclarationCamp) IsComplete() bool {
+	if len(dc.Manquants) != 0 {
+		return false
+	}
+	for _, equipier := range dc.Equipiers {
+		if len(equipier.Manquants) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// needDiplome renvoie true pour les fonctions d'encadrement,
+// pour lesquelles la qualification est déclarée.
+func needDiplome(roles cps.Roles) bool {
+	return roles.Is(cps.Direction) || roles.Is(cps.Adjoint) || roles.Is(cps.Animation)
+}
+
+func newDeclarationEquipier(camp cps.Camp, equipier cps.Equipier, personne pr.Personne, fiche pr.Ficheequipier) DeclarationEquipier {
+	out := DeclarationEquipier{
+		IdEquipier:        equipier.Id,
+		Nom:               personne.FNom(),
+		Prenom:            personne.FPrenom(),
+		Sexe:              personne.Sexe,
+		DateNaissance:     personne.DateNaissance,
+		Fonctions:         equipier.Roles.String(),
+		Diplome:           fiche.Diplome,
+		Approfondissement: fiche.Approfondissement,
+		Presence:          equipier.Presence.Plage(camp.Plage()),
+	}
+	if strings.TrimSpace(personne.Nom) == "" {
+		out.Manquants = append(out.Manquants, "Nom")
+	}
+	if strings.TrimSpace(personne.Prenom) == "" {
+		out.Manquants = append(out.Manquants, "Prénom")
+	}
+	if personne.Sexe == pr.NoSexe {
+		out.Manquants = append(out.Manquants, "Sexe")
+	}
+	if personne.DateNaissance.Time().IsZero() {
+		out.Manquants = append(out.Manquants, "Date de naissance")
+	}
+	if strings.TrimSpace(fiche.SecuriteSociale) == "" {
+		out.Manquants = append(out.Manquants, "Numéro de sécurité sociale (lieu de naissance)")
+	} else if check := CheckSecuriteSociale(personne.Sexe, personne.DateNaissance.Time(), fiche.SecuriteSociale); check.Err != "" {
+		out.Manquants = append(out.Manquants, fmt.Sprintf("Numéro de sécurité sociale invalide : %s", check.Err))
+	} else {
+		out.DepartementNaissance, out.CommuneNaissance = check.DepartementNaissance, check.CommuneNaissance
+		if out.CommuneNaissance == "" {
+			out.Manquants = append(out.Manquants, "Commune de naissance")
+		}
+	}
+	if needDiplome(equipier.Roles) && fiche.Diplome == pr.DAucun {
+		out.Manquants = append(out.Manquants, "Diplôme")
+	}
+	return out
+}
+
+func newDeclarationCamp(camp cps.Camp, inscrits []cps.ParticipantPersonne,
+	equipiers cps.Equipiers, personnes pr.Personnes, fiches map[pr.IdPersonne]pr.Ficheequipier,
+) DeclarationCamp {
+	out := DeclarationCamp{
+		IdCamp:   camp.Id,
+		Camp:     camp.Label(),
+		Agrement: camp.Agrement,
+		Lieu:     camp.Lieu,
+		Dates:    camp.Plage(),
+	}
+	if strings.TrimSpace(camp.Agrement) == "" {
+		out.Manquants = append(out.Manquants, "Numéro d'agrément")
+	}
+	if strings.TrimSpace(camp.Lieu) == "" {
+		out.Manquants = append(out.Manquants, "Lieu")
+	}
+
+	for _, equipier := range equipiers {
+		out.Equipiers = append(out.Equipiers, newDeclarationEquipier(camp, equipier, personnes[equipier.IdPersonne], fiches[equipier.IdPersonne]))
+	}
+	slices.SortFunc(out.Equipiers, func(a, b DeclarationEquipier) int {
+		if c := strings.Compare(a.Nom, b.Nom); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Prenom, b.Prenom)
+	})
+
+	out.Effectifs = make([]EffectifTranche, len(tranchesDeclaration))
+	for i, tranche := range tranchesDeclaration {
+		out.Effectifs[i].Label = tranche.label
+	}
+	for _, inscrit := range inscrits {
+		age := camp.AgeDebutCamp(inscrit.Personne.DateNaissance)
+		for i, tranche := range tranchesDeclaration {
+			if age <= tranche.ageMax {
+				out.Effectifs[i].Effectif++
+				break
+			}
+		}
+	}
+	return out
+}
+
+// LoadDeclarationCamp renvoie les données de déclaration du séjour [idCamp],
+// avec les champs manquants pour chaque équipier.
+func LoadDeclarationCamp(db cps.DB, idCamp cps.IdCamp) (DeclarationCamp, error) {
+	camp, err := cps.LoadCamp(db, idCamp)
+	if err != nil {
+		return DeclarationCamp{}, err
+	}
+	equipiers, personnes, fiches, err := cps.LoadEquipiersByCamps(db, idCamp)
+	if err != nil {
+		return DeclarationCamp{}, err
+	}
+	return newDeclarationCamp(camp.Camp, camp.Participants(true), equipiers, personnes, fiches), nil
+}
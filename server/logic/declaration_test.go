@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestDeclarationCamp(t *testing.T) {
+	camp := cps.Camp{DateDebut: shared.NewDate(2024, time.July, 8), Duree: 10, Lieu: "Vercors"}
+	inscrits := []cps.ParticipantPersonne{
+		{Personne: pr.Personne{Identite: pr.Identite{DateNaissance: shared.NewDate(2020, time.January, 1)}}},
+		{Personne: pr.Personne{Identite: pr.Identite{DateNaissance: shared.NewDate(2014, time.January, 1)}}},
+		{Personne: pr.Personne{Identite: pr.Identite{DateNaissance: shared.NewDate(2014, time.January, 1)}}},
+		{Personne: pr.Personne{Identite: pr.Identite{DateNaissance: shared.NewDate(2008, time.January, 1)}}},
+	}
+	equipiers := cps.Equipiers{
+		1: {Id: 1, IdPersonne: 1, Roles: cps.Roles{cps.Direction}},
+		2: {Id: 2, IdPersonne: 2, Roles: cps.Roles{cps.Animation}},
+		3: {Id: 3, IdPersonne: 3, Roles: cps.Roles{cps.Cuisine}},
+	}
+	personnes := pr.Personnes{
+		1: {Id: 1, Identite: pr.Identite{Nom: "Durand", Prenom: "paul", Sexe: pr.Man, DateNaissance: shared.NewDate(1994, time.May, 1)}},
+		2: {Id: 2, Identite: pr.Identite{Nom: "Martin", Prenom: "Léa", Sexe: pr.Woman, DateNaissance: shared.NewDate(2004, time.May, 1)}},
+		3: {Id: 3, Identite: pr.Identite{Nom: "Petit", Prenom: "Jean", Sexe: pr.Man, DateNaissance: shared.NewDate(1994, time.May, 1)}},
+	}
+	fiches := map[pr.IdPersonne]pr.Ficheequipier{
+		1: {SecuriteSociale: "1 94 05 78 551 268 91", Diplome: pr.DBafd},
+		2: {SecuriteSociale: "2 04 05 78 551 268 91"},
+		3: {SecuriteSociale: "1 94 05 78 551 268 91"},
+	}
+
+	out := newDeclarationCamp(camp, inscrits, equipiers, personnes, fiches)
+	tu.Assert(t, len(out.Manquants) == 1) // agrément
+	tu.Assert(t, len(out.Equipiers) == 3 && out.Equipiers[0].Nom == "DURAND" && out.Equipiers[0].Prenom == "Paul")
+	tu.Assert(t, len(out.Equipiers[0].Manquants) == 0 && out.Equipiers[0].DepartementNaissance != "")
+	tu.Assert(t, len(out.Equipiers[1].Manquants) == 2) // sécurité sociale, diplôme
+	tu.Assert(t, len(out.Equipiers[2].Manquants) == 0) // pas de diplôme requis
+	tu.Assert(t, !out.IsComplete())
+	tu.Assert(t, out.Effectifs[0].Effectif == 1 && out.Effectifs[1].Effectif == 2 && out.Effectifs[2].Effectif == 1 && out.Effectifs[3].Effectif == 0)
+}
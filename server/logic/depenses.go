@@ -0,0 +1,157 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"registro/crypto"
+	cps "registro/sql/camps"
+	dn "registro/sql/dons"
+	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	sh "registro/sql/shared"
+	"registro/utils"
+)
+
+// notes de frais des équipiers : saisies depuis l'espace équipier,
+// validées par le directeur puis remboursées (ou converties en don)
+// par le centre d'inscriptions
+
+// DepenseExt ajoute les justificatifs à une note de frais.
+type DepenseExt struct {
+	Depense       cps.Depense
+	Justificatifs []PublicFile
+}
+
+// DepenseEquipier ajoute l'équipier et le séjour à une note de frais.
+type DepenseEquipier struct {
+	DepenseExt
+
+	IdPersonne pr.IdPersonne
+	Equipier   string
+	IdCamp     cps.IdCamp
+	Camp       string // label
+}
+
+// CheckDepense normalise et vérifie une note de frais
+// saisie par un équipier.
+func CheckDepense(depense cps.Depense) (cps.Depense, error) {
+	depense.Description = strings.TrimSpace(depense.Description)
+	if depense.Montant.Cent <= 0 {
+		return depense, errors.New("Le montant de la dépense doit être positif.")
+	}
+	if depense.Date.Time().IsZero() {
+		return depense, errors.New("La date de la dépense est requise.")
+	}
+	if depense.Categorie == cps.DepenseAutre && depense.Description == "" {
+		return depense, errors.New("Merci de préciser la nature de la dépense.")
+	}
+	return depense, nil
+}
+
+// loadJustificatifs renvoie les justificatifs des [depenses].
+func loadJustificatifs(db cps.DB, key crypto.Encrypter, depenses cps.Depenses) (map[cps.IdDepense][]PublicFile, error) {
+	links, err := fs.SelectFileDepensesByIdDepenses(db, depenses.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	files, err := fs.SelectFiles(db, links.IdFiles()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	out := make(map[cps.IdDepense][]PublicFile)
+	for _, link := range links {
+		out[link.IdDepense] = append(out[link.IdDepense], NewPublicFile(key, files[link.IdFile]))
+	}
+	return out, nil
+}
+
+func sortDepenses[T any](l []T, depense func(T) cps.Depense) {
+	slices.SortFunc(l, func(a, b T) int { return depense(a).Moment.Compare(depense(b).Moment) })
+}
+
+// LoadDepensesEquipier renvoie les notes de frais de l'équipier,
+// par date de dépôt.
+func LoadDepensesEquipier(db cps.DB, key crypto.Encrypter, idEquipier cps.IdEquipier) ([]DepenseExt, error) {
+	depenses, err := cps.SelectDepensesByIdEquipiers(db, idEquipier)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	justificatifs, err := loadJustificatifs(db, key, depenses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DepenseExt, 0, len(depenses))
+	for _, depense := range depenses {
+		out = append(out, DepenseExt{depense, justificatifs[depense.Id]})
+	}
+	sortDepenses(out, func(d DepenseExt) cps.Depense { return d.Depense })
+	return out, nil
+}
+
+// LoadDepensesCamps renvoie les notes de frais des équipiers
+// des séjours donnés, par date de dépôt.
+func LoadDepensesCamps(db cps.DB, key crypto.Encrypter, idCamps ...cps.IdCamp) ([]DepenseEquipier, error) {
+	camps, err := cps.SelectCamps(db, idCamps...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	equipiers, personnes, _, err := cps.LoadEquipiersByCamps(db, idCamps...)
+	if err != nil {
+		return nil, err
+	}
+	depenses, err := cps.SelectDepensesByIdEquipiers(db, equipiers.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	justificatifs, err := loadJustificatifs(db, key, depenses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DepenseEquipier, 0, len(depenses))
+	for _, depense := range depenses {
+		equipier := equipiers[depense.IdEquipier]
+		out = append(out, DepenseEquipier{
+			DepenseExt: DepenseExt{depense, justificatifs[depense.Id]},
+			IdPersonne: equipier.IdPersonne,
+			Equipier:   personnes[equipier.IdPersonne].NOMPrenom(),
+			IdCamp:     equipier.IdCamp,
+			Camp:       camps[equipier.IdCamp].Label(),
+		})
+	}
+	sortDepenses(out, func(d DepenseEquipier) cps.Depense { return d.Depense })
+	return out, nil
+}
+
+// DeleteJustificatifsDepenses supprime les liens et les fichiers des justificatifs
+// des notes de frais des [equipiers], qui ne sont pas supprimés
+// par cascade. Le contenu des fichiers renvoyés doit ensuite être supprimé.
+func DeleteJustificatifsDepenses(tx cps.DB, equipiers ...cps.IdEquipier) (fs.Files, error) {
+	depenses, err := cps.SelectDepensesByIdEquipiers(tx, equipiers...)
+	if err != nil {
+		return nil, err
+	}
+	links, err := fs.DeleteFileDepensesByIdDepenses(tx, depenses.IDs()...)
+	if err != nil {
+		return nil, err
+	}
+	return fs.DeleteFiles(tx, links.IdFiles()...)
+}
+
+// NewDonDepense renvoie le don correspondant à l'abandon
+// du remboursement de la note de frais par l'équipier.
+func NewDonDepense(depense DepenseEquipier, date time.Time) dn.Don {
+	return dn.Don{
+		IdPersonne:   depense.IdPersonne.Opt(),
+		Montant:      depense.Depense.Montant,
+		ModePaiement: ds.AbandonFrais,
+		Date:         sh.NewDateFrom(date),
+		Affectation:  depense.Camp,
+		Details: fmt.Sprintf("Abandon de frais (%s du %s) : %s",
+			depense.Depense.Categorie, depense.Depense.Date, depense.Depense.Description),
+	}
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestCheckDepense(t *testing.T) {
+	date := shared.NewDate(2024, time.July, 10)
+	for _, test := range []struct {
+		depense  cps.Depense
+		expected cps.Depense // only valid if ok is true
+		ok       bool
+	}{
+		{
+			cps.Depense{Categorie: cps.DepenseNourriture, Montant: ds.NewEuros(12.5), Date: date},
+			cps.Depense{Categorie: cps.DepenseNourriture, Montant: ds.NewEuros(12.5), Date: date}, true,
+		},
+		{cps.Depense{Categorie: cps.DepenseNourriture, Montant: ds.NewEuros(0), Date: date}, cps.Depense{}, false},
+		{cps.Depense{Categorie: cps.DepenseTransport, Montant: ds.NewEuros(-4), Date: date}, cps.Depense{}, false},
+		{cps.Depense{Categorie: cps.DepenseTransport, Montant: ds.NewEuros(40)}, cps.Depense{}, false},
+		{cps.Depense{Categorie: cps.DepenseAutre, Montant: ds.NewEuros(40), Date: date, Description: "  "}, cps.Depense{}, false},
+		{
+			cps.Depense{Categorie: cps.DepenseAutre, Montant: ds.NewEuros(40), Date: date, Description: " Piles "},
+			cps.Depense{Categorie: cps.DepenseAutre, Montant: ds.NewEuros(40), Date: date, Description: "Piles"}, true,
+		},
+		{
+			cps.Depense{Categorie: cps.DepenseTransport, Montant: ds.NewEuros(40), Date: date, Description: "\tPéage\n"},
+			cps.Depense{Categorie: cps.DepenseTransport, Montant: ds.NewEuros(40), Date: date, Description: "Péage"}, true,
+		},
+	} {
+		out, err := CheckDepense(test.depense)
+		tu.Assert(t, (err == nil) == test.ok)
+		if test.ok {
+			tu.Assert(t, out == test.expected)
+		}
+	}
+}
+
+func TestNewDonDepense(t *testing.T) {
+	depense := DepenseEquipier{
+		DepenseExt: DepenseExt{Depense: cps.Depense{Categorie: cps.DepenseTransport, Montant: ds.NewEuros(35), Date: shared.NewDate(2024, time.July, 8)}},
+		IdPersonne: 4,
+		Camp:       "Vercors 2024",
+	}
+	don := NewDonDepense(depense, time.Now())
+	tu.Assert(t, don.IdPersonne.Valid && don.IdPersonne.Id == 4)
+	tu.Assert(t, don.ModePaiement == ds.AbandonFrais && don.Montant == depense.Depense.Montant)
+	tu.Assert(t, don.Affectation == "Vercors 2024")
+}
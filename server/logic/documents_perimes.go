@@ -0,0 +1,141 @@
+package logic
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+)
+
+// DocumentPerime est un document temporaire (voir [fs.Demande.JoursValide])
+// dont la validité expire avant le prochain séjour de la personne.
+type DocumentPerime struct {
+	File       fs.File
+	Demande    fs.Demande
+	IdPersonne pr.IdPersonne
+	Validite   shared.Date // dernier jour de validité
+	IdCamp     cps.IdCamp  // prochain séjour
+
+	// Un seul des deux champs est défini, selon le rôle
+	// de la personne dans le prochain séjour.
+	IdDossier  ds.IdDossier
+	IdEquipier cps.IdEquipier
+}
+
+type prochainCamp struct {
+	camp       cps.Camp
+	idDossier  ds.IdDossier
+	idEquipier cps.IdEquipier
+}
+
+// prochainsCamps renvoie, pour chaque personne, le prochain séjour
+// (commençant après [now]), comme participant inscrit ou comme équipier.
+func prochainsCamps(db cps.DB, now time.Time) (map[pr.IdPersonne]prochainCamp, error) {
+	camps, err := cps.SelectAllCamps(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	today := shared.NewDateFrom(now).Time()
+	for id, camp := range camps {
+		if camp.DateDebut.Time().Before(today) {
+			delete(camps, id)
+		}
+	}
+	participants, err := cps.SelectParticipantsByIdCamps(db, camps.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	equipiers, err := cps.SelectEquipiersByIdCamps(db, camps.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+
+	out := make(map[pr.IdPersonne]prochainCamp)
+	update := func(id pr.IdPersonne, item prochainCamp) {
+		current, has := out[id]
+		if !has || item.camp.DateDebut.Time().Before(current.camp.DateDebut.Time()) {
+			out[id] = item
+		}
+	}
+	for _, part := range participants {
+		if part.Statut != cps.Inscrit {
+			continue
+		}
+		update(part.IdPersonne, prochainCamp{camp: camps[part.IdCamp], idDossier: part.IdDossier})
+	}
+	for _, equipier := range equipiers {
+		update(equipier.IdPersonne, prochainCamp{camp: camps[equipier.IdCamp], idEquipier: equipier.Id})
+	}
+	return out, nil
+}
+
+// ChercheDocumentsPerimes renvoie les documents, pas encore marqués comme périmés,
+// dont la validité expire avant le début du prochain séjour de la personne.
+func ChercheDocumentsPerimes(db cps.DB, now time.Time) ([]DocumentPerime, error) {
+	demandes, err := fs.SelectAllDemandes(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	for id, demande := range demandes {
+		if demande.JoursValide <= 0 {
+			delete(demandes, id)
+		}
+	}
+	links, err := fs.SelectFilePersonnesByIdDemandes(db, demandes.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	files, err := fs.SelectFiles(db, links.IdFiles()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	prochains, err := prochainsCamps(db, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DocumentPerime
+	for _, link := range links {
+		file, demande := files[link.IdFile], demandes[link.IdDemande]
+		prochain, has := prochains[link.IdPersonne]
+		if file.Perime || !has {
+			continue
+		}
+		validite := shared.NewDateFrom(file.Uploaded).AddDays(demande.JoursValide)
+		if !validite.Time().Before(prochain.camp.DateDebut.Time()) {
+			continue
+		}
+		out = append(out, DocumentPerime{
+			File:       file,
+			Demande:    demande,
+			IdPersonne: link.IdPersonne,
+			Validite:   validite,
+			IdCamp:     prochain.camp.Id,
+			IdDossier:  prochain.idDossier,
+			IdEquipier: prochain.idEquipier,
+		})
+	}
+	slices.SortFunc(out, func(a, b DocumentPerime) int { return int(a.File.Id - b.File.Id) })
+	return out, nil
+}
+
+// MarqueDocumentsPerimes marque les documents comme périmés :
+// ils sont alors redemandés sur l'espace perso ou l'espace équipier.
+func MarqueDocumentsPerimes(db *sql.DB, docs []DocumentPerime) error {
+	return utils.InTx(db, func(tx *sql.Tx) error {
+		for _, doc := range docs {
+			file := doc.File
+			file.Perime = true
+			if _, err := file.Update(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	"registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestDocumentsPerimes(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	now := time.Now()
+	personne, err := pr.Personne{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp, err := cps.Camp{IdTaux: 1, DateDebut: shared.NewDateFrom(now.Add(30 * 24 * time.Hour)), Duree: 7}.Insert(db)
+	tu.AssertNoErr(t, err)
+	equipier, err := cps.Equipier{IdCamp: camp.Id, IdPersonne: personne.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	demande, err := files.Demande{MaxDocs: 1, JoursValide: 365}.Insert(db)
+	tu.AssertNoErr(t, err)
+	ancien, err := files.File{Uploaded: now.Add(-360 * 24 * time.Hour)}.Insert(db)
+	tu.AssertNoErr(t, err)
+	recent, err := files.File{Uploaded: now.Add(-10 * 24 * time.Hour)}.Insert(db)
+	tu.AssertNoErr(t, err)
+	for _, file := range []files.File{ancien, recent} {
+		err = files.FilePersonne{IdFile: file.Id, IdPersonne: personne.Id, IdDemande: demande.Id}.Insert(db)
+		tu.AssertNoErr(t, err)
+	}
+
+	docs, err := ChercheDocumentsPerimes(db, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(docs) == 1)
+	tu.Assert(t, docs[0].File.Id == ancien.Id && docs[0].IdCamp == camp.Id && docs[0].IdEquipier == equipier.Id)
+
+	err = MarqueDocumentsPerimes(db.DB, docs)
+	tu.AssertNoErr(t, err)
+	ancien, err = files.SelectFile(db, ancien.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, ancien.Perime)
+
+	// pas de nouvelle relance
+	docs, err = ChercheDocumentsPerimes(db, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(docs) == 0)
+}
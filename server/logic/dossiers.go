@@ -52,7 +52,7 @@ func LoadDossiers(db ds.DB, ids []ds.IdDossier) (Dossiers, error) {
 	}
 	participants := links.ByIdDossier()
 
-	personnes, err := pr.SelectPersonnes(db, append(dossiers.IdResponsables(), links.IdPersonnes()...)...)
+	personnes, err := pr.SelectPersonnes(db, slices.Concat(dossiers.IdResponsables(), dossiers.IdResponsable2s(), links.IdPersonnes())...)
 	if err != nil {
 		return Dossiers{}, utils.SQLError(err)
 	}
@@ -80,13 +80,30 @@ func (ld *Dossiers) For(id ds.IdDossier) Dossier {
 type Dossier struct {
 	Dossier      ds.Dossier
 	Participants cps.Participants // Liste exacte
-	personnesM   pr.Personnes     // containing at least the reponsable and participants
+	personnesM   pr.Personnes     // containing at least the reponsables and participants
 	camps        cps.Camps        // containing at least the camps for [participants]
 	Events       Events
 }
 
 func (de *Dossier) Responsable() pr.Personne { return de.personnesM[de.Dossier.IdResponsable] }
 
+// Responsable2 returns the second responsable, if any.
+func (de *Dossier) Responsable2() (pr.Personne, bool) {
+	if !de.Dossier.IdResponsable2.Valid {
+		return pr.Personne{}, false
+	}
+	return de.personnesM[de.Dossier.IdResponsable2.Id], true
+}
+
+// ResponsableFor returns the responsable using the espace perso
+// with the given [acces].
+func (de *Dossier) ResponsableFor(acces EspacePersoAcces) pr.Personne {
+	if resp2, has := de.Responsable2(); acces.IsResponsable2() && has {
+		return resp2
+	}
+	return de.Responsable()
+}
+
 // ParticipantsExt is sorted by Statut > Camp > Id
 func (de *Dossier) ParticipantsExt() []cps.ParticipantCamp {
 	ps := make([]cps.ParticipantCamp, 0, len(de.Participants))
@@ -223,6 +240,7 @@ type DossierExt struct {
 	Dossier       ds.Dossier
 	IdResponsable pr.IdPersonne
 	Responsable   string
+	Responsable2  string // optionnel
 	Participants  []cps.ParticipantCamp
 	Aides         map[cps.IdParticipant]cps.Aides
 	AidesFiles    map[cps.IdAide]PublicFile // optionnel
@@ -310,7 +328,11 @@ func (d DossierFinance) Publish(key crypto.Encrypter) DossierExt {
 			}
 		}
 	}
-	return DossierExt{d.Dossier.Dossier, d.Responsable().Id, d.Responsable().PrenomNOM(), d.ParticipantsExt(), d.aides, aideFiles, d.Events, d.paiements, bilan}
+	responsable2 := ""
+	if resp2, has := d.Responsable2(); has {
+		responsable2 = resp2.PrenomNOM()
+	}
+	return DossierExt{d.Dossier.Dossier, d.Responsable().Id, d.Responsable().PrenomNOM(), responsable2, d.ParticipantsExt(), d.aides, aideFiles, d.Events, d.paiements, bilan}
 }
 
 // LoadByMail renvoie les dossiers dont le responsable a le mail fourni. Ignore les responsables temporaires.
@@ -342,3 +364,23 @@ func LoadByMail(db ds.DB, mail string) (Dossiers, pr.Personnes, error) {
 	out, err := LoadDossiers(db, dossiers.IDs())
 	return out, responsables, err
 }
+
+// LoadByMailResponsable2 renvoie les dossiers dont le second responsable a le mail fourni.
+// Ignore les responsables temporaires.
+func LoadByMailResponsable2(db ds.DB, mail string) (Dossiers, error) {
+	mail = strings.TrimSpace(mail)
+	if len(mail) == 0 { // return early to avoid matching against empty profiles
+		return Dossiers{}, nil
+	}
+	responsables, err := pr.SelectByMail(db, mail)
+	if err != nil {
+		return Dossiers{}, utils.SQLError(err)
+	}
+	responsables.RemoveTemp()
+
+	dossiers, err := ds.SelectDossiersByIdResponsable2s(db, responsables.IDs()...)
+	if err != nil {
+		return Dossiers{}, utils.SQLError(err)
+	}
+	return LoadDossiers(db, dossiers.IDs())
+}
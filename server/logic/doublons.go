@@ -0,0 +1,207 @@
+package logic
+
+import (
+	"database/sql"
+	"errors"
+
+	"registro/logic/search"
+	cps "registro/sql/camps"
+	"registro/sql/dons"
+	ds "registro/sql/dossiers"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// SeuilDoublons est le score minimal (en pourcents)
+// utilisé pour détecter les doublons.
+const SeuilDoublons = 80
+
+// DoublonExt ajoute à un groupe de doublons
+// l'utilisation de chaque profil, pour aider à choisir
+// le profil à conserver.
+type DoublonExt struct {
+	search.Doublon
+	References map[pr.IdPersonne]PersonneReferences
+}
+
+// LoadDoublons parcourt tous les profils (non temporaires)
+// à la recherche de doublons.
+func LoadDoublons(db pr.DB) ([]DoublonExt, error) {
+	personnes, err := pr.SelectAllPersonnes(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	personnes.RemoveTemp()
+
+	doublons := search.ChercheDoublons(personnes, SeuilDoublons)
+	out := make([]DoublonExt, len(doublons))
+	for i, doublon := range doublons {
+		refs := make(map[pr.IdPersonne]PersonneReferences, len(doublon.Personnes))
+		for _, pe := range doublon.Personnes {
+			refs[pe.Id], err = CheckPersonneReferences(db, pe.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		out[i] = DoublonExt{doublon, refs}
+	}
+	return out, nil
+}
+
+// FusionIn indique comment fusionner deux profils.
+type FusionIn struct {
+	Garde    pr.IdPersonne // le profil conservé
+	Supprime pr.IdPersonne // le profil supprimé, après fusion
+}
+
+// checkCampsCommuns renvoie une erreur si les deux profils
+// sont inscrits (ou équipiers) sur un même séjour.
+func checkCampsCommuns(db cps.DB, args FusionIn) error {
+	participants, err := cps.SelectParticipantsByIdPersonnes(db, args.Garde, args.Supprime)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	equipiers, err := cps.SelectEquipiersByIdPersonnes(db, args.Garde, args.Supprime)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	seen := make(map[cps.IdCamp]pr.IdPersonne)
+	check := func(idCamp cps.IdCamp, idPersonne pr.IdPersonne) error {
+		if other, has := seen[idCamp]; has && other != idPersonne {
+			return errors.New("Les deux profils sont présents sur un même séjour.")
+		}
+		seen[idCamp] = idPersonne
+		return nil
+	}
+	for _, part := range participants {
+		if err := check(part.IdCamp, part.IdPersonne); err != nil {
+			return err
+		}
+	}
+	clear(seen)
+	for _, equipier := range equipiers {
+		if err := check(equipier.IdCamp, equipier.IdPersonne); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FusionnePersonnes fusionne le profil [args.Supprime] dans [args.Garde] :
+// l'identité est complétée (les valeurs de [args.Garde] sont prioritaires),
+// toutes les occurrences (participants, équipiers, dossiers, dons, documents, fiches)
+// sont redirigées, puis le profil [args.Supprime] est supprimé.
+func FusionnePersonnes(db *sql.DB, args FusionIn) (pr.Personne, error) {
+	if args.Garde == args.Supprime {
+		return pr.Personne{}, errors.New("internal error: same profils")
+	}
+	if err := checkCampsCommuns(db, args); err != nil {
+		return pr.Personne{}, err
+	}
+
+	var garde pr.Personne
+	err := utils.InTx(db, func(tx *sql.Tx) error {
+		var err error
+		garde, err = pr.SelectPersonne(tx, args.Garde)
+		if err != nil {
+			return err
+		}
+		supprime, err := pr.SelectPersonne(tx, args.Supprime)
+		if err != nil {
+			return err
+		}
+		if garde.IsTemp {
+			return errors.New("Le profil conservé ne doit pas être temporaire.")
+		}
+
+		// 1) identité : les valeurs de [garde] sont prioritaires
+		garde.Identite, _ = search.Merge(garde.Identite, supprime.Identite)
+		if supprime.CharteAccepted.After(garde.CharteAccepted) {
+			garde.CharteAccepted = supprime.CharteAccepted
+		}
+		garde, err = garde.Update(tx)
+		if err != nil {
+			return err
+		}
+
+		// 2) fiches : on garde la fiche sanitaire la plus récente,
+		// et la fiche équipier de [garde] en priorité
+		if err = fusionneFiches(tx, garde.Id, supprime.Id); err != nil {
+			return err
+		}
+
+		// 3) occurrences
+		if err = redirectPersonne(tx, garde.Id, supprime.Id); err != nil {
+			return err
+		}
+		if err = dons.SwitchDonPersonne(tx, garde.Id.Opt(), supprime.Id.Opt()); err != nil {
+			return err
+		}
+		// un dossier ne peut pas avoir deux fois le même responsable
+		dossiers, err := ds.SelectDossiersByIdResponsable2s(tx, garde.Id)
+		if err != nil {
+			return err
+		}
+		for _, dossier := range dossiers {
+			if dossier.IdResponsable != garde.Id {
+				continue
+			}
+			dossier.IdResponsable2 = pr.OptIdPersonne{}
+			if _, err = dossier.Update(tx); err != nil {
+				return err
+			}
+		}
+
+		// 4) supprime le doublon
+		_, err = pr.DeletePersonneById(tx, supprime.Id)
+		return err
+	})
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	return garde, nil
+}
+
+func fusionneFiches(tx *sql.Tx, garde, supprime pr.IdPersonne) error {
+	fiches, err := pr.DeleteFichesanitairesByIdPersonnes(tx, garde, supprime)
+	if err != nil {
+		return err
+	}
+	if len(fiches) != 0 {
+		fiche := fiches[0]
+		for _, other := range fiches[1:] {
+			if other.Modified.After(fiche.Modified) {
+				fiche = other
+			}
+		}
+		fiche.IdPersonne = garde
+		if err = fiche.Insert(tx); err != nil {
+			return err
+		}
+	}
+	// l'historique des deux fiches est conservé
+	if err = pr.SwitchFichesanitaireVersionPersonne(tx, garde, supprime); err != nil {
+		return err
+	}
+	// de même pour les certifications
+	if err = pr.SwitchCertificationPersonne(tx, garde, supprime); err != nil {
+		return err
+	}
+
+	fichesEquipier, err := pr.DeleteFicheequipiersByIdPersonnes(tx, garde, supprime)
+	if err != nil {
+		return err
+	}
+	if len(fichesEquipier) != 0 {
+		byPersonne := fichesEquipier.ByIdPersonne()
+		fiche, has := byPersonne[garde]
+		if !has {
+			fiche = byPersonne[supprime]
+		}
+		fiche.IdPersonne = garde
+		if err = fiche.Insert(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	"registro/sql/dons"
+	ds "registro/sql/dossiers"
+	"registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestFusionnePersonnes(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	date := shared.NewDate(1980, 3, 4)
+	garde, err := pr.Personne{Identite: pr.Identite{Nom: "Kugler", Prenom: "Benoit", DateNaissance: date}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	doublon, err := pr.Personne{Identite: pr.Identite{Nom: "KUGLER", Prenom: "Benoît", DateNaissance: date, Mail: "x@free.fr"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	doublons, err := LoadDoublons(db)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(doublons) == 1 && len(doublons[0].References) == 2)
+
+	camp1, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp2, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	dossier, err := ds.Dossier{IdResponsable: doublon.Id, IdTaux: 1, MomentInscription: time.Now()}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Equipier{IdCamp: camp1.Id, IdPersonne: garde.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	equipier, err := cps.Equipier{IdCamp: camp2.Id, IdPersonne: doublon.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	don, err := dons.Don{IdPersonne: doublon.Id.Opt()}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Fichesanitaire{IdPersonne: garde.Id, Modified: time.Now().Add(-time.Hour)}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Fichesanitaire{IdPersonne: doublon.Id, Modified: time.Now(), DifficultesSante: "récent"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Ficheequipier{IdPersonne: doublon.Id, Profession: "prof"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	fi, err := files.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	demande, err := files.Demande{MaxDocs: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = files.FilePersonne{IdFile: fi.Id, IdPersonne: doublon.Id, IdDemande: demande.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	// même séjour : refusé
+	_, err = cps.Equipier{IdCamp: camp1.Id, IdPersonne: doublon.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = FusionnePersonnes(db.DB, FusionIn{Garde: garde.Id, Supprime: doublon.Id})
+	tu.Assert(t, err != nil)
+	_, err = cps.DeleteEquipiersByIdCamps(db, camp1.Id)
+	tu.AssertNoErr(t, err)
+
+	pe, err := FusionnePersonnes(db.DB, FusionIn{Garde: garde.Id, Supprime: doublon.Id})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, pe.Nom == "Kugler" && pe.Mail == "x@free.fr")
+
+	dossier, err = ds.SelectDossier(db, dossier.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, dossier.IdResponsable == garde.Id)
+	equipier, err = cps.SelectEquipier(db, equipier.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, equipier.IdPersonne == garde.Id)
+	don, err = dons.SelectDon(db, don.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, don.IdPersonne == garde.Id.Opt())
+	fiche, found, err := pr.SelectFichesanitaireByIdPersonne(db, garde.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && fiche.DifficultesSante == "récent")
+	ficheEquipier, found, err := pr.SelectFicheequipierByIdPersonne(db, garde.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && ficheEquipier.Profession == "prof")
+	links, err := files.SelectFilePersonnesByIdPersonnes(db, garde.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(links) == 1)
+
+	_, err = pr.SelectPersonne(db, doublon.Id)
+	tu.Assert(t, err != nil)
+}
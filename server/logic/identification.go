@@ -0,0 +1,336 @@
+package logic
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"registro/logic/search"
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	"registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// IdentCandidate est un profil existant pouvant
+// correspondre à un profil temporaire.
+type IdentCandidate struct {
+	search.ScoredPersonne
+
+	Merged    pr.Identite // le résultat de la fusion
+	Conflicts search.Conflicts
+}
+
+// TempProfil est un profil temporaire, à identifier.
+type TempProfil struct {
+	Personne   search.PersonneHeader
+	Candidates []IdentCandidate // triés par pertinence (meilleur en premier)
+}
+
+// bestTarget renvoie l'identification à appliquer si le meilleur candidat
+// atteint [seuil] (en pourcents) et qu'aucun autre candidat ne l'atteint.
+func (tp TempProfil) bestTarget(seuil int) (IdentTarget, bool) {
+	if len(tp.Candidates) == 0 || tp.Candidates[0].ScorePercent < seuil {
+		return IdentTarget{}, false
+	}
+	if len(tp.Candidates) >= 2 && tp.Candidates[1].ScorePercent >= seuil {
+		return IdentTarget{}, false // ambigu
+	}
+	return IdentTarget{IdTemporaire: tp.Personne.Id, Rattache: true, RattacheTo: tp.Candidates[0].Personne.Id}, true
+}
+
+// LoadTempProfils renvoie tous les profils temporaires, accompagnés
+// des meilleurs profils existants, triés par date de création.
+func LoadTempProfils(db pr.DB) ([]TempProfil, error) {
+	const maxCandidates = 3
+
+	personnes, err := pr.SelectAllPersonnes(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	var temps []pr.Personne
+	for _, pe := range personnes {
+		if pe.IsTemp {
+			temps = append(temps, pe)
+		}
+	}
+	personnes.RemoveTemp()
+	slices.SortFunc(temps, func(a, b pr.Personne) int { return int(a.Id - b.Id) })
+
+	out := make([]TempProfil, len(temps))
+	for i, temp := range temps {
+		_, similaires := search.ChercheSimilaires(personnes, search.NewPatternsSimilarite(temp.Identite))
+		if len(similaires) > maxCandidates {
+			similaires = similaires[:maxCandidates]
+		}
+		candidates := make([]IdentCandidate, len(similaires))
+		for j, similaire := range similaires {
+			existant := personnes[similaire.Personne.Id]
+			merged, conflicts := search.Merge(temp.Identite, existant.Identite)
+			candidates[j] = IdentCandidate{similaire, merged, conflicts}
+		}
+		out[i] = TempProfil{search.NewPersonneHeader(temp), candidates}
+	}
+	return out, nil
+}
+
+// AutoIdentifie rattache tous les profils temporaires dont le meilleur candidat
+// a un score supérieur ou égal à [seuil] (en pourcents), sans ambiguïté.
+//
+// Chaque identification est réalisée indépendamment : en cas d'erreur,
+// les identifications déjà effectuées sont renvoyées.
+func AutoIdentifie(db *sql.DB, seuil int) ([]IdentRecord, error) {
+	profils, err := LoadTempProfils(db)
+	if err != nil {
+		return nil, err
+	}
+	var out []IdentRecord
+	for _, profil := range profils {
+		target, ok := profil.bestTarget(seuil)
+		if !ok {
+			continue
+		}
+		record, err := IdentifiePersonne(db, target)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, record)
+	}
+	return out, nil
+}
+
+// durée pendant laquelle une identification peut être annulée
+const undoIdentificationDelay = 2 * time.Hour
+
+// IdentAction est une identification récente, qui peut encore être annulée.
+type IdentAction struct {
+	Id     pr.IdIdentification
+	Time   time.Time
+	Record IdentRecord
+}
+
+// JournaliseIdentifications enregistre [records] dans le journal des identifications,
+// pour une éventuelle annulation.
+// Le journal est conservé en base : les annulations restent possibles
+// après un redémarrage du serveur.
+func JournaliseIdentifications(db *sql.DB, now time.Time, records ...IdentRecord) ([]IdentAction, error) {
+	out := make([]IdentAction, len(records))
+	err := utils.InTx(db, func(tx *sql.Tx) error {
+		for i, record := range records {
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			item, err := pr.Identification{Moment: now, Record: data}.Insert(tx)
+			if err != nil {
+				return err
+			}
+			out[i] = IdentAction{item.Id, item.Moment, record}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// LoadIdentifications renvoie les identifications annulables, la plus récente en premier.
+// Les identifications expirées sont retirées du journal.
+func LoadIdentifications(db pr.DB, now time.Time) ([]IdentAction, error) {
+	items, err := pr.SelectAllIdentifications(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	var (
+		expired []pr.IdIdentification
+		out     []IdentAction
+	)
+	for _, item := range items {
+		if now.Sub(item.Moment) > undoIdentificationDelay {
+			expired = append(expired, item.Id)
+			continue
+		}
+		action := IdentAction{Id: item.Id, Time: item.Moment}
+		if err = json.Unmarshal(item.Record, &action.Record); err != nil {
+			return nil, err
+		}
+		out = append(out, action)
+	}
+	if _, err = pr.DeleteIdentificationsByIDs(db, expired...); err != nil {
+		return nil, utils.SQLError(err)
+	}
+	slices.SortFunc(out, func(a, b IdentAction) int { return int(b.Id - a.Id) })
+	return out, nil
+}
+
+// AnnuleIdentification annule l'identification [id] du journal,
+// et renvoie le profil temporaire restauré.
+// En cas de fusion, le profil temporaire est recréé avec un nouvel Id,
+// et seule l'identité du profil existant est restaurée : l'annulation
+// est refusée si elle a été modifiée depuis l'identification.
+func AnnuleIdentification(db *sql.DB, now time.Time, id pr.IdIdentification) (temporaire pr.Personne, err error) {
+	errExpired := errors.New("Cette identification ne peut plus être annulée.")
+	err = utils.InTx(db, func(tx *sql.Tx) error {
+		// l'entrée est retirée du journal dans la même transaction :
+		// en cas d'échec, l'annulation reste possible
+		item, err := pr.DeleteIdentificationById(tx, id)
+		if err == sql.ErrNoRows {
+			return errExpired
+		} else if err != nil {
+			return err
+		}
+		if now.Sub(item.Moment) > undoIdentificationDelay {
+			return errExpired
+		}
+		var record IdentRecord
+		if err = json.Unmarshal(item.Record, &record); err != nil {
+			return err
+		}
+		temporaire, err = annuleIdentification(tx, record)
+		return err
+	})
+	return temporaire, err
+}
+
+// annuleIdentification annule les modifications décrites par [record].
+func annuleIdentification(tx *sql.Tx, record IdentRecord) (pr.Personne, error) {
+	if !record.Target.Rattache {
+		temporaire, err := pr.SelectPersonne(tx, record.Temporaire.Id)
+		if err != nil {
+			return pr.Personne{}, err
+		}
+		temporaire.IsTemp = true
+		return temporaire.Update(tx)
+	}
+
+	// 1) restaure les deux profils : seule l'identité du profil existant
+	// a été modifiée par la fusion, et elle ne doit pas avoir changé depuis
+	existant, err := pr.SelectPersonne(tx, record.Existant.Id)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	if !sameIdentite(existant.Identite, record.Fusion) {
+		return pr.Personne{}, errors.New("Le profil existant a été modifié depuis l'identification, qui ne peut plus être annulée.")
+	}
+	existant.Identite = record.Existant.Identite
+	_, err = existant.Update(tx)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	temporaire, err := record.Temporaire.Insert(tx)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+
+	// 2) redirige les occurrences
+	participants, err := cps.SelectParticipants(tx, record.Participants...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, participant := range participants {
+		participant.IdPersonne = temporaire.Id
+		if _, err = participant.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	equipiers, err := cps.SelectEquipiers(tx, record.Equipiers...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, equipier := range equipiers {
+		equipier.IdPersonne = temporaire.Id
+		if _, err = equipier.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	dossiers, err := ds.SelectDossiers(tx, record.Dossiers...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, dossier := range dossiers {
+		dossier.IdResponsable = temporaire.Id
+		if _, err = dossier.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	dossiers, err = ds.SelectDossiers(tx, record.Dossiers2...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, dossier := range dossiers {
+		dossier.IdResponsable2 = temporaire.Id.Opt()
+		if _, err = dossier.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	demandes, err := files.SelectDemandes(tx, record.Demandes...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, demande := range demandes {
+		demande.IdDirecteur = temporaire.Id.Opt()
+		if _, err = demande.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	candidatures, err := cps.SelectCandidatures(tx, record.Candidatures...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, candidature := range candidatures {
+		candidature.IdPersonne = temporaire.Id
+		if _, err = candidature.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	signatures, err := files.SelectSignatures(tx, record.Signatures...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, signature := range signatures {
+		signature.IdPersonne = temporaire.Id
+		if _, err = signature.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	soins, err := cps.SelectSoins(tx, record.Soins...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, soin := range soins {
+		soin.IdPersonne = temporaire.Id
+		if _, err = soin.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	certifications, err := pr.SelectCertifications(tx, record.Certifications...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for _, certification := range certifications {
+		certification.IdPersonne = temporaire.Id
+		if _, err = certification.Update(tx); err != nil {
+			return pr.Personne{}, err
+		}
+	}
+	links, err := files.DeleteFilePersonnesByIdFiles(tx, record.FilePersonnes...)
+	if err != nil {
+		return pr.Personne{}, err
+	}
+	for i := range links {
+		links[i].IdPersonne = temporaire.Id
+	}
+	if err = files.InsertManyFilePersonnes(tx, links...); err != nil {
+		return pr.Personne{}, err
+	}
+	return temporaire, nil
+}
+
+// sameIdentite compare les champs modifiés par [search.Merge]
+func sameIdentite(a, b pr.Identite) bool {
+	return a.Nom == b.Nom && a.Prenom == b.Prenom && a.Sexe == b.Sexe &&
+		a.DateNaissance.Time().Equal(b.DateNaissance.Time()) && a.Nationnalite == b.Nationnalite &&
+		slices.Equal(a.Tels, b.Tels) && a.Mail == b.Mail &&
+		a.Adresse == b.Adresse && a.CodePostal == b.CodePostal && a.Ville == b.Ville && a.Pays == b.Pays
+}
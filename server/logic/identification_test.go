@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"registro/logic/search"
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	"registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestTempProfilBestTarget(t *testing.T) {
+	candidate := func(id pr.IdPersonne, score int) IdentCandidate {
+		return IdentCandidate{ScoredPersonne: search.ScoredPersonne{ScorePercent: score, Personne: search.PersonneHeader{Id: id}}}
+	}
+	tests := []struct {
+		candidates []IdentCandidate
+		want       bool
+	}{
+		{nil, false},
+		{[]IdentCandidate{candidate(2, 100)}, true},
+		{[]IdentCandidate{candidate(2, 80)}, false},
+		{[]IdentCandidate{candidate(2, 100), candidate(3, 50)}, true},
+		{[]IdentCandidate{candidate(2, 100), candidate(3, 95)}, false},
+	}
+	for _, tt := range tests {
+		tp := TempProfil{Personne: search.PersonneHeader{Id: 1}, Candidates: tt.candidates}
+		target, ok := tp.bestTarget(90)
+		tu.Assert(t, ok == tt.want)
+		if ok {
+			tu.Assert(t, target == IdentTarget{IdTemporaire: 1, Rattache: true, RattacheTo: 2})
+		}
+	}
+}
+
+func TestAutoIdentifieAndUndo(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	identite := pr.Identite{Nom: "Kugler", Prenom: "Benoit", Sexe: pr.Man, DateNaissance: shared.NewDate(2000, 1, 1)}
+	existant, err := pr.Personne{Identite: identite}.Insert(db)
+	tu.AssertNoErr(t, err)
+	identite.Mail = "x@free.fr"
+	temp, err := pr.Personne{Identite: identite, IsTemp: true}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = pr.Personne{Identite: pr.Identite{Nom: "Other"}, IsTemp: true}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	camp, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	dossier, err := ds.Dossier{IdResponsable: temp.Id, IdTaux: 1, MomentInscription: time.Now()}.Insert(db)
+	tu.AssertNoErr(t, err)
+	participant, err := cps.Participant{IdCamp: camp.Id, IdPersonne: temp.Id, IdDossier: dossier.Id, IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	fi, err := files.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	demande, err := files.Demande{MaxDocs: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = files.FilePersonne{IdFile: fi.Id, IdPersonne: temp.Id, IdDemande: demande.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	signed, err := files.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	signature, err := files.Signature{IdFile: signed.Id, IdPersonne: temp.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	soin, err := cps.Soin{IdCamp: camp.Id, IdPersonne: temp.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	certification, err := pr.Certification{IdPersonne: temp.Id, Kind: pr.CPsc1}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	profils, err := LoadTempProfils(db)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(profils) == 2)
+	tu.Assert(t, len(profils[0].Candidates) == 1 && profils[0].Candidates[0].Merged.Mail == "x@free.fr")
+
+	records, err := AutoIdentifie(db.DB, 100)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(records) == 1)
+
+	participant, err = cps.SelectParticipant(db, participant.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, participant.IdPersonne == existant.Id)
+	existant, err = pr.SelectPersonne(db, existant.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, existant.Mail == "x@free.fr")
+	certification, err = pr.SelectCertification(db, certification.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, certification.IdPersonne == existant.Id)
+
+	now := time.Now()
+	old, err := JournaliseIdentifications(db.DB, now.Add(-3*time.Hour), IdentRecord{})
+	tu.AssertNoErr(t, err)
+	actions, err := JournaliseIdentifications(db.DB, now, records...)
+	tu.AssertNoErr(t, err)
+	list, err := LoadIdentifications(db, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(list) == 1 && list[0].Id == actions[0].Id) // the first one has expired
+	tu.Assert(t, list[0].Record.Temporaire.Id == temp.Id)
+	_, err = AnnuleIdentification(db.DB, now, old[0].Id)
+	tu.AssertErr(t, err)
+
+	// the profil has been modified since : refuse the undo
+	existant.Ville = "Paris"
+	existant, err = existant.Update(db)
+	tu.AssertNoErr(t, err)
+	_, err = AnnuleIdentification(db.DB, now, actions[0].Id)
+	tu.AssertErr(t, err)
+	// other fields are not affected by the undo
+	existant.Ville = ""
+	existant.Publicite.EchoRocher = true
+	_, err = existant.Update(db)
+	tu.AssertNoErr(t, err)
+
+	restored, err := AnnuleIdentification(db.DB, now, actions[0].Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, restored.IsTemp && restored.Mail == "x@free.fr")
+	_, err = AnnuleIdentification(db.DB, now, actions[0].Id)
+	tu.AssertErr(t, err) // already undone
+
+	existant, err = pr.SelectPersonne(db, existant.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, existant.Mail == "" && existant.Publicite.EchoRocher)
+	participant, err = cps.SelectParticipant(db, participant.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, participant.IdPersonne == restored.Id)
+	dossier, err = ds.SelectDossier(db, dossier.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, dossier.IdResponsable == restored.Id)
+	links, err := files.SelectFilePersonnesByIdPersonnes(db, restored.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(links) == 1)
+	signature, err = files.SelectSignature(db, signature.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, signature.IdPersonne == restored.Id)
+	soin, err = cps.SelectSoin(db, soin.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, soin.IdPersonne == restored.Id)
+	certification, err = pr.SelectCertification(db, certification.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, certification.IdPersonne == restored.Id)
+}
+
+func TestSameIdentite(t *testing.T) {
+	a := pr.Identite{Nom: "Kugler", DateNaissance: shared.NewDate(2000, 1, 1)}
+	b := a
+	b.Tels = pr.Tels{}
+	b.DateNaissance = shared.Date(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
+	tu.Assert(t, sameIdentite(a, b))
+	b.Tels = pr.Tels{"0601020304"}
+	tu.Assert(t, !sameIdentite(a, b))
+	b = a
+	b.Ville = "Paris"
+	tu.Assert(t, !sameIdentite(a, b))
+}
@@ -0,0 +1,135 @@
+package logic
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+)
+
+// ParticipantSante résume la fiche sanitaire d'un inscrit,
+// pour l'assistant sanitaire.
+type ParticipantSante struct {
+	IdPersonne       pr.IdPersonne
+	Personne         string // NOM Prénom
+	DateNaissance    shared.Date
+	DifficultesSante string
+	Traitements      pr.Traitements
+	Allergies        pr.Allergies
+	PAI              bool
+}
+
+// RegistreInfirmerie regroupe les traitements des inscrits d'un séjour
+// et le cahier de soins rempli pendant le séjour.
+type RegistreInfirmerie struct {
+	Camp         cps.Camp
+	Participants []ParticipantSante // inscrits, par ordre alphabétique
+	Soins        []cps.Soin         // par ordre chronologique
+
+	// Personnes contient le nom de toutes les personnes du registre,
+	// y compris celles qui ne sont plus inscrites.
+	Personnes map[pr.IdPersonne]string
+}
+
+// LoadRegistreInfirmerie charge les fiches sanitaires des inscrits
+// du séjour [idCamp], et les soins enregistrés.
+func LoadRegistreInfirmerie(db cps.DB, idCamp cps.IdCamp) (RegistreInfirmerie, error) {
+	camp, err := cps.LoadCamp(db, idCamp)
+	if err != nil {
+		return RegistreInfirmerie{}, err
+	}
+	inscrits := camp.Participants(true)
+	fiches, err := pr.SelectFichesanitairesByIdPersonnes(db, camp.Personnes(true).IDs()...)
+	if err != nil {
+		return RegistreInfirmerie{}, utils.SQLError(err)
+	}
+	fichesByPersonne := fiches.ByIdPersonne()
+	soins, err := cps.SelectSoinsByIdCamps(db, idCamp)
+	if err != nil {
+		return RegistreInfirmerie{}, utils.SQLError(err)
+	}
+	personnes, err := pr.SelectPersonnes(db, soins.IdPersonnes()...)
+	if err != nil {
+		return RegistreInfirmerie{}, utils.SQLError(err)
+	}
+
+	out := RegistreInfirmerie{Camp: camp.Camp, Personnes: make(map[pr.IdPersonne]string)}
+	for _, personne := range personnes {
+		out.Personnes[personne.Id] = personne.NOMPrenom()
+	}
+	for _, inscrit := range inscrits {
+		fiche := fichesByPersonne[inscrit.Personne.Id]
+		out.Participants = append(out.Participants, ParticipantSante{
+			IdPersonne:       inscrit.Personne.Id,
+			Personne:         inscrit.Personne.NOMPrenom(),
+			DateNaissance:    inscrit.Personne.DateNaissance,
+			DifficultesSante: fiche.DifficultesSante,
+			Traitements:      fiche.Traitements,
+			Allergies:        fiche.Allergies,
+			PAI:              fiche.PAI,
+		})
+		out.Personnes[inscrit.Personne.Id] = inscrit.Personne.NOMPrenom()
+	}
+	slices.SortFunc(out.Participants, func(a, b ParticipantSante) int { return strings.Compare(a.Personne, b.Personne) })
+
+	out.Soins = utils.MapValues(soins)
+	slices.SortFunc(out.Soins, func(a, b cps.Soin) int {
+		if c := a.Moment.Compare(b.Moment); c != 0 {
+			return c
+		}
+		return int(a.Id - b.Id)
+	})
+	return out, nil
+}
+
+// Administration renvoie la prise du médicament [medicament] par [idPersonne]
+// enregistrée le jour [jour], au créneau [creneau].
+func (rg RegistreInfirmerie) Administration(idPersonne pr.IdPersonne, medicament string, jour shared.Date, creneau cps.Creneau) (cps.Soin, bool) {
+	for _, soin := range rg.Soins {
+		if soin.Kind != cps.Administration || soin.IdPersonne != idPersonne || soin.Creneau != creneau {
+			continue
+		}
+		if shared.NewDateFrom(soin.Moment) != jour {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(soin.Medicament), strings.TrimSpace(medicament)) {
+			continue
+		}
+		return soin, true
+	}
+	return cps.Soin{}, false
+}
+
+// CheckSoin vérifie que le [soin] concerne un inscrit du séjour [idCamp],
+// et le normalise.
+func CheckSoin(db cps.DB, idCamp cps.IdCamp, soin cps.Soin) (cps.Soin, error) {
+	participants, err := cps.SelectParticipantsByIdCamps(db, idCamp)
+	if err != nil {
+		return soin, utils.SQLError(err)
+	}
+	isInscrit := false
+	for _, participant := range participants {
+		if participant.IdPersonne == soin.IdPersonne && participant.Statut == cps.Inscrit {
+			isInscrit = true
+			break
+		}
+	}
+	if !isInscrit {
+		return soin, errors.New("access forbidden")
+	}
+	soin.IdCamp = idCamp
+	soin.Medicament = strings.TrimSpace(soin.Medicament)
+	soin.Dose = strings.TrimSpace(soin.Dose)
+	if soin.Kind == cps.Administration && soin.Medicament == "" {
+		return soin, errors.New("Le médicament administré est requis.")
+	}
+	if soin.Moment.IsZero() {
+		soin.Moment = time.Now()
+	}
+	return soin, nil
+}
@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestRegistreInfirmerie(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	debut := shared.NewDate(2024, time.July, 8)
+	camp, err := cps.Camp{IdTaux: 1, DateDebut: debut, Duree: 5}.Insert(db)
+	tu.AssertNoErr(t, err)
+	pe1, err := pr.Personne{Identite: pr.Identite{Nom: "Durand"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	pe2, err := pr.Personne{Identite: pr.Identite{Nom: "Martin"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	dossier, err := ds.Dossier{IdTaux: 1, IdResponsable: pe1.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Participant{IdCamp: camp.Id, IdTaux: 1, IdDossier: dossier.Id, IdPersonne: pe1.Id, Statut: cps.Inscrit}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Participant{IdCamp: camp.Id, IdTaux: 1, IdDossier: dossier.Id, IdPersonne: pe2.Id, Statut: cps.AStatuer}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	err = pr.Fichesanitaire{IdPersonne: pe1.Id, Traitements: pr.Traitements{{Medicament: "Doliprane"}}}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	// seuls les inscrits sont acceptés
+	_, err = CheckSoin(db, camp.Id, cps.Soin{IdPersonne: pe2.Id, Kind: cps.Incident})
+	tu.AssertErr(t, err)
+	_, err = CheckSoin(db, camp.Id, cps.Soin{IdPersonne: pe1.Id, Kind: cps.Administration})
+	tu.AssertErr(t, err) // médicament manquant
+
+	soin, err := CheckSoin(db, camp.Id, cps.Soin{IdPersonne: pe1.Id, Kind: cps.Administration, Medicament: " Doliprane ",
+		Moment: debut.Time().Add(8 * time.Hour), Creneau: cps.Matin})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, soin.IdCamp == camp.Id && soin.Medicament == "Doliprane")
+	_, err = soin.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	registre, err := LoadRegistreInfirmerie(db, camp.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(registre.Participants) == 1 && len(registre.Participants[0].Traitements) == 1)
+	tu.Assert(t, len(registre.Soins) == 1)
+	_, ok := registre.Administration(pe1.Id, "doliprane", debut, cps.Matin)
+	tu.Assert(t, ok)
+	_, ok = registre.Administration(pe1.Id, "doliprane", debut, cps.Soir)
+	tu.Assert(t, !ok)
+}
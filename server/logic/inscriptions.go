@@ -104,67 +104,178 @@ type IdentTarget struct {
 	RattacheTo pr.IdPersonne // only valid if [Rattache] is true
 }
 
-func IdentifiePersonne(db *sql.DB, args IdentTarget) error {
-	temporaire, err := pr.SelectPersonne(db, args.IdTemporaire)
+// IdentRecord décrit les modifications effectuées par [IdentifiePersonne],
+// et permet de les annuler (voir [AnnuleIdentification]).
+type IdentRecord struct {
+	Target IdentTarget
+
+	Temporaire pr.Personne // le profil temporaire, avant identification
+	Existant   pr.Personne // le profil existant, avant fusion (only valid if Target.Rattache is true)
+	Fusion     pr.Identite // l'identité du profil existant, après fusion (only valid if Target.Rattache is true)
+
+	// Occurrences redirigées vers le profil existant
+	Participants   []cps.IdParticipant
+	Equipiers      []cps.IdEquipier
+	Dossiers       []ds.IdDossier
+	Dossiers2      []ds.IdDossier // second responsable
+	Demandes       []files.IdDemande
+	FilePersonnes  []files.IdFile
+	Candidatures   []cps.IdCandidature
+	Signatures     []files.IdSignature
+	Soins          []cps.IdSoin
+	Certifications []pr.IdCertification
+}
+
+func IdentifiePersonne(db *sql.DB, args IdentTarget) (record IdentRecord, err error) {
+	err = utils.InTx(db, func(tx *sql.Tx) error {
+		record, err = identifiePersonne(tx, args)
+		return err
+	})
+	return record, err
+}
+
+// identifiePersonne est la version transactionnelle de [IdentifiePersonne]
+func identifiePersonne(tx *sql.Tx, args IdentTarget) (IdentRecord, error) {
+	record := IdentRecord{Target: args}
+	temporaire, err := pr.SelectPersonne(tx, args.IdTemporaire)
 	if err != nil {
-		return utils.SQLError(err)
+		return record, err
 	}
+	record.Temporaire = temporaire
 
 	if !args.Rattache {
 		// on marque simplement la personne 'entrante' comme non temporaire
 		temporaire.IsTemp = false
-		_, err = temporaire.Update(db)
-		if err != nil {
-			return utils.SQLError(err)
-		}
-		return nil
+		_, err = temporaire.Update(tx)
+		return record, err
 	}
 
 	if args.IdTemporaire == args.RattacheTo {
-		return errors.New("internal error: same target and origin profil")
+		return record, errors.New("internal error: same target and origin profil")
 	}
 
-	return utils.InTx(db, func(tx *sql.Tx) error {
-		existant, err := pr.SelectPersonne(tx, args.RattacheTo)
-		if err != nil {
-			return err
-		}
-		if existant.IsTemp {
-			return errors.New("internal error: target is temporary")
-		}
+	existant, err := pr.SelectPersonne(tx, args.RattacheTo)
+	if err != nil {
+		return record, err
+	}
+	if existant.IsTemp {
+		return record, errors.New("internal error: target is temporary")
+	}
+	record.Existant = existant
 
-		// 1) on applique les modifications de la fusion
-		existant.Identite, _ = search.Merge(temporaire.Identite, existant.Identite)
-		_, err = existant.Update(tx)
-		if err != nil {
-			return err
-		}
+	// 0) garde une trace des occurrences de [IdTemporaire]
+	if err = record.selectOccurrences(tx); err != nil {
+		return record, err
+	}
 
-		// 2) redirige les occurrences de [IdTemporaire]
-		if err = cps.SwitchParticipantPersonne(tx, existant.Id, temporaire.Id); err != nil {
-			return err
-		}
-		if err = cps.SwitchEquipierPersonne(tx, existant.Id, temporaire.Id); err != nil {
-			return err
-		}
-		if err = ds.SwitchDossierPersonne(tx, existant.Id, temporaire.Id); err != nil {
-			return err
-		}
-		if err = files.SwitchDemandePersonne(tx, existant.Id.Opt(), temporaire.Id.Opt()); err != nil {
-			return err
-		}
-		if err = files.SwitchFilePersonnePersonne(tx, existant.Id, temporaire.Id); err != nil {
-			return err
-		}
+	// 1) on applique les modifications de la fusion
+	existant.Identite, _ = search.Merge(temporaire.Identite, existant.Identite)
+	existant, err = existant.Update(tx)
+	if err != nil {
+		return record, err
+	}
+	record.Fusion = existant.Identite
 
-		// 3) supprime la personne temporaire désormais inutile
-		_, err = pr.DeletePersonneById(tx, temporaire.Id)
-		if err != nil {
-			return err
-		}
+	// 2) redirige les occurrences de [IdTemporaire]
+	if err = redirectPersonne(tx, existant.Id, temporaire.Id); err != nil {
+		return record, err
+	}
 
-		return nil
-	})
+	// 3) supprime la personne temporaire désormais inutile
+	_, err = pr.DeletePersonneById(tx, temporaire.Id)
+	return record, err
+}
+
+// redirectPersonne remplace les occurrences de [from] par [target]
+// dans les participants, équipiers, candidatures, dossiers, demandes, documents,
+// signatures, soins et certifications.
+func redirectPersonne(tx *sql.Tx, target, from pr.IdPersonne) error {
+	if err := cps.SwitchParticipantPersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := cps.SwitchEquipierPersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := cps.SwitchCandidaturePersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := ds.SwitchDossierPersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := ds.SwitchDossierResponsable2Personne(tx, target.Opt(), from.Opt()); err != nil {
+		return err
+	}
+	if err := files.SwitchDemandePersonne(tx, target.Opt(), from.Opt()); err != nil {
+		return err
+	}
+	if err := files.SwitchFilePersonnePersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := files.SwitchSignaturePersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := cps.SwitchSoinPersonne(tx, target, from); err != nil {
+		return err
+	}
+	if err := pr.SwitchCertificationPersonne(tx, target, from); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (record *IdentRecord) selectOccurrences(tx *sql.Tx) error {
+	id := record.Temporaire.Id
+	participants, err := cps.SelectParticipantsByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	equipiers, err := cps.SelectEquipiersByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	dossiers, err := ds.SelectDossiersByIdResponsables(tx, id)
+	if err != nil {
+		return err
+	}
+	dossiers2, err := ds.SelectDossiersByIdResponsable2s(tx, id)
+	if err != nil {
+		return err
+	}
+	demandes, err := files.SelectDemandesByIdDirecteurs(tx, id)
+	if err != nil {
+		return err
+	}
+	links, err := files.SelectFilePersonnesByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	candidatures, err := cps.SelectCandidaturesByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	signatures, err := files.SelectSignaturesByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	soins, err := cps.SelectSoinsByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	certifications, err := pr.SelectCertificationsByIdPersonnes(tx, id)
+	if err != nil {
+		return err
+	}
+	record.Participants = participants.IDs()
+	record.Equipiers = equipiers.IDs()
+	record.Dossiers = dossiers.IDs()
+	record.Dossiers2 = dossiers2.IDs()
+	record.Demandes = demandes.IDs()
+	record.FilePersonnes = links.IdFiles()
+	record.Candidatures = candidatures.IDs()
+	record.Signatures = signatures.IDs()
+	record.Soins = soins.IDs()
+	record.Certifications = certifications.IDs()
+	return nil
 }
 
 type StatutHints = map[cps.IdParticipant]StatutExt
@@ -39,7 +39,7 @@ func TestIdentifieProfil(t *testing.T) {
 	err = files.FilePersonne{IdFile: fi.Id, IdPersonne: pe1.Id, IdDemande: demande.Id}.Insert(db)
 	tu.AssertNoErr(t, err)
 
-	err = IdentifiePersonne(db.DB, IdentTarget{
+	_, err = IdentifiePersonne(db.DB, IdentTarget{
 		IdTemporaire: pe1.Id,
 		Rattache:     true,
 		RattacheTo:   pe2.Id,
@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"errors"
 	"slices"
 
 	"registro/crypto"
@@ -81,13 +82,13 @@ func SearchSimilaires(db pr.DB, id pr.IdPersonne) ([]search.ScoredPersonne, erro
 	return filtered, nil
 }
 
-// not included, will cascade on delete : Fichesanitaire, Demande
+// not included, will cascade on delete : Fichesanitaire, Demande, Soin
 type PersonneReferences struct {
 	Participants []cps.IdParticipant
 	Equipiers    []cps.IdEquipier
 	Dossiers     []ds.IdDossier
 	Files        []fs.IdFile
-	Dons         []dons.IdDon // TODO
+	Dons         []dons.IdDon
 }
 
 func (pr PersonneReferences) Empty() bool {
@@ -117,24 +118,45 @@ func CheckPersonneReferences(db pr.DB, id pr.IdPersonne) (out PersonneReferences
 	if err != nil {
 		return out, utils.SQLError(err)
 	}
-	out.Dossiers = dossiers.IDs()
+	dossiers2, err := ds.SelectDossiersByIdResponsable2s(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Dossiers = append(dossiers.IDs(), dossiers2.IDs()...)
 
 	links1, err := fs.SelectFilePersonnesByIdPersonnes(db, id)
 	if err != nil {
 		return out, utils.SQLError(err)
 	}
-	out.Files = links1.IdFiles()
+	certifications, err := pr.SelectCertificationsByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	links2, err := fs.SelectFileCertificationsByIdCertifications(db, certifications.IDs()...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Files = append(links1.IdFiles(), links2.IdFiles()...)
 
-	// links2, err := rd.SelectDonDonateursByIdPersonnes(db, id)
-	// if err != nil {
-	// 	return out, utils.SQLError(err)
-	// }
-	// out.Dons = links2
+	dons, err := dons.SelectDonsByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Dons = dons.IDs()
 
 	return out, nil
 }
 
-const EndpointEspacePerso = "espace-perso"
+const (
+	EndpointEspacePerso = "espace-perso"
+	EndpointEquipier    = "/equipier"
+)
+
+// EquipierURL renvoie le lien vers l'espace équipier.
+func EquipierURL(key crypto.Encrypter, host string, id cps.IdEquipier) string {
+	token := crypto.EncryptID(key, id)
+	return utils.BuildUrl(host, EndpointEquipier, utils.QP("token", token))
+}
 
 func EspacePersoURL(key crypto.Encrypter, host string, dossier ds.IdDossier, queryParams ...utils.QueryParam) string {
 	crypted := crypto.EncryptID(key, dossier)
@@ -142,6 +164,55 @@ func EspacePersoURL(key crypto.Encrypter, host string, dossier ds.IdDossier, que
 	return utils.BuildUrl(host, EndpointEspacePerso, queryParams...)
 }
 
+// EspacePersoAcces identifie l'utilisateur d'un espace personnel :
+// le responsable du dossier ou le second responsable légal.
+type EspacePersoAcces struct {
+	IdDossier    ds.IdDossier
+	Responsable2 pr.OptIdPersonne // valide pour le second responsable
+}
+
+func (ac EspacePersoAcces) IsResponsable2() bool { return ac.Responsable2.Valid }
+
+// EspacePersoURLResponsable2 est similaire à [EspacePersoURL], mais
+// renvoie le lien propre au second responsable légal.
+func EspacePersoURLResponsable2(key crypto.Encrypter, host string, dossier ds.IdDossier, responsable2 pr.IdPersonne, queryParams ...utils.QueryParam) string {
+	acces := EspacePersoAcces{IdDossier: dossier, Responsable2: responsable2.Opt()}
+	crypted, _ := key.EncryptJSON(acces) // errors should never happen on safe data
+	queryParams = append(queryParams, utils.QP("token", crypted))
+	return utils.BuildUrl(host, EndpointEspacePerso, queryParams...)
+}
+
+// DecryptEspacePersoToken décode les liens créés par [EspacePersoURL]
+// et [EspacePersoURLResponsable2].
+// Les droits du second responsable doivent être vérifiés par l'appelant,
+// voir [CheckAccesResponsable2].
+func DecryptEspacePersoToken(key crypto.Encrypter, token string) (EspacePersoAcces, error) {
+	if id, err := crypto.DecryptID[ds.IdDossier](key, token); err == nil {
+		return EspacePersoAcces{IdDossier: id}, nil
+	}
+	var out EspacePersoAcces
+	if err := key.DecryptJSON(token, &out); err != nil || !out.IsResponsable2() || out.IdDossier <= 0 {
+		return EspacePersoAcces{}, errors.New("Lien invalide.")
+	}
+	return out, nil
+}
+
+// CheckAccesResponsable2 vérifie que l'accès est toujours valide pour [dossier],
+// et, si [write] est true, qu'il autorise les modifications.
+// Il n'y a pas de restriction pour le responsable principal.
+func CheckAccesResponsable2(dossier ds.Dossier, acces EspacePersoAcces, write bool) error {
+	if !acces.IsResponsable2() {
+		return nil
+	}
+	if dossier.IdResponsable2 != acces.Responsable2 { // accès révoqué
+		return errors.New("Lien invalide.")
+	}
+	if write && dossier.AccesResponsable2 != ds.AccesComplet {
+		return errors.New("access forbidden")
+	}
+	return nil
+}
+
 // PublicFile expose un accès protégé à un fichier,
 // permettant téléchargement/suppression/modification.
 type PublicFile struct {
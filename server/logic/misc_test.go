@@ -2,9 +2,11 @@ package logic
 
 import (
 	"database/sql"
+	"net/url"
 	"testing"
 	"time"
 
+	"registro/crypto"
 	cps "registro/sql/camps"
 	"registro/sql/dossiers"
 	pr "registro/sql/personnes"
@@ -59,6 +61,17 @@ func TestCheckPersonneReferences(t *testing.T) {
 	ref, err := CheckPersonneReferences(db, pe.Id)
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, len(ref.Participants) == 2)
+	tu.Assert(t, len(ref.Dossiers) == 0)
+
+	dossier.IdResponsable2 = pe.Id.Opt()
+	dossier, err = dossier.Update(db)
+	tu.AssertNoErr(t, err)
+	ref, err = CheckPersonneReferences(db, pe.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(ref.Dossiers) == 1)
+	dossier.IdResponsable2 = pr.OptIdPersonne{}
+	_, err = dossier.Update(db)
+	tu.AssertNoErr(t, err)
 
 	_, err = cps.DeleteParticipantById(db, p1.Id)
 	tu.AssertNoErr(t, err)
@@ -72,3 +85,36 @@ func TestCheckPersonneReferences(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, ref.Empty())
 }
+
+func tokenFrom(t *testing.T, espacePersoURL string) string {
+	u, err := url.Parse(espacePersoURL)
+	tu.AssertNoErr(t, err)
+	return u.Query().Get("token")
+}
+
+func TestEspacePersoToken(t *testing.T) {
+	key := crypto.NewEncrypter("test")
+	dossier := dossiers.Dossier{Id: 24, IdResponsable2: pr.IdPersonne(5).Opt()}
+
+	u := EspacePersoURL(key, "localhost", dossier.Id)
+	acces, err := DecryptEspacePersoToken(key, tokenFrom(t, u))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, acces == EspacePersoAcces{IdDossier: 24})
+	tu.AssertNoErr(t, CheckAccesResponsable2(dossier, acces, true))
+
+	u = EspacePersoURLResponsable2(key, "localhost", dossier.Id, 5)
+	acces, err = DecryptEspacePersoToken(key, tokenFrom(t, u))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, acces.IdDossier == 24 && acces.IsResponsable2())
+	tu.AssertNoErr(t, CheckAccesResponsable2(dossier, acces, false))
+	tu.AssertErr(t, CheckAccesResponsable2(dossier, acces, true)) // read only
+
+	dossier.AccesResponsable2 = dossiers.AccesComplet
+	tu.AssertNoErr(t, CheckAccesResponsable2(dossier, acces, true))
+
+	dossier.IdResponsable2 = pr.IdPersonne(6).Opt() // revoked
+	tu.AssertErr(t, CheckAccesResponsable2(dossier, acces, false))
+
+	_, err = DecryptEspacePersoToken(key, "invalid")
+	tu.AssertErr(t, err)
+}
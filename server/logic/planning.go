@@ -0,0 +1,262 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"registro/config"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	sh "registro/sql/shared"
+	"registro/utils"
+)
+
+// planning des équipiers : affectations de la saison et taux d'encadrement,
+// contrôlés par la réglementation des accueils collectifs de mineurs (ACM)
+
+// AffectationEquipier est la présence d'un équipier sur un séjour.
+type AffectationEquipier struct {
+	IdEquipier cps.IdEquipier
+	IdCamp     cps.IdCamp
+	Camp       string // label
+	Roles      cps.Roles
+	Presence   sh.Plage // dates effectives (voir [cps.PresenceOffsets])
+}
+
+// ConflitAffectation signale deux séjours
+// dont les dates de présence se chevauchent.
+type ConflitAffectation struct {
+	IdCamp1, IdCamp2 cps.IdCamp
+	Jours            int // nombre de jours en commun
+}
+
+// PlanningPersonne regroupe les séjours d'une personne sur la saison.
+type PlanningPersonne struct {
+	IdPersonne   pr.IdPersonne
+	Personne     string
+	Affectations []AffectationEquipier // par date croissante
+	Conflits     []ConflitAffectation
+}
+
+// conflits renvoie les chevauchements entre les [affectations].
+func conflits(affectations []AffectationEquipier) (out []ConflitAffectation) {
+	for i, a1 := range affectations {
+		for _, a2 := range affectations[i+1:] {
+			if jours := a1.Presence.Intersection(a2.Presence); jours != 0 {
+				out = append(out, ConflitAffectation{a1.IdCamp, a2.IdCamp, jours})
+			}
+		}
+	}
+	return out
+}
+
+// EncadrementCamp est le rapport de conformité de l'équipe
+// d'un séjour aux règles d'encadrement.
+type EncadrementCamp struct {
+	IdCamp   cps.IdCamp
+	Camp     string // label
+	Agrement string // numéro Jeunesse et Sport
+
+	Participants     int // inscrits
+	Animateurs       int // animateurs et adjoints
+	AnimateursRequis int
+
+	Diplomes       int // animateurs diplômés
+	DiplomesRequis int
+
+	SansQualification    int // animateurs ni diplômés, ni stagiaires
+	SansQualificationMax int
+
+	SurveillantsBaignade int
+
+	Violations     []string // règles non respectées
+	Avertissements []string // points à vérifier
+
+	// ConformiteValidee est true si le centre a validé le rapport,
+	// et que celui-ci n'a pas changé depuis (voir [EncadrementCamp.Empreinte])
+	ConformiteValidee bool
+}
+
+// animateursRequis renvoie le nombre d'animateurs nécessaires pour
+// encadrer des participants ayant les [ages] donnés.
+func animateursRequis(tranches []config.TrancheEncadrement, ages []int) int {
+	if len(tranches) == 0 {
+		return 0
+	}
+	var total float64
+	for _, age := range ages {
+		tranche := tranches[len(tranches)-1]
+		for _, t := range tranches {
+			if age <= t.AgeMax {
+				tranche = t
+				break
+			}
+		}
+		if tranche.Participants > 0 {
+			total += 1 / float64(tranche.Participants)
+		}
+	}
+	// évite les erreurs d'arrondi (12 x 1/12)
+	return int(math.Ceil(total - 1e-9))
+}
+
+// isAnimateur renvoie true si l'équipier compte dans le taux d'encadrement.
+func isAnimateur(equipier cps.Equipier) bool {
+	return equipier.Roles.Is(cps.Animation) || equipier.Roles.Is(cps.Adjoint)
+}
+
+func newEncadrementCamp(cfg config.Encadrement, camp cps.Camp, ages []int,
+	equipe cps.Equipiers, fiches map[pr.IdPersonne]pr.Ficheequipier,
+) EncadrementCamp {
+	out := EncadrementCamp{
+		IdCamp:           camp.Id,
+		Camp:             camp.Label(),
+		Agrement:         camp.Agrement,
+		Participants:     len(ages),
+		AnimateursRequis: animateursRequis(cfg.Tranches, ages),
+	}
+	for _, equipier := range equipe {
+		fiche := fiches[equipier.IdPersonne]
+		if fiche.Approfondissement == pr.ASb {
+			out.SurveillantsBaignade++
+		}
+		if !isAnimateur(equipier) {
+			continue
+		}
+		out.Animateurs++
+		if fiche.Diplome.IsQualifiant() {
+			out.Diplomes++
+		} else if !fiche.Diplome.IsStagiaire() {
+			out.SansQualification++
+		}
+	}
+	out.DiplomesRequis = int(math.Ceil(float64(out.Animateurs*cfg.DiplomesMin) / 100))
+	out.SansQualificationMax = out.Animateurs * cfg.SansQualificationMax / 100
+
+	if directeur, has := equipe.Directeur(); !has {
+		out.Violations = append(out.Violations, "Le séjour n'a pas de directeur.")
+	} else if cfg.DirecteurBAFD && !fiches[directeur.IdPersonne].Diplome.IsBAFD() {
+		out.Violations = append(out.Violations, "Le directeur n'est pas titulaire ou stagiaire BAFD.")
+	}
+	if out.Animateurs < out.AnimateursRequis {
+		out.Violations = append(out.Violations, fmt.Sprintf("%d animateur(s) pour %d requis.", out.Animateurs, out.AnimateursRequis))
+	}
+	if out.Diplomes < out.DiplomesRequis {
+		out.Violations = append(out.Violations, fmt.Sprintf("%d animateur(s) diplômé(s) pour %d requis.", out.Diplomes, out.DiplomesRequis))
+	}
+	if out.SansQualification > out.SansQualificationMax {
+		out.Violations = append(out.Violations, fmt.Sprintf("%d animateur(s) sans qualification pour %d autorisé(s) au plus.", out.SansQualification, out.SansQualificationMax))
+	}
+
+	if out.SurveillantsBaignade < cfg.SurveillantBaignade {
+		out.Avertissements = append(out.Avertissements, fmt.Sprintf("%d surveillant(s) de baignade pour %d requis.", out.SurveillantsBaignade, cfg.SurveillantBaignade))
+	}
+	if strings.TrimSpace(camp.Agrement) == "" {
+		out.Avertissements = append(out.Avertissements, "Le numéro d'agrément Jeunesse et Sport n'est pas renseigné.")
+	}
+
+	// une empreinte vide correspond aux séjours validés avant son introduction
+	out.ConformiteValidee = camp.ConformiteValidee &&
+		(camp.ConformiteEmpreinte == "" || camp.ConformiteEmpreinte == out.Empreinte())
+	return out
+}
+
+// IsConforme renvoie true si aucune règle n'est enfreinte.
+func (ec EncadrementCamp) IsConforme() bool { return len(ec.Violations) == 0 }
+
+// Empreinte renvoie le hash du contenu du rapport, enregistré lors
+// de sa validation par le centre : toute modification de l'équipe
+// ou des inscrits changeant le rapport annule la validation.
+func (ec EncadrementCamp) Empreinte() string {
+	ec.ConformiteValidee = false
+	content, _ := json.Marshal(ec) // the struct is always marshallable
+	return fs.Empreinte(content)
+}
+
+// loadEncadrements renvoie le rapport de conformité des [camps], par date de début,
+// ainsi que leurs équipiers.
+func loadEncadrements(db cps.DB, camps cps.Camps, cfg config.Encadrement) ([]EncadrementCamp, cps.Equipiers, pr.Personnes, error) {
+	data, err := cps.LoadCamps(db, camps.IDs())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	equipiers, personnes, fiches, err := cps.LoadEquipiersByCamps(db, camps.IDs()...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	byCamp := equipiers.ByIdCamp()
+
+	sortedCamps := utils.MapValues(camps)
+	slices.SortFunc(sortedCamps, func(a, b cps.Camp) int { return a.DateDebut.Time().Compare(b.DateDebut.Time()) })
+	out := make([]EncadrementCamp, len(sortedCamps))
+	for i, camp := range sortedCamps {
+		inscrits := data.For(camp.Id).Participants(true)
+		ages := make([]int, len(inscrits))
+		for j, inscrit := range inscrits {
+			ages[j] = camp.AgeDebutCamp(inscrit.Personne.DateNaissance)
+		}
+		out[i] = newEncadrementCamp(cfg, camp, ages, byCamp[camp.Id], fiches)
+	}
+	return out, equipiers, personnes, nil
+}
+
+// LoadEncadrementCamp renvoie le rapport de conformité du séjour [idCamp].
+func LoadEncadrementCamp(db cps.DB, idCamp cps.IdCamp, cfg config.Encadrement) (EncadrementCamp, error) {
+	camp, err := cps.SelectCamp(db, idCamp)
+	if err != nil {
+		return EncadrementCamp{}, utils.SQLError(err)
+	}
+	out, _, _, err := loadEncadrements(db, cps.Camps{camp.Id: camp}, cfg)
+	if err != nil {
+		return EncadrementCamp{}, err
+	}
+	return out[0], nil
+}
+
+// PlanningEquipiers est la vue d'ensemble des équipes
+// d'une saison.
+type PlanningEquipiers struct {
+	Personnes []PlanningPersonne // par ordre alphabétique
+	Camps     []EncadrementCamp  // par date de début
+}
+
+// LoadPlanningEquipiers renvoie les affectations des équipiers
+// des séjours commençant l'année [year], et vérifie l'encadrement de chaque séjour.
+func LoadPlanningEquipiers(db cps.DB, year int, cfg config.Encadrement) (PlanningEquipiers, error) {
+	camps, err := cps.SelectAllCamps(db)
+	if err != nil {
+		return PlanningEquipiers{}, utils.SQLError(err)
+	}
+	camps.RestrictByYear(year)
+
+	encadrements, equipiers, personnes, err := loadEncadrements(db, camps, cfg)
+	if err != nil {
+		return PlanningEquipiers{}, err
+	}
+	out := PlanningEquipiers{Camps: encadrements}
+
+	byPersonne := make(map[pr.IdPersonne][]AffectationEquipier)
+	for _, equipier := range equipiers {
+		camp := camps[equipier.IdCamp]
+		byPersonne[equipier.IdPersonne] = append(byPersonne[equipier.IdPersonne], AffectationEquipier{
+			IdEquipier: equipier.Id,
+			IdCamp:     camp.Id,
+			Camp:       camp.Label(),
+			Roles:      equipier.Roles,
+			Presence:   equipier.Presence.Plage(camp.Plage()),
+		})
+	}
+	for idPersonne, affectations := range byPersonne {
+		slices.SortFunc(affectations, func(a, b AffectationEquipier) int {
+			return a.Presence.From.Time().Compare(b.Presence.From.Time())
+		})
+		personne := personnes[idPersonne]
+		out.Personnes = append(out.Personnes, PlanningPersonne{idPersonne, personne.NOMPrenom(), affectations, conflits(affectations)})
+	}
+	slices.SortFunc(out.Personnes, func(a, b PlanningPersonne) int { return strings.Compare(a.Personne, b.Personne) })
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"registro/config"
+	cps "registro/sql/camps"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestConflitsAffectations(t *testing.T) {
+	juillet := cps.Camp{Id: 1, DateDebut: shared.NewDate(2024, time.July, 1), Duree: 10}
+	aout := cps.Camp{Id: 2, DateDebut: shared.NewDate(2024, time.July, 10), Duree: 10}
+	affectations := []AffectationEquipier{
+		{IdCamp: 1, Presence: cps.PresenceOffsets{}.Plage(juillet.Plage())},
+		{IdCamp: 2, Presence: cps.PresenceOffsets{}.Plage(aout.Plage())},
+	}
+	tu.Assert(t, len(conflits(affectations)) == 1 && conflits(affectations)[0].Jours == 1)
+
+	// départ anticipé d'un jour
+	affectations[0].Presence = cps.PresenceOffsets{Fin: -1}.Plage(juillet.Plage())
+	tu.Assert(t, affectations[0].Presence.To() == shared.NewDate(2024, time.July, 9))
+	tu.Assert(t, len(conflits(affectations)) == 0)
+}
+
+func TestEncadrementCamp(t *testing.T) {
+	cfg := config.Encadrement{
+		Tranches:             []config.TrancheEncadrement{{AgeMax: 5, Participants: 8}, {AgeMax: 17, Participants: 12}},
+		DiplomesMin:          50,
+		SansQualificationMax: 20,
+		DirecteurBAFD:        true,
+		SurveillantBaignade:  1,
+	}
+	tu.Assert(t, animateursRequis(cfg.Tranches, nil) == 0)
+	tu.Assert(t, animateursRequis(cfg.Tranches, []int{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10}) == 1)
+	tu.Assert(t, animateursRequis(cfg.Tranches, []int{4, 4, 4, 4, 8, 8, 8, 8, 8, 8}) == 1)
+	tu.Assert(t, animateursRequis(cfg.Tranches, []int{4, 4, 4, 4, 8, 8, 8, 8, 8, 8, 8}) == 2)
+	tu.Assert(t, animateursRequis(cfg.Tranches, []int{20}) == 1) // dernière tranche
+
+	equipe := cps.Equipiers{
+		1: {Id: 1, IdPersonne: 1, Roles: cps.Roles{cps.Direction}},
+		2: {Id: 2, IdPersonne: 2, Roles: cps.Roles{cps.Animation}},
+		3: {Id: 3, IdPersonne: 3, Roles: cps.Roles{cps.Animation}},
+		4: {Id: 4, IdPersonne: 4, Roles: cps.Roles{cps.Cuisine}},
+	}
+	fiches := map[pr.IdPersonne]pr.Ficheequipier{
+		1: {Diplome: pr.DBafdStag},
+		2: {Diplome: pr.DBafa},
+		3: {Diplome: pr.DBafaStag},
+		4: {Approfondissement: pr.ASb},
+	}
+	ages := make([]int, 24)
+	for i := range ages {
+		ages[i] = 10
+	}
+	camp := cps.Camp{Agrement: "026ORG0163"}
+	out := newEncadrementCamp(cfg, camp, ages, equipe, fiches)
+	tu.Assert(t, out.Animateurs == 2 && out.AnimateursRequis == 2)
+	tu.Assert(t, out.Diplomes == 1 && out.DiplomesRequis == 1)
+	tu.Assert(t, out.SansQualification == 0 && out.SurveillantsBaignade == 1)
+	tu.Assert(t, out.IsConforme() && len(out.Avertissements) == 0)
+	tu.Assert(t, !out.ConformiteValidee)
+
+	// la validation porte sur le rapport validé
+	validated := cps.Camp{Agrement: camp.Agrement, ConformiteValidee: true, ConformiteEmpreinte: out.Empreinte()}
+	out = newEncadrementCamp(cfg, validated, ages, equipe, fiches)
+	tu.Assert(t, out.ConformiteValidee)
+	out = newEncadrementCamp(cfg, validated, append(ages, 10), equipe, fiches)
+	tu.Assert(t, !out.ConformiteValidee) // un inscrit de plus
+	out = newEncadrementCamp(cfg, cps.Camp{Agrement: camp.Agrement, ConformiteValidee: true}, append(ages, 10), equipe, fiches)
+	tu.Assert(t, out.ConformiteValidee) // validation antérieure à l'empreinte
+
+	fiches[1] = pr.Ficheequipier{Diplome: pr.DBafa}
+	fiches[4] = pr.Ficheequipier{}
+	out = newEncadrementCamp(cfg, camp, append(ages, 10), equipe, fiches)
+	tu.Assert(t, out.AnimateursRequis == 3)
+	tu.Assert(t, len(out.Violations) == 2)     // BAFD, animateurs
+	tu.Assert(t, len(out.Avertissements) == 1) // baignade
+
+	fiches[3] = pr.Ficheequipier{}
+	out = newEncadrementCamp(cfg, camp, ages, equipe, fiches)
+	tu.Assert(t, out.SansQualification == 1 && out.SansQualificationMax == 0)
+	tu.Assert(t, len(out.Violations) == 2) // BAFD, sans qualification
+
+	delete(equipe, 1)
+	out = newEncadrementCamp(cfg, cps.Camp{}, nil, equipe, fiches)
+	tu.Assert(t, len(out.Violations) == 2)     // directeur, sans qualification
+	tu.Assert(t, len(out.Avertissements) == 2) // baignade, agrément
+}
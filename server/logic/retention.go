@@ -0,0 +1,267 @@
+package logic
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"registro/config"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+)
+
+type RetentionCategorie uint8
+
+const (
+	RFichesanitaire      RetentionCategorie = iota // Fiche sanitaire
+	RDocumentParticipant                           // Document participant
+	RDocumentEquipier                              // Document équipier
+	RSecuriteSociale                               // Numéro de sécurité sociale
+)
+
+func (rc RetentionCategorie) duree(cfg config.Retention) int {
+	switch rc {
+	case RFichesanitaire:
+		return cfg.Fichesanitaire
+	case RDocumentParticipant:
+		return cfg.DocumentsParticipant
+	case RDocumentEquipier:
+		return cfg.DocumentsEquipier
+	case RSecuriteSociale:
+		return cfg.SecuriteSociale
+	default:
+		return 0
+	}
+}
+
+// DonneeRetention est une donnée sensible, soumise
+// à une durée de conservation limitée.
+type DonneeRetention struct {
+	Categorie  RetentionCategorie
+	IdPersonne pr.IdPersonne
+	Personne   string
+	IdFile     fs.OptIdFile // pour les documents
+	Label      string       // nom du document
+	Expiration shared.Date  // date à partir de laquelle la donnée est supprimée
+}
+
+// isEquipierDocument renvoie true pour les documents demandés aux équipiers
+// (les vaccins, le PAI et les demandes personnalisées concernent les participants)
+func isEquipierDocument(demande fs.Demande) bool {
+	switch demande.Categorie {
+	case fs.NoBuiltin, fs.Vaccins, fs.PAI:
+		return false
+	default:
+		return true
+	}
+}
+
+// lastCamps renvoie, pour chaque personne, la date de fin
+// de son dernier séjour (comme participant ou équipier)
+func lastCamps(db cps.DB) (map[pr.IdPersonne]time.Time, error) {
+	camps, err := cps.SelectAllCamps(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	participants, err := cps.SelectAllParticipants(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	equipiers, err := cps.SelectAllEquipiers(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	out := make(map[pr.IdPersonne]time.Time)
+	update := func(id pr.IdPersonne, idCamp cps.IdCamp) {
+		camp := camps[idCamp]
+		if fin := camp.DateFin().Time(); fin.After(out[id]) {
+			out[id] = fin
+		}
+	}
+	for _, part := range participants {
+		update(part.IdPersonne, part.IdCamp)
+	}
+	for _, equipier := range equipiers {
+		update(equipier.IdPersonne, equipier.IdCamp)
+	}
+	return out, nil
+}
+
+func laterOf(t1, t2 time.Time) time.Time {
+	if t1.After(t2) {
+		return t1
+	}
+	return t2
+}
+
+// loadRetention liste toutes les données soumises à une durée de conservation,
+// en ignorant les catégories désactivées.
+//
+// La durée est décomptée à partir de la fin du dernier séjour de la personne,
+// ou de la dernière modification de la donnée si elle est plus récente.
+func loadRetention(db pr.DB, cfg config.Retention) ([]DonneeRetention, error) {
+	lasts, err := lastCamps(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DonneeRetention
+	add := func(item DonneeRetention, ref time.Time) {
+		duree := item.Categorie.duree(cfg)
+		if duree <= 0 || ref.IsZero() {
+			return
+		}
+		item.Expiration = shared.NewDateFrom(ref.AddDate(0, duree, 0))
+		out = append(out, item)
+	}
+
+	fiches, err := pr.SelectAllFichesanitaires(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	for _, fiche := range fiches {
+		add(DonneeRetention{Categorie: RFichesanitaire, IdPersonne: fiche.IdPersonne},
+			laterOf(lasts[fiche.IdPersonne], fiche.Modified))
+	}
+
+	// les fiches équipiers ne sont pas datées : seuls les séjours sont pris en compte
+	fichesEquipiers, err := pr.SelectAllFicheequipiers(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	for _, fiche := range fichesEquipiers {
+		if fiche.SecuriteSociale == "" {
+			continue
+		}
+		add(DonneeRetention{Categorie: RSecuriteSociale, IdPersonne: fiche.IdPersonne}, lasts[fiche.IdPersonne])
+	}
+
+	links, err := fs.SelectAllFilePersonnes(db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	files, err := fs.SelectFiles(db, links.IdFiles()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	demandes, err := fs.SelectDemandes(db, links.IdDemandes()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	for _, link := range links {
+		file := files[link.IdFile]
+		categorie := RDocumentParticipant
+		if isEquipierDocument(demandes[link.IdDemande]) {
+			categorie = RDocumentEquipier
+		}
+		add(DonneeRetention{Categorie: categorie, IdPersonne: link.IdPersonne, IdFile: file.Id.Opt(), Label: file.NomClient},
+			laterOf(lasts[link.IdPersonne], file.Uploaded))
+	}
+
+	ids := utils.NewSet[pr.IdPersonne]()
+	for _, item := range out {
+		ids.Add(item.IdPersonne)
+	}
+	personnes, err := pr.SelectPersonnes(db, ids.Keys()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	for i, item := range out {
+		out[i].Personne = personnes[item.IdPersonne].NOMPrenom()
+	}
+
+	slices.SortStableFunc(out, func(a, b DonneeRetention) int { return a.Expiration.Time().Compare(b.Expiration.Time()) })
+	return out, nil
+}
+
+// LoadPurgeReport renvoie les données qui seront supprimées
+// dans les [jours] prochains jours (ou qui le sont déjà),
+// triées par date d'expiration.
+func LoadPurgeReport(db pr.DB, cfg config.Retention, now time.Time, jours int) ([]DonneeRetention, error) {
+	items, err := loadRetention(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	limit := shared.NewDateFrom(now).AddDays(jours).Time()
+	out := slices.DeleteFunc(items, func(item DonneeRetention) bool { return item.Expiration.Time().After(limit) })
+	return out, nil
+}
+
+// PurgeDonneesExpirees supprime les données dont la durée de conservation
+// est dépassée à la date [now], y compris le contenu des documents
+// dans [files]. Les données supprimées sont renvoyées.
+//
+// Les données expirées sont recherchées dans la transaction qui les supprime :
+// une donnée modifiée entre-temps (fiche mise à jour, nouveau séjour)
+// n'est pas supprimée.
+func PurgeDonneesExpirees(db *sql.DB, files fs.FileSystem, cfg config.Retention, now time.Time) ([]DonneeRetention, error) {
+	var (
+		expired []DonneeRetention
+		deleted fs.Files
+	)
+	err := utils.InTx(db, func(tx *sql.Tx) error {
+		// une modification concurrente des données lues fait échouer la purge,
+		// qui est relancée la nuit suivante
+		_, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ")
+		if err != nil {
+			return err
+		}
+		expired, err = LoadPurgeReport(tx, cfg, now, 0)
+		if err != nil {
+			return err
+		}
+
+		var (
+			fiches, securiteSociales []pr.IdPersonne
+			toDelete                 []fs.IdFile
+		)
+		for _, item := range expired {
+			switch item.Categorie {
+			case RFichesanitaire:
+				fiches = append(fiches, item.IdPersonne)
+			case RSecuriteSociale:
+				securiteSociales = append(securiteSociales, item.IdPersonne)
+			case RDocumentParticipant, RDocumentEquipier:
+				toDelete = append(toDelete, item.IdFile.Id)
+			}
+		}
+
+		_, err = pr.DeleteFichesanitairesByIdPersonnes(tx, fiches...)
+		if err != nil {
+			return err
+		}
+		_, err = pr.DeleteFichesanitaireVersionsByIdPersonnes(tx, fiches...)
+		if err != nil {
+			return err
+		}
+		fichesEquipiers, err := pr.SelectFicheequipiersByIdPersonnes(tx, securiteSociales...)
+		if err != nil {
+			return err
+		}
+		for _, fiche := range fichesEquipiers {
+			fiche.SecuriteSociale = ""
+			if err = fiche.Delete(tx); err != nil {
+				return err
+			}
+			if err = fiche.Insert(tx); err != nil {
+				return err
+			}
+		}
+		deleted, err = fs.DeleteFiles(tx, toDelete...) // cascade on FilePersonne
+		return err
+	})
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+
+	// the metadata are deleted : cleanup the content
+	err = files.Delete(db, utils.MapValues(deleted)...)
+	if err != nil {
+		return expired, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"registro/config"
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestPurgeDonneesExpirees(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	files := fs.NewFileSystem(t.TempDir())
+	cfg := config.Retention{Fichesanitaire: 12, DocumentsParticipant: 12, DocumentsEquipier: 36, SecuriteSociale: 0}
+
+	pe1, err := pr.Personne{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	pe2, err := pr.Personne{}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	// pe1 : ancien séjour, pe2 : séjour récent
+	old, err := cps.Camp{IdTaux: 1, DateDebut: shared.NewDate(2020, 7, 1), Duree: 10}.Insert(db)
+	tu.AssertNoErr(t, err)
+	recent, err := cps.Camp{IdTaux: 1, DateDebut: shared.NewDate(2025, 7, 1), Duree: 10}.Insert(db)
+	tu.AssertNoErr(t, err)
+	dossier, err := ds.Dossier{IdResponsable: pe1.Id, IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Participant{IdCamp: old.Id, IdPersonne: pe1.Id, IdDossier: dossier.Id, IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Participant{IdCamp: recent.Id, IdPersonne: pe2.Id, IdDossier: dossier.Id, IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	modified := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	err = pr.Fichesanitaire{IdPersonne: pe1.Id, Modified: modified}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Fichesanitaire{IdPersonne: pe2.Id, Modified: modified}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Ficheequipier{IdPersonne: pe1.Id, SecuriteSociale: "1 02 03 26"}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	demande, err := fs.Demande{Categorie: fs.Vaccins, MaxDocs: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file, err := fs.File{Uploaded: modified}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = fs.FilePersonne{IdFile: file.Id, IdPersonne: pe1.Id, IdDemande: demande.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, files.Save(file, []byte("content"), false))
+	tu.AssertNoErr(t, files.Save(file, []byte("min"), true))
+
+	now := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := LoadPurgeReport(db.DB, cfg, now, 30)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(report) == 2) // fiche sanitaire et vaccins de pe1
+
+	// la fiche de pe2 expire l'année prochaine
+	report, err = LoadPurgeReport(db.DB, cfg, now, 365)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(report) == 3)
+
+	purged, err := PurgeDonneesExpirees(db.DB, files, cfg, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(purged) == 2)
+
+	fiches, err := pr.SelectAllFichesanitaires(db)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(fiches) == 1 && fiches[0].IdPersonne == pe2.Id)
+	links, err := fs.SelectAllFilePersonnes(db)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(links) == 0)
+	_, err = files.Load(file, false)
+	tu.Assert(t, err != nil)
+
+	// catégorie désactivée
+	fiche, found, err := pr.SelectFicheequipierByIdPersonne(db, pe1.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && fiche.SecuriteSociale != "")
+
+	cfg.SecuriteSociale = 12
+	purged, err = PurgeDonneesExpirees(db.DB, files, cfg, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(purged) == 1)
+	fiche, _, err = pr.SelectFicheequipierByIdPersonne(db, pe1.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, fiche.SecuriteSociale == "")
+}
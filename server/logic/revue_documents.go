@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"registro/config"
+	"registro/crypto"
+	"registro/mails"
+	cps "registro/sql/camps"
+	fs "registro/sql/files"
+	pr "registro/sql/personnes"
+	"registro/utils"
+)
+
+// RevueDocumentIn modifie l'état de vérification d'un document
+type RevueDocumentIn struct {
+	Key         string // crypted [fs.IdFile]
+	Revue       fs.Revue
+	Commentaire string // obligatoire pour un refus
+}
+
+// RevueDocument enregistre la vérification d'un document envoyé par
+// un participant ou un équipier du séjour [idCamp].
+// En cas de refus, l'expéditeur est prévenu par mail.
+func RevueDocument(db *sql.DB, key crypto.Encrypter, smtp config.SMTP, asso config.Asso, host string,
+	idCamp cps.IdCamp, args RevueDocumentIn,
+) (PublicFile, error) {
+	id, err := crypto.DecryptID[fs.IdFile](key, args.Key)
+	if err != nil {
+		return PublicFile{}, err
+	}
+	args.Commentaire = strings.TrimSpace(args.Commentaire)
+	if args.Revue == fs.Refuse && args.Commentaire == "" {
+		return PublicFile{}, errors.New("Merci de préciser le motif du refus.")
+	}
+	if args.Revue != fs.Refuse {
+		args.Commentaire = ""
+	}
+
+	file, err := fs.SelectFile(db, id)
+	if err != nil {
+		return PublicFile{}, utils.SQLError(err)
+	}
+	link, found, err := fs.SelectFilePersonneByIdFile(db, id)
+	if err != nil {
+		return PublicFile{}, utils.SQLError(err)
+	}
+	if !found {
+		return PublicFile{}, errors.New("internal error: document not linked to a personne")
+	}
+	// vérifie que la personne est liée au séjour
+	equipier, isEquipier, err := cps.SelectEquipierByIdCampAndIdPersonne(db, idCamp, link.IdPersonne)
+	if err != nil {
+		return PublicFile{}, utils.SQLError(err)
+	}
+	participant, isParticipant, err := cps.SelectParticipantByIdCampAndIdPersonne(db, idCamp, link.IdPersonne)
+	if err != nil {
+		return PublicFile{}, utils.SQLError(err)
+	}
+	if !isEquipier && !isParticipant {
+		return PublicFile{}, errors.New("access forbidden")
+	}
+
+	file.Revue, file.RevueCommentaire = args.Revue, args.Commentaire
+	file, err = file.Update(db)
+	if err != nil {
+		return PublicFile{}, utils.SQLError(err)
+	}
+
+	if file.Revue == fs.Refuse {
+		demande, err := fs.SelectDemande(db, link.IdDemande)
+		if err != nil {
+			return PublicFile{}, utils.SQLError(err)
+		}
+		camp, err := cps.SelectCamp(db, idCamp)
+		if err != nil {
+			return PublicFile{}, utils.SQLError(err)
+		}
+		var (
+			to, html string
+			ccs      []string
+		)
+		if isParticipant {
+			dossier, err := LoadDossier(db, participant.IdDossier)
+			if err != nil {
+				return PublicFile{}, err
+			}
+			responsable, personne := dossier.Responsable(), dossier.PersonneFor(participant)
+			url := EspacePersoURL(key, host, participant.IdDossier, utils.QP("origine", "document-refuse"))
+			html, err = mails.RefuseDocument(asso, mails.NewContact(&responsable), camp.Label(), personne.FPrenom(), demande.Title(), file.RevueCommentaire, url)
+			if err != nil {
+				return PublicFile{}, err
+			}
+			to, ccs = responsable.Mail, dossier.Dossier.CopiesMails
+		} else {
+			personne, err := pr.SelectPersonne(db, equipier.IdPersonne)
+			if err != nil {
+				return PublicFile{}, utils.SQLError(err)
+			}
+			url := EquipierURL(key, host, equipier.Id)
+			html, err = mails.RefuseDocument(asso, mails.NewContact(&personne), camp.Label(), "", demande.Title(), file.RevueCommentaire, url)
+			if err != nil {
+				return PublicFile{}, err
+			}
+			to = personne.Mail
+		}
+		if to != "" {
+			err = mails.NewMailer(smtp, asso.MailsSettings).SendMail(to, "Document refusé", html, ccs, nil)
+			if err != nil {
+				return PublicFile{}, err
+			}
+		}
+	}
+
+	return NewPublicFile(key, file), nil
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"testing"
+
+	"registro/config"
+	"registro/crypto"
+	cps "registro/sql/camps"
+	"registro/sql/files"
+	pr "registro/sql/personnes"
+	tu "registro/utils/testutils"
+)
+
+func TestRevueDocument(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	key := crypto.NewEncrypter("test")
+	personne, err := pr.Personne{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp1, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp2, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Equipier{IdCamp: camp1.Id, IdPersonne: personne.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	demande, err := files.Demande{MaxDocs: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file, err := files.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = files.FilePersonne{IdFile: file.Id, IdPersonne: personne.Id, IdDemande: demande.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	fileKey := crypto.EncryptID(key, file.Id)
+
+	// motif requis
+	_, err = RevueDocument(db.DB, key, config.SMTP{}, config.Asso{}, "", camp1.Id, RevueDocumentIn{Key: fileKey, Revue: files.Refuse, Commentaire: " "})
+	tu.AssertErr(t, err)
+	// autre séjour
+	_, err = RevueDocument(db.DB, key, config.SMTP{}, config.Asso{}, "", camp2.Id, RevueDocumentIn{Key: fileKey, Revue: files.Accepte})
+	tu.AssertErr(t, err)
+
+	out, err := RevueDocument(db.DB, key, config.SMTP{}, config.Asso{}, "", camp1.Id, RevueDocumentIn{Key: fileKey, Revue: files.Accepte, Commentaire: "ignoré"})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, out.Revue == files.Accepte && out.RevueCommentaire == "")
+}
@@ -0,0 +1,367 @@
+package logic
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+
+	cps "registro/sql/camps"
+	"registro/sql/dons"
+	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
+	in "registro/sql/inscriptions"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+)
+
+// DonneesPersonne regroupe toutes les données liées à une personne,
+// et permet de répondre à une demande d'accès (RGPD).
+type DonneesPersonne struct {
+	Personne   pr.Personne
+	References PersonneReferences
+
+	Fichesanitaires        pr.Fichesanitaires // 0 ou 1 élément
+	FichesanitaireVersions []pr.FichesanitaireVersion
+	Ficheequipiers         pr.Ficheequipiers // 0 ou 1 élément
+	Certifications         []pr.Certification
+
+	Camps        []CampItem
+	Participants []cps.Participant
+	Equipiers    []cps.Equipier
+	Soins        []cps.Soin // registre de l'infirmerie
+	Candidatures []cps.Candidature
+	Depenses     []cps.Depense // notes de frais
+
+	// Dossiers dont la personne est responsable (ou second responsable)
+	Dossiers  []ds.Dossier
+	Paiements []ds.Paiement
+	Events    map[ds.IdDossier]Events
+
+	Dons []dons.Don
+
+	// Files contient les métadonnées des documents,
+	// dont le contenu doit être chargé séparément
+	Files         []fs.File
+	Demandes      fs.Demandes
+	Links         fs.FilePersonnes
+	Signatures    fs.Signatures   // documents signés, inclus dans [Files]
+	Justificatifs fs.FileDepenses // justificatifs des notes de frais, inclus dans [Files]
+}
+
+// LoadDonneesPersonne charge toutes les données liées à la personne [id].
+// Le contenu des documents n'est pas chargé.
+func LoadDonneesPersonne(db *sql.DB, id pr.IdPersonne) (out DonneesPersonne, err error) {
+	out.Personne, err = pr.SelectPersonne(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.References, err = CheckPersonneReferences(db, id)
+	if err != nil {
+		return out, err
+	}
+
+	out.Fichesanitaires, err = pr.SelectFichesanitairesByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	versions, err := pr.SelectFichesanitaireVersionsByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.FichesanitaireVersions = utils.MapValues(versions)
+	slices.SortFunc(out.FichesanitaireVersions, func(a, b pr.FichesanitaireVersion) int { return int(a.Id - b.Id) })
+	out.Ficheequipiers, err = pr.SelectFicheequipiersByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	certifications, err := pr.SelectCertificationsByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Certifications = utils.MapValues(certifications)
+	slices.SortFunc(out.Certifications, func(a, b pr.Certification) int { return int(a.Id - b.Id) })
+
+	participants, err := cps.SelectParticipants(db, out.References.Participants...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	equipiers, err := cps.SelectEquipiers(db, out.References.Equipiers...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	camps, err := cps.SelectCamps(db, append(participants.IdCamps(), equipiers.IdCamps()...)...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Camps = NewCampItems(camps)
+	out.Participants = utils.MapValues(participants)
+	out.Equipiers = utils.MapValues(equipiers)
+	soins, err := cps.SelectSoinsByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Soins = utils.MapValues(soins)
+	candidatures, err := cps.SelectCandidaturesByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Candidatures = utils.MapValues(candidatures)
+	depenses, err := cps.SelectDepensesByIdEquipiers(db, out.References.Equipiers...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Depenses = utils.MapValues(depenses)
+	sortDepenses(out.Depenses, func(d cps.Depense) cps.Depense { return d })
+
+	dossiers, err := ds.SelectDossiers(db, out.References.Dossiers...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	paiements, err := ds.SelectPaiementsByIdDossiers(db, out.References.Dossiers...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	events, err := LoadEventsByDossiers(db, out.References.Dossiers...)
+	if err != nil {
+		return out, err
+	}
+	out.Dossiers = utils.MapValues(dossiers)
+	out.Paiements = utils.MapValues(paiements)
+	out.Events = make(map[ds.IdDossier]Events)
+	for _, id := range out.References.Dossiers {
+		out.Events[id] = events.For(id)
+	}
+
+	allDons, err := dons.SelectDons(db, out.References.Dons...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Dons = utils.MapValues(allDons)
+
+	out.Signatures, err = fs.SelectSignaturesByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Justificatifs, err = fs.SelectFileDepensesByIdDepenses(db, depenses.IDs()...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	idFiles := append(append(out.References.Files, out.Signatures.IdFiles()...), out.Justificatifs.IdFiles()...)
+	files, err := fs.SelectFiles(db, idFiles...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Links, err = fs.SelectFilePersonnesByIdPersonnes(db, id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Demandes, err = fs.SelectDemandes(db, out.Links.IdDemandes()...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	out.Files = utils.MapValues(files)
+
+	return out, nil
+}
+
+const nomAnonyme = "Anonyme"
+
+// anonymiseIdentite ne conserve que le sexe et l'année de naissance,
+// utilisés pour les statistiques.
+func anonymiseIdentite(identite pr.Identite) pr.Identite {
+	out := pr.Identite{Nom: nomAnonyme, Sexe: identite.Sexe}
+	if !identite.DateNaissance.Time().IsZero() {
+		out.DateNaissance = shared.NewDate(identite.DateNaissance.Time().Year(), 1, 1)
+	}
+	return out
+}
+
+func isSameIdentite(part in.InscriptionParticipant, pe pr.Identite) bool {
+	return strings.EqualFold(strings.TrimSpace(part.Nom), strings.TrimSpace(pe.Nom)) &&
+		strings.EqualFold(strings.TrimSpace(part.Prenom), strings.TrimSpace(pe.Prenom)) &&
+		part.DateNaissance == pe.DateNaissance
+}
+
+// AnonymisePersonne efface les données personnelles de la personne [id] (droit à l'oubli) :
+//   - l'identité est remplacée par un profil anonyme
+//   - la fiche sanitaire, la fiche équipier, les documents et les signatures sont supprimés
+//   - les copies de l'identité dans les inscriptions sont effacées
+//   - les justificatifs des notes de frais sont supprimés, ainsi que les notes
+//     non réglées ; les notes remboursées (ou converties en don) sont conservées
+//     sans leur description
+//
+// Les participants, dossiers, paiements et dons sont conservés,
+// de sorte que la comptabilité reste cohérente.
+//
+// Les fichiers supprimés sont renvoyés : leur contenu doit être supprimé
+// du [fs.FileSystem] par l'appelant.
+func AnonymisePersonne(tx *sql.Tx, id pr.IdPersonne) (pr.Personne, fs.Files, error) {
+	pe, err := pr.SelectPersonne(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	identite := pe.Identite
+
+	// copies de l'identité, à traiter en premier
+	participants, err := cps.SelectParticipantsByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	inscriptions, err := in.SelectInscriptionsByConfirmedAsDossiers(tx, participants.IdDossiers()...)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	inscParticipants, err := in.DeleteInscriptionParticipantsByIdInscriptions(tx, inscriptions.IDs()...)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	for i, part := range inscParticipants {
+		if isSameIdentite(part, identite) {
+			anonyme := anonymiseIdentite(part.Identite())
+			inscParticipants[i].Nom, inscParticipants[i].Prenom = anonyme.Nom, anonyme.Prenom
+			inscParticipants[i].DateNaissance = anonyme.DateNaissance
+			inscParticipants[i].Nationnalite = anonyme.Nationnalite
+		}
+	}
+	err = in.InsertManyInscriptionParticipants(tx, inscParticipants...)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+
+	dossiers, err := ds.SelectDossiersByIdResponsables(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	for _, dossier := range dossiers {
+		dossier.CopiesMails = nil
+		_, err = dossier.Update(tx)
+		if err != nil {
+			return pe, nil, utils.SQLError(err)
+		}
+	}
+	inscriptions, err = in.SelectInscriptionsByConfirmedAsDossiers(tx, dossiers.IDs()...)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	for _, insc := range inscriptions {
+		anonyme := anonymiseIdentite(identite)
+		insc.Responsable = in.ResponsableLegal{Nom: anonyme.Nom, Sexe: anonyme.Sexe, DateNaissance: anonyme.DateNaissance}
+		insc.Message = ""
+		insc.CopiesMails = nil
+		_, err = insc.Update(tx)
+		if err != nil {
+			return pe, nil, utils.SQLError(err)
+		}
+	}
+
+	// le second responsable n'est pas lié à la comptabilité
+	dossiers, err = ds.SelectDossiersByIdResponsable2s(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	for _, dossier := range dossiers {
+		dossier.IdResponsable2 = pr.OptIdPersonne{}
+		dossier.AccesResponsable2 = ds.LectureSeule
+		_, err = dossier.Update(tx)
+		if err != nil {
+			return pe, nil, utils.SQLError(err)
+		}
+	}
+
+	// données de santé et documents
+	_, err = pr.DeleteFichesanitairesByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	_, err = pr.DeleteFichesanitaireVersionsByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	_, err = pr.DeleteFicheequipiersByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	_, err = cps.DeleteSoinsByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	_, err = cps.DeleteCandidaturesByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	certifications, err := pr.SelectCertificationsByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	links2, err := fs.SelectFileCertificationsByIdCertifications(tx, certifications.IDs()...)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	_, err = pr.DeleteCertificationsByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	links, err := fs.SelectFilePersonnesByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	signatures, err := fs.DeleteSignaturesByIdPersonnes(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	idFiles := append(append(links.IdFiles(), links2.IdFiles()...), signatures.IdFiles()...)
+	files, err := fs.DeleteFiles(tx, idFiles...) // cascade on FilePersonne
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	justificatifs, err := anonymiseDepenses(tx, id)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+	for idFile, file := range justificatifs {
+		files[idFile] = file
+	}
+
+	pe.Identite = anonymiseIdentite(identite)
+	pe.Publicite = pr.Publicite{}
+	pe, err = pe.Update(tx)
+	if err != nil {
+		return pe, nil, utils.SQLError(err)
+	}
+
+	return pe, files, nil
+}
+
+// anonymiseDepenses supprime les justificatifs des notes de frais de la personne [id],
+// ainsi que les notes non réglées, et efface la description des autres.
+func anonymiseDepenses(tx *sql.Tx, id pr.IdPersonne) (fs.Files, error) {
+	equipiers, err := cps.SelectEquipiersByIdPersonnes(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	files, err := DeleteJustificatifsDepenses(tx, equipiers.IDs()...)
+	if err != nil {
+		return nil, err
+	}
+	depenses, err := cps.SelectDepensesByIdEquipiers(tx, equipiers.IDs()...)
+	if err != nil {
+		return nil, err
+	}
+	var toDelete []cps.IdDepense
+	for _, depense := range depenses {
+		switch depense.Statut {
+		case cps.DepenseRemboursee, cps.DepenseConvertie: // comptabilité
+			depense.Description, depense.Commentaire = "", ""
+			if _, err = depense.Update(tx); err != nil {
+				return nil, err
+			}
+		default:
+			toDelete = append(toDelete, depense.Id)
+		}
+	}
+	if _, err = cps.DeleteDepensesByIDs(tx, toDelete...); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
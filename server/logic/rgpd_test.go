@@ -0,0 +1,113 @@
+package logic
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	cps "registro/sql/camps"
+	ds "registro/sql/dossiers"
+	fs "registro/sql/files"
+	in "registro/sql/inscriptions"
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	"registro/utils"
+	tu "registro/utils/testutils"
+)
+
+func TestAnonymiseIdentite(t *testing.T) {
+	out := anonymiseIdentite(pr.Identite{
+		Nom: "Kugler", Prenom: "Benoit", Sexe: pr.Man, Mail: "x@free.fr",
+		DateNaissance: shared.NewDate(2000, 5, 12), Adresse: "rue",
+	})
+	tu.Assert(t, reflect.DeepEqual(out, pr.Identite{Nom: nomAnonyme, Sexe: pr.Man, DateNaissance: shared.NewDate(2000, 1, 1)}))
+
+	out = anonymiseIdentite(pr.Identite{Nom: "Kugler"})
+	tu.Assert(t, out.DateNaissance.Time().IsZero())
+}
+
+func TestAnonymisePersonne(t *testing.T) {
+	db := tu.NewTestDB(t, "../migrations/create_1_tables.sql",
+		"../migrations/create_2_json_funcs.sql", "../migrations/create_3_constraints.sql",
+		"../migrations/init.sql")
+	defer db.Remove()
+
+	identite := pr.Identite{Nom: "Kugler", Prenom: "Benoit", DateNaissance: shared.NewDate(2000, 5, 12), Mail: "x@free.fr"}
+	pe, err := pr.Personne{Identite: identite}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = pr.Fichesanitaire{IdPersonne: pe.Id, Allergies: pr.Allergies{Alimentaires: true, Precisions: "arachides"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	camp, err := cps.Camp{IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	dossier, err := ds.Dossier{IdResponsable: pe.Id, IdTaux: 1, CopiesMails: pr.Mails{"other@free.fr"}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Participant{IdCamp: camp.Id, IdPersonne: pe.Id, IdDossier: dossier.Id, IdTaux: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	paiement, err := ds.Paiement{IdDossier: dossier.Id, Payeur: "Kugler"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	insc, err := in.Inscription{IdTaux: 1, Responsable: in.ResponsableLegal{Nom: "Kugler"}, ConfirmedAsDossier: dossier.Id.Opt()}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = in.InscriptionParticipant{IdInscription: insc.Id, IdCamp: camp.Id, IdTaux: 1, Nom: "Kugler", Prenom: "Benoit", DateNaissance: identite.DateNaissance}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	demande, err := fs.Demande{Categorie: fs.Vaccins, MaxDocs: 1}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file, err := fs.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = fs.FilePersonne{IdFile: file.Id, IdPersonne: pe.Id, IdDemande: demande.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	signed, err := fs.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = fs.Signature{IdFile: signed.Id, IdPersonne: pe.Id, Document: fs.CharteParticipant, Signataire: "Benoit Kugler"}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	equipier, err := cps.Equipier{IdCamp: camp.Id, IdPersonne: pe.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	rembourse, err := cps.Depense{IdEquipier: equipier.Id, Description: "Essence Paris", Statut: cps.DepenseRemboursee}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = cps.Depense{IdEquipier: equipier.Id, Description: "Péage"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	justificatif, err := fs.File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = fs.FileDepense{IdFile: justificatif.Id, IdDepense: rembourse.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	data, err := LoadDonneesPersonne(db.DB, pe.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(data.Fichesanitaires) == 1)
+	tu.Assert(t, len(data.Participants) == 1 && len(data.Dossiers) == 1 && len(data.Paiements) == 1)
+	tu.Assert(t, len(data.Files) == 3 && len(data.Signatures) == 1 && len(data.Camps) == 1)
+	tu.Assert(t, len(data.Depenses) == 2 && len(data.Justificatifs) == 1)
+
+	var deleted fs.Files
+	err = utils.InTx(db.DB, func(tx *sql.Tx) error {
+		pe, deleted, err = AnonymisePersonne(tx, pe.Id)
+		return err
+	})
+	tu.AssertNoErr(t, err)
+	_, hasFile := deleted[file.Id]
+	_, hasSigned := deleted[signed.Id]
+	_, hasJustificatif := deleted[justificatif.Id]
+	tu.Assert(t, len(deleted) == 3 && hasFile && hasSigned && hasJustificatif)
+	tu.Assert(t, pe.Nom == nomAnonyme && pe.Prenom == "" && pe.Mail == "")
+
+	_, found, err := pr.SelectFichesanitaireByIdPersonne(db, pe.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !found)
+
+	// comptabilité conservée
+	data, err = LoadDonneesPersonne(db.DB, pe.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(data.Participants) == 1 && len(data.Dossiers) == 1)
+	tu.Assert(t, len(data.Paiements) == 1 && data.Paiements[0].Id == paiement.Id)
+	tu.Assert(t, len(data.Files) == 0 && len(data.Signatures) == 0)
+	tu.Assert(t, len(data.Dossiers[0].CopiesMails) == 0)
+	tu.Assert(t, len(data.Depenses) == 1 && data.Depenses[0].Id == rembourse.Id && data.Depenses[0].Description == "")
+
+	insc, err = in.SelectInscription(db, insc.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, insc.Responsable.Nom == nomAnonyme)
+	inscParts, err := in.SelectInscriptionParticipantsByIdInscriptions(db, insc.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(inscParts) == 1 && inscParts[0].Nom == nomAnonyme)
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"slices"
+
+	pr "registro/sql/personnes"
+)
+
+// Doublon est un groupe de profils probablement identiques.
+type Doublon struct {
+	Personnes    []PersonneHeader // triés par Id
+	ScorePercent int              // score minimal des paires retenues
+}
+
+// les blocs trop grands (typiquement des profils vides)
+// sont ignorés, pour éviter un coût quadratique
+const maxBlockSize = 50
+
+// blockKeys renvoie les clés de regroupement de [p] :
+// on ne compare que les profils partageant au moins une clé.
+func blockKeys(p pr.Identite) (out []string) {
+	nom, prenom := Normalize(p.Nom), Normalize(p.Prenom)
+	hasDate := !p.DateNaissance.Time().IsZero()
+	date := p.DateNaissance.String()
+	if nom != "" && hasDate {
+		out = append(out, "nd:"+nom+"|"+date)
+	}
+	if prenom != "" && hasDate {
+		out = append(out, "pd:"+prenom+"|"+date)
+	}
+	if nom != "" && prenom != "" {
+		out = append(out, "np:"+nom+"|"+prenom)
+	}
+	return out
+}
+
+// scorePaire renvoie le score (en pourcents) de similarité entre [p1] et [p2],
+// en utilisant le plus favorable des deux sens de comparaison.
+func scorePaire(p1, p2 pr.Personne) int {
+	score := func(p, ref pr.Personne) int {
+		pattern := NewPatternsSimilarite(ref.Identite)
+		scoreMax := pattern.scoreMax()
+		if scoreMax == 0 {
+			return 0
+		}
+		return 100 * comparaison(p, pattern) / scoreMax
+	}
+	return max(score(p1, p2), score(p2, p1))
+}
+
+// unionFind regroupe les profils liés
+type unionFind map[pr.IdPersonne]pr.IdPersonne
+
+func (uf unionFind) find(id pr.IdPersonne) pr.IdPersonne {
+	parent, ok := uf[id]
+	if !ok || parent == id {
+		return id
+	}
+	root := uf.find(parent)
+	uf[id] = root
+	return root
+}
+
+func (uf unionFind) union(id1, id2 pr.IdPersonne) {
+	r1, r2 := uf.find(id1), uf.find(id2)
+	if r1 != r2 {
+		uf[max(r1, r2)] = min(r1, r2)
+	}
+}
+
+// ChercheDoublons regroupe les profils de [personnes] probablement identiques,
+// c'est à dire dont le score de similarité atteint [seuil] (en pourcents).
+//
+// Pour limiter le nombre de comparaisons, les profils sont répartis selon
+// trois clés : (nom, date de naissance), (prénom, date de naissance) et
+// (nom, prénom), les noms et prénoms étant normalisés. Deux profils sont
+// comparés dès qu'ils partagent l'une de ces clés. Les groupes de plus de
+// [maxBlockSize] profils sont ignorés. Les paires retenues sont ensuite
+// regroupées de proche en proche.
+func ChercheDoublons(personnes pr.Personnes, seuil int) []Doublon {
+	blocks := make(map[string][]pr.IdPersonne)
+	for id, p := range personnes {
+		for _, key := range blockKeys(p.Identite) {
+			blocks[key] = append(blocks[key], id)
+		}
+	}
+
+	uf := make(unionFind)
+	scores := make(map[pr.IdPersonne]int) // score minimal, par racine provisoire
+	type pair struct{ id1, id2 pr.IdPersonne }
+	compared := make(map[pair]bool)
+	var links []pair
+	for _, block := range blocks {
+		if len(block) < 2 || len(block) > maxBlockSize {
+			continue
+		}
+		slices.Sort(block)
+		for i, id1 := range block {
+			for _, id2 := range block[i+1:] {
+				pa := pair{id1, id2}
+				if compared[pa] {
+					continue
+				}
+				compared[pa] = true
+				if score := scorePaire(personnes[id1], personnes[id2]); score >= seuil {
+					uf.union(id1, id2)
+					links = append(links, pa)
+					scores[id1], scores[id2] = minScore(scores[id1], score), minScore(scores[id2], score)
+				}
+			}
+		}
+	}
+
+	clusters := make(map[pr.IdPersonne]*Doublon)
+	for _, link := range links {
+		for _, id := range [2]pr.IdPersonne{link.id1, link.id2} {
+			root := uf.find(id)
+			cluster := clusters[root]
+			if cluster == nil {
+				cluster = &Doublon{ScorePercent: 100}
+				clusters[root] = cluster
+			}
+			if !slices.ContainsFunc(cluster.Personnes, func(h PersonneHeader) bool { return h.Id == id }) {
+				cluster.Personnes = append(cluster.Personnes, NewPersonneHeader(personnes[id]))
+			}
+			cluster.ScorePercent = min(cluster.ScorePercent, scores[id])
+		}
+	}
+
+	out := make([]Doublon, 0, len(clusters))
+	for _, cluster := range clusters {
+		slices.SortFunc(cluster.Personnes, func(a, b PersonneHeader) int { return int(a.Id - b.Id) })
+		out = append(out, *cluster)
+	}
+	slices.SortFunc(out, func(a, b Doublon) int { return int(a.Personnes[0].Id - b.Personnes[0].Id) })
+	return out
+}
+
+// minScore traite 0 comme "pas encore de score"
+func minScore(current, score int) int {
+	if current == 0 {
+		return score
+	}
+	return min(current, score)
+}
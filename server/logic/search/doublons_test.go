@@ -0,0 +1,42 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	pr "registro/sql/personnes"
+	"registro/sql/shared"
+	tu "registro/utils/testutils"
+)
+
+func TestChercheDoublons(t *testing.T) {
+	date := shared.NewDate(2000, 5, 12)
+	personnes := pr.Personnes{
+		1: {Id: 1, Identite: pr.Identite{Nom: "Kugler", Prenom: "Benoît", DateNaissance: date, Sexe: pr.Man}},
+		2: {Id: 2, Identite: pr.Identite{Nom: "KUGLER", Prenom: "benoit", DateNaissance: date}},
+		3: {Id: 3, Identite: pr.Identite{Nom: "Kugler", Prenom: "Benoit"}},                     // sans date
+		4: {Id: 4, Identite: pr.Identite{Nom: "Kugler", Prenom: "Marie", DateNaissance: date}}, // jumelle
+		5: {Id: 5, Identite: pr.Identite{Nom: "Dupont", Prenom: "Paul", DateNaissance: date}},
+		6: {Id: 6, Identite: pr.Identite{Nom: "Dupont", Prenom: "Paul", DateNaissance: shared.NewDate(1990, 1, 1)}},
+		7: {Id: 7},
+		8: {Id: 8},
+	}
+	out := ChercheDoublons(personnes, 80)
+	tu.Assert(t, len(out) == 1)
+	ids := []pr.IdPersonne{}
+	for _, p := range out[0].Personnes {
+		ids = append(ids, p.Id)
+	}
+	tu.Assert(t, len(ids) == 3 && ids[0] == 1 && ids[1] == 2 && ids[2] == 3)
+	tu.Assert(t, out[0].ScorePercent >= 80)
+
+	out = ChercheDoublons(personnes, 40)
+	tu.Assert(t, len(out) == 2)
+}
+
+func TestChercheDoublonsPerf(t *testing.T) {
+	personnes := loadPersonnes(t)
+	ti := time.Now()
+	_ = ChercheDoublons(personnes, 80)
+	tu.Assert(t, time.Since(ti) < time.Second)
+}
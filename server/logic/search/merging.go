@@ -103,10 +103,10 @@ func cmpEnum[T fieldEnum](in, out T) diff {
 	return conflict
 }
 
-// conflicts indique quels champs n'ont pu être automatiquement fusionnés,
+// Conflicts indique quels champs n'ont pu être automatiquement fusionnés,
 // c'est à dire ceux pour lesquels les deux profils ont une valeur (sensiblement)
 // différente
-type conflicts struct {
+type Conflicts struct {
 	Nom                  bool
 	NomJeuneFille        bool
 	Prenom               bool
@@ -181,7 +181,7 @@ func choose[T fields](entrant, existant T) (T, bool) {
 
 // Merge compare champs par champs les deux personnes et renvoie
 // le résultat de la fusion et un crible d'alerte
-func Merge(entrant pr.Identite, existant pr.Identite) (merged pr.Identite, conflicts conflicts) {
+func Merge(entrant pr.Identite, existant pr.Identite) (merged pr.Identite, conflicts Conflicts) {
 	merged.Nom, conflicts.Nom = choose(entrant.Nom, existant.Nom)
 	merged.Prenom, conflicts.Prenom = choose(entrant.Prenom, existant.Prenom)
 	merged.Sexe, conflicts.Sexe = choose(entrant.Sexe, existant.Sexe)
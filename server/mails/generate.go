@@ -36,6 +36,12 @@ var (
 	sendPhotosLinkEquipiersT    *template.Template
 	notifieMessageDirecteursT   *template.Template
 	notifieMessageFondsSoutienT *template.Template
+	inviteResponsable2T         *template.Template
+	renouvelleDocumentsT        *template.Template
+	recapDocumentsPerimesT      *template.Template
+	refuseDocumentT             *template.Template
+	notifieModificationFicheT   *template.Template
+	refuseCandidatureT          *template.Template
 )
 
 func init() {
@@ -58,6 +64,12 @@ func init() {
 	sendPhotosLinkEquipiersT = parseTemplate("templates/sendPhotosLinkEquipiers.html")
 	notifieMessageDirecteursT = parseTemplate("templates/notifieMessageDirecteurs.html")
 	notifieMessageFondsSoutienT = parseTemplate("templates/notifieMessageFondsSoutien.html")
+	inviteResponsable2T = parseTemplate("templates/inviteResponsable2.html")
+	renouvelleDocumentsT = parseTemplate("templates/renouvelleDocuments.html")
+	recapDocumentsPerimesT = parseTemplate("templates/recapDocumentsPerimes.html")
+	refuseDocumentT = parseTemplate("templates/refuseDocument.html")
+	notifieModificationFicheT = parseTemplate("templates/notifieModificationFiche.html")
+	refuseCandidatureT = parseTemplate("templates/refuseCandidature.html")
 }
 
 func parseTemplate(templateFile string) *template.Template {
@@ -202,6 +214,57 @@ func RelanceDocuments(cfg config.Asso, contact Contact, campLabel, prenom, espac
 	return render(relanceDocumentsT, args)
 }
 
+// RenouvelleDocuments demande le renouvellement des documents périmés.
+// [prenom] est vide pour un équipier, [espaceURL] pointe alors
+// vers l'espace équipier.
+func RenouvelleDocuments(cfg config.Asso, contact Contact, campLabel, prenom string, documents []string, espaceURL string) (string, error) {
+	args := struct {
+		champsCommuns
+		Camp                   string
+		Prenom                 string
+		Documents              []string
+		EspacePersoURL         string
+		EspacePersoButtonLabel string
+	}{
+		champsCommuns: champsCommuns{
+			Title:       "Documents à renouveler",
+			Salutations: contact.Salutations(),
+			Asso:        cfg,
+			Signature:   cfg.MailsSettings.SignatureMailCentre + "<br/><br/>" + mailAuto,
+		},
+		Camp:                   campLabel,
+		Prenom:                 prenom,
+		Documents:              documents,
+		EspacePersoURL:         espaceURL,
+		EspacePersoButtonLabel: "RENOUVELER LES DOCUMENTS",
+	}
+	return render(renouvelleDocumentsT, args)
+}
+
+// RecapDocumentsPerimes résume, pour le directeur, les documents
+// périmés dont le renouvellement a été demandé.
+func RecapDocumentsPerimes(cfg config.Asso, contact Contact, campLabel string, documents []string, directeursURL string) (string, error) {
+	args := struct {
+		champsCommuns
+		Camp                   string
+		Documents              []string
+		EspacePersoURL         string
+		EspacePersoButtonLabel string
+	}{
+		champsCommuns: champsCommuns{
+			Title:       "Documents périmés",
+			Salutations: contact.Salutations(),
+			Asso:        cfg,
+			Signature:   mailAuto,
+		},
+		Camp:                   campLabel,
+		Documents:              documents,
+		EspacePersoURL:         directeursURL,
+		EspacePersoButtonLabel: "Espace Directeur",
+	}
+	return render(recapDocumentsPerimesT, args)
+}
+
 func NotifieSondage(asso config.Asso, contact Contact, campLabel string, lienEspacePerso string) (string, error) {
 	args := struct {
 		champsCommuns
@@ -363,6 +426,37 @@ func NotifieModificationOptions(cfg config.Asso, directeur pr.Identite, camp str
 	return render(notifieModificationOptionsT, args)
 }
 
+// NotifieModificationFiche prévient le directeur d'une modification
+// tardive de la fiche sanitaire d'un inscrit.
+// Seules les rubriques modifiées sont mentionnées : le détail
+// est consultable sur l'espace Directeur.
+func NotifieModificationFiche(cfg config.Asso, directeur pr.Identite, camp string, participant string, rubriques []string, urlDirecteur string) (string, error) {
+	s := "Cher"
+	if directeur.Sexe == pr.Woman {
+		s = "Chère"
+	}
+
+	args := struct {
+		champsCommuns
+		Camp        string
+		Participant string
+		Rubriques   []string
+		URL         string
+	}{
+		champsCommuns{
+			Title:       "Modification d'une fiche sanitaire",
+			Salutations: fmt.Sprintf("%s %s,", s, directeur.FPrenom()),
+			Asso:        cfg,
+			Signature:   mailAutoSignature,
+		},
+		camp,
+		participant,
+		rubriques,
+		urlDirecteur,
+	}
+	return render(notifieModificationFicheT, args)
+}
+
 // organisme est vide pour les dons particulier
 func NotifieDon(cfg config.Asso, contact Contact, montant dossiers.Montant) (string, error) {
 	args := struct {
@@ -540,6 +634,30 @@ func NotifieMessageFondsSoutien(cfg config.Asso, responsable, contenu, backoffic
 	return render(notifieMessageFondsSoutienT, args)
 }
 
+// InviteResponsable2 envoie au second responsable légal son lien
+// vers l'espace de suivi du dossier de [responsable].
+func InviteResponsable2(cfg config.Asso, contact Contact, responsable string, lectureSeule bool, espacePersoURL string) (string, error) {
+	args := struct {
+		champsCommuns
+		Responsable            string
+		LectureSeule           bool
+		EspacePersoURL         string
+		EspacePersoButtonLabel string
+	}{
+		champsCommuns: champsCommuns{
+			Title:       "Accès à l'espace de suivi",
+			Salutations: contact.Salutations(),
+			Asso:        cfg,
+			Signature:   cfg.MailsSettings.SignatureMailCentre + "<br/><br/>" + mailAuto,
+		},
+		Responsable:            responsable,
+		LectureSeule:           lectureSeule,
+		EspacePersoURL:         espacePersoURL,
+		EspacePersoButtonLabel: "MON ESPACE",
+	}
+	return render(inviteResponsable2T, args)
+}
+
 // func NewRenvoieLienJoomeo(lien, login, password string) (string, error) {
 // 	commun := newChampCommuns(Contact{}, "Espace photo")
 // 	commun.SignatureMail = "<i>Ps : Ceci est un mail automatique, merci de ne pas y répondre.</i>"
@@ -655,3 +773,53 @@ func NotifieMessageFondsSoutien(cfg config.Asso, responsable, contenu, backoffic
 // 		SignatureMail: rd.SignatureMail,
 // 	}
 // }
+
+// RefuseDocument informe l'expéditeur du refus d'un document.
+// [prenom] est vide pour un équipier, [espaceURL] pointe alors
+// vers l'espace équipier.
+func RefuseDocument(cfg config.Asso, contact Contact, campLabel, prenom, document, motif, espaceURL string) (string, error) {
+	args := struct {
+		champsCommuns
+		Camp                   string
+		Prenom                 string
+		Document               string
+		Motif                  string
+		EspacePersoURL         string
+		EspacePersoButtonLabel string
+	}{
+		champsCommuns: champsCommuns{
+			Title:       "Document refusé",
+			Salutations: contact.Salutations(),
+			Asso:        cfg,
+			Signature:   cfg.MailsSettings.SignatureMailCentre + "<br/><br/>" + mailAuto,
+		},
+		Camp:                   campLabel,
+		Prenom:                 prenom,
+		Document:               document,
+		Motif:                  motif,
+		EspacePersoURL:         espaceURL,
+		EspacePersoButtonLabel: "ENVOYER UN NOUVEAU DOCUMENT",
+	}
+	return render(refuseDocumentT, args)
+}
+
+// RefuseCandidature informe un candidat que sa candidature pour rejoindre
+// l'équipe du séjour [campLabel] n'a pas été retenue.
+// [message] est optionnel.
+func RefuseCandidature(cfg config.Asso, contact Contact, campLabel, message string) (string, error) {
+	args := struct {
+		champsCommuns
+		Camp    string
+		Message string
+	}{
+		champsCommuns: champsCommuns{
+			Title:       "Candidature équipier",
+			Salutations: contact.Salutations(),
+			Asso:        cfg,
+			Signature:   cfg.MailsSettings.SignatureMailCentre + "<br/><br/>" + mailAuto,
+		},
+		Camp:    campLabel,
+		Message: message,
+	}
+	return render(refuseCandidatureT, args)
+}
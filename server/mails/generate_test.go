@@ -2,6 +2,7 @@ package mails
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -96,6 +97,17 @@ func TestNotifieModificationOptions(t *testing.T) {
 	tu.Write(t, "NotifieModificationOptions_2.html", []byte(html))
 }
 
+func TestNotifieModificationFiche(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := NotifieModificationFiche(cfg,
+		pr.Identite{Prenom: "Cl audie", Sexe: pr.Woman}, fmt.Sprintf("C3 - %d", time.Now().Year()), "Vincent",
+		[]string{"Allergies", "Traitements"}, "http://test.fr")
+	tu.AssertNoErr(t, err)
+
+	tu.Write(t, "NotifieModificationFiche.html", []byte(html))
+}
+
 func TestNotificationDon(t *testing.T) {
 	cfg, _ := loadEnv(t)
 
@@ -218,6 +230,47 @@ func TestRelanceDocuments(t *testing.T) {
 	tu.Write(t, "RelanceDocuments.html", []byte(html))
 }
 
+func TestRenouvelleDocuments(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := RenouvelleDocuments(cfg, Contact{Prenom: "Benoit", Sexe: pr.Man}, "Vive la vie - 2056", "Julie",
+		[]string{"Vaccins (valide jusqu'au 12/06/2056)", "Test d'aisance aquatique"}, "http://localhost/test")
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "RenouvelleDocuments.html", []byte(html))
+
+	html, err = RenouvelleDocuments(cfg, Contact{Prenom: "Marie", Sexe: pr.Woman}, "Vive la vie - 2056", "",
+		[]string{"Carte d'identité"}, "http://localhost/test")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, strings.Contains(html, "espace équipier"))
+}
+
+func TestRecapDocumentsPerimes(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := RecapDocumentsPerimes(cfg, Contact{Prenom: "Benoit", Sexe: pr.Man}, "Vive la vie - 2056",
+		[]string{"Julie DUPONT : Vaccins (valide jusqu'au 12/06/2056)"}, "http://localhost/directeurs")
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "RecapDocumentsPerimes.html", []byte(html))
+}
+
+func TestRefuseDocument(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := RefuseDocument(cfg, Contact{Prenom: "Benoit", Sexe: pr.Man}, "Vive la vie - 2056", "Julie",
+		"Vaccins", "Le document est illisible.", "http://localhost/test")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, strings.Contains(html, "Le document est illisible."))
+	tu.Write(t, "RefuseDocument.html", []byte(html))
+}
+
+func TestInviteResponsable2(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := InviteResponsable2(cfg, Contact{Prenom: "Marie", Sexe: pr.Woman}, "Benoit KUGLER", true, "http://localhost/test")
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "InviteResponsable2.html", []byte(html))
+}
+
 func TestRenvoieEspacePersoURL(t *testing.T) {
 	cfg, _ := loadEnv(t)
 	html, err := RenvoieEspacePersoURL(cfg, "smsld@free.fr", []ResumeDossier{
@@ -393,3 +446,11 @@ func TestNotifieMessageFondsSoutien(t *testing.T) {
 // 		t.Fatal(err)
 // 	}
 // }
+
+func TestRefuseCandidature(t *testing.T) {
+	cfg, _ := loadEnv(t)
+
+	html, err := RefuseCandidature(cfg, Contact{Prenom: "Benoit", Sexe: pr.Man}, "C3 - 2025", "L'équipe est déjà complète.")
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "RefuseCandidature.html", []byte(html))
+}
@@ -25,6 +25,7 @@ import (
 	"registro/recufiscal"
 	cp "registro/sql/camps"
 	"registro/sql/files"
+	"registro/utils"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -63,7 +64,28 @@ func main() {
 	check(db.Ping())
 	fmt.Println("Connecting: OK.")
 
-	fs := files.NewFileSystem(directories.Files)
+	var storage files.Storage = files.NewLocalStorage(directories.Files)
+	s3, err := config.NewS3()
+	check(err)
+	if s3.Endpoint != "" {
+		storage, err = files.NewS3Storage(s3)
+		check(err)
+		fmt.Println("Using S3 storage:", s3.Endpoint, s3.Bucket)
+	}
+	encryption, err := config.NewFilesEncryption()
+	check(err)
+	if encryption.MasterKey != "" {
+		storage = files.NewEncryptedStorage(storage, encryption)
+		fmt.Println("Encrypting files at rest.")
+	}
+	fs := files.NewFileSystemFrom(storage)
+	if socket := config.NewClamdSocket(); socket != "" {
+		fs = fs.WithScanner(files.NewClamdScanner(socket))
+		fmt.Println("Scanning uploaded files with clamd:", socket)
+	}
+	maxDocumentSize, err := config.NewMaxDocumentSize()
+	check(err)
+	fs = fs.WithMaxDocumentSize(maxDocumentSize)
 
 	e := echo.New()
 	e.HideBanner = true
@@ -82,7 +104,7 @@ func main() {
 	directeursCt, err := directeurs.NewController(db, keys.EncryptKey, keys.Directeurs, fs, smtp, asso, immich)
 	check(err)
 
-	equipiersCt := equipiers.NewController(db, asso.ID, encrypter, fs, immich)
+	equipiersCt := equipiers.NewController(db, asso, encrypter, fs, immich)
 
 	espacepersoCt := espaceperso.NewController(db, encrypter, smtp, asso, fs, immich)
 
@@ -117,6 +139,13 @@ func main() {
 		}
 	}
 
+	// nettoyage nocturne des données sensibles
+	go utils.RunDaily(3, backofficeCt.PurgeDonneesExpirees)
+	go utils.RunDaily(4, backofficeCt.RechercheDoublons)
+	// renouvellement des documents périmés
+	publicHost := config.NewPublicHost()
+	go utils.RunDaily(6, func() { backofficeCt.RelanceDocumentsPerimes(publicHost) })
+
 	setupRoutesBackoffice(e, backofficeCt)
 	setupRoutesDirecteurs(e, directeursCt)
 	setupRoutesDons(e, donsCt)
@@ -39,6 +39,15 @@ CREATE TYPE PresenceOffsets AS (
     Fin integer
 );
 
+CREATE TABLE certifications (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1, 2, 3, 4, 5, 6, 7)) NOT NULL,
+    Label text NOT NULL,
+    Obtention date NOT NULL,
+    Expiration date NOT NULL
+);
+
 CREATE TABLE ficheequipiers (
     IdPersonne integer NOT NULL,
     SecuriteSociale text NOT NULL,
@@ -63,8 +72,11 @@ CREATE TABLE ficheequipiers (
 CREATE TABLE fichesanitaires (
     IdPersonne integer NOT NULL,
     DifficultesSante text NOT NULL,
-    AllergiesAlimentaires text NOT NULL,
-    TraitementMedical text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
     Medecin jsonb NOT NULL,
     AutreContact jsonb NOT NULL,
     Modified timestamp(0) with time zone NOT NULL,
@@ -72,6 +84,27 @@ CREATE TABLE fichesanitaires (
     guard boolean NOT NULL
 );
 
+CREATE TABLE fichesanitaireversions (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    DifficultesSante text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
+    Medecin jsonb NOT NULL,
+    AutreContact jsonb NOT NULL,
+    Modified timestamp(0) with time zone NOT NULL,
+    Auteur text NOT NULL
+);
+
+CREATE TABLE identifications (
+    Id serial PRIMARY KEY,
+    Moment timestamp(0) with time zone NOT NULL,
+    Record bytea NOT NULL
+);
+
 CREATE TABLE personnes (
     Id serial PRIMARY KEY,
     Nom text NOT NULL,
@@ -94,6 +127,8 @@ CREATE TABLE dossiers (
     Id serial PRIMARY KEY,
     IdTaux integer NOT NULL,
     IdResponsable integer NOT NULL,
+    IdResponsable2 integer,
+    AccesResponsable2 smallint CHECK (AccesResponsable2 IN (0, 1)) NOT NULL,
     CopiesMails text[],
     PartageAdressesOK boolean NOT NULL,
     DemandeFondSoutien boolean NOT NULL,
@@ -154,11 +189,40 @@ CREATE TABLE camps (
     Password text NOT NULL,
     DocumentsReady boolean NOT NULL,
     DocumentsToShow DocumentsToShow NOT NULL,
+    ConformiteValidee boolean NOT NULL,
+    ConformiteEmpreinte text NOT NULL,
     Vetements jsonb NOT NULL,
     AlbumID text NOT NULL,
     Meta jsonb NOT NULL
 );
 
+CREATE TABLE candidatures (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Role smallint CHECK (Role IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)) NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Motivation text NOT NULL,
+    Diplome smallint CHECK (Diplome IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19)) NOT NULL,
+    Approfondissement smallint CHECK (Approfondissement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    Formation text NOT NULL,
+    Profession text NOT NULL,
+    ExperienceTravailJeunes text NOT NULL
+);
+
+CREATE TABLE depenses (
+    Id serial PRIMARY KEY,
+    IdEquipier integer NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4)) NOT NULL,
+    Montant Montant NOT NULL,
+    Date date NOT NULL,
+    Description text NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3, 4)) NOT NULL,
+    Commentaire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL
+);
+
 CREATE TABLE equipiers (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -227,6 +291,19 @@ CREATE TABLE projet_spis (
     Question text NOT NULL
 );
 
+CREATE TABLE soins (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Creneau smallint CHECK (Creneau IN (0, 1, 2, 3)) NOT NULL,
+    Medicament text NOT NULL,
+    Dose text NOT NULL,
+    Description text NOT NULL,
+    Auteur text NOT NULL
+);
+
 CREATE TABLE sondages (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -256,7 +333,7 @@ CREATE TABLE demandes (
     Id serial PRIMARY KEY,
     IdFile integer,
     IdDirecteur integer,
-    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)) NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16)) NOT NULL,
     Description text NOT NULL,
     MaxDocs integer NOT NULL,
     JoursValide integer NOT NULL
@@ -277,7 +354,11 @@ CREATE TABLE files (
     Id serial PRIMARY KEY,
     Taille integer NOT NULL,
     NomClient text NOT NULL,
-    Uploaded timestamp(0) with time zone NOT NULL
+    Uploaded timestamp(0) with time zone NOT NULL,
+    Perime boolean NOT NULL,
+    Revue smallint CHECK (Revue IN (0, 1, 2)) NOT NULL,
+    RevueCommentaire text NOT NULL,
+    Empreinte text NOT NULL
 );
 
 CREATE TABLE file_aides (
@@ -285,12 +366,22 @@ CREATE TABLE file_aides (
     IdAide integer NOT NULL
 );
 
+CREATE TABLE file_certifications (
+    IdFile integer NOT NULL,
+    IdCertification integer NOT NULL
+);
+
 CREATE TABLE file_camps (
     IdFile integer NOT NULL,
     IdCamp integer NOT NULL,
     IsLettre boolean NOT NULL
 );
 
+CREATE TABLE file_depenses (
+    IdFile integer NOT NULL,
+    IdDepense integer NOT NULL
+);
+
 CREATE TABLE file_personnes (
     IdFile integer NOT NULL,
     IdPersonne integer NOT NULL,
@@ -298,6 +389,17 @@ CREATE TABLE file_personnes (
     guard boolean NOT NULL
 );
 
+CREATE TABLE signatures (
+    Id serial PRIMARY KEY,
+    IdFile integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Document smallint CHECK (Document IN (0, 1, 2, 3)) NOT NULL,
+    Signataire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    IP text NOT NULL,
+    Empreinte text NOT NULL
+);
+
 CREATE TABLE inscriptions (
     Id serial PRIMARY KEY,
     IdTaux integer NOT NULL,
@@ -350,6 +452,7 @@ CREATE TABLE event_messages (
     VuEspaceperso boolean NOT NULL,
     VuFondSoutien boolean NOT NULL,
     OnlyToFondSoutien boolean NOT NULL,
+    OrigineResponsable2 boolean NOT NULL,
     guard smallint NOT NULL
 );
 
@@ -384,7 +487,7 @@ CREATE TABLE dons (
     IdPersonne integer,
     IdOrganisme integer,
     Montant Montant NOT NULL,
-    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5, 6)) NOT NULL,
     Date date NOT NULL,
     Affectation text NOT NULL,
     Details text NOT NULL,
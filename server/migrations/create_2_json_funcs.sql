@@ -1,5 +1,93 @@
 -- generated by make_sql.go DO NOT EDIT.
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Traitement (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Vaccination (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_boolean (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'boolean';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a boolean', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Allergies (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Asthme', 'Alimentaires', 'Medicamenteuses', 'Autres', 'Aliments', 'Precisions', 'ConduiteATenir'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_boolean (data -> 'Asthme')
+        AND gomacro_validate_json_boolean (data -> 'Alimentaires')
+        AND gomacro_validate_json_boolean (data -> 'Medicamenteuses')
+        AND gomacro_validate_json_string (data -> 'Autres')
+        AND gomacro_validate_json_string (data -> 'Aliments')
+        AND gomacro_validate_json_string (data -> 'Precisions')
+        AND gomacro_validate_json_string (data -> 'ConduiteATenir');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_NomTel (data jsonb)
     RETURNS boolean
     AS $$
@@ -46,6 +134,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Medicament', 'Dose', 'Horaire', 'Duree'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Medicament')
+        AND gomacro_validate_json_string (data -> 'Dose')
+        AND gomacro_validate_json_string (data -> 'Horaire')
+        AND gomacro_validate_json_string (data -> 'Duree');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Vaccin', 'Date'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Vaccin')
+        AND gomacro_validate_json_string (data -> 'Date');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
     RETURNS boolean
     AS $$
@@ -18,6 +18,9 @@ ALTER TABLE fichesanitaires
 ALTER TABLE fichesanitaires
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE fichesanitaireversions
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE ficheequipiers
     ADD UNIQUE (IdPersonne);
 
@@ -33,12 +36,39 @@ ALTER TABLE ficheequipiers
 ALTER TABLE ficheequipiers
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE certifications
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
 
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
 
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
 ALTER TABLE ficheequipiers
     ADD CONSTRAINT Recommandation_gomacro CHECK (gomacro_validate_json_pers_Recommandation (Recommandation));
 
@@ -57,6 +87,9 @@ ALTER TABLE dossiers
 ALTER TABLE dossiers
     ADD FOREIGN KEY (IdResponsable) REFERENCES personnes;
 
+ALTER TABLE dossiers
+    ADD FOREIGN KEY (IdResponsable2) REFERENCES personnes ON DELETE SET NULL;
+
 ALTER TABLE paiements
     ADD FOREIGN KEY (IdDossier) REFERENCES dossiers ON DELETE CASCADE;
 
@@ -147,6 +180,12 @@ ALTER TABLE aides
 ALTER TABLE aides
     ADD FOREIGN KEY (IdParticipant) REFERENCES participants ON DELETE CASCADE;
 
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps;
+
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE equipiers
     ADD UNIQUE (IdCamp, IdPersonne);
 
@@ -162,6 +201,18 @@ ALTER TABLE equipiers
 ALTER TABLE equipiers
     ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
 
+ALTER TABLE candidatures
+    ADD UNIQUE (IdCamp, IdPersonne);
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps ON DELETE CASCADE;
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE depenses
+    ADD FOREIGN KEY (IdEquipier) REFERENCES equipiers ON DELETE CASCADE;
+
 ALTER TABLE camps
     ADD CONSTRAINT Vetements_gomacro CHECK (gomacro_validate_json_camp_ListeVetements (Vetements));
 
@@ -180,6 +231,8 @@ ALTER TABLE participants
 ALTER TABLE camps
     ADD CONSTRAINT Meta_gomacro CHECK (gomacro_validate_json_map_string (Meta));
 
+CREATE INDEX ON files (Empreinte);
+
 ALTER TABLE demandes
     ADD CONSTRAINT constraint_categorie CHECK (Categorie = 0 OR IdDirecteur IS NULL);
 
@@ -260,6 +313,36 @@ ALTER TABLE file_aides
 ALTER TABLE file_aides
     ADD FOREIGN KEY (IdAide) REFERENCES aides;
 
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdCertification);
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdCertification) REFERENCES certifications ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdDepense) REFERENCES depenses ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE inscriptions
     ADD UNIQUE (Id, IdTaux);
 
@@ -330,6 +413,11 @@ ALTER TABLE event_messages
     /* Acteur.Directeur */
         OR OrigineCamp IS NULL);
 
+ALTER TABLE event_messages
+    ADD CHECK (Origine = 0
+    /* Acteur.Espaceperso */
+        OR OrigineResponsable2 = FALSE);
+
 ALTER TABLE event_messages
     ADD FOREIGN KEY (IdEvent) REFERENCES events ON DELETE CASCADE;
 
@@ -39,6 +39,15 @@ CREATE TYPE PresenceOffsets AS (
     Fin integer
 );
 
+CREATE TABLE certifications (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1, 2, 3, 4, 5, 6, 7)) NOT NULL,
+    Label text NOT NULL,
+    Obtention date NOT NULL,
+    Expiration date NOT NULL
+);
+
 CREATE TABLE ficheequipiers (
     IdPersonne integer NOT NULL,
     SecuriteSociale text NOT NULL,
@@ -63,8 +72,11 @@ CREATE TABLE ficheequipiers (
 CREATE TABLE fichesanitaires (
     IdPersonne integer NOT NULL,
     DifficultesSante text NOT NULL,
-    AllergiesAlimentaires text NOT NULL,
-    TraitementMedical text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
     Medecin jsonb NOT NULL,
     AutreContact jsonb NOT NULL,
     Modified timestamp(0) with time zone NOT NULL,
@@ -72,6 +84,27 @@ CREATE TABLE fichesanitaires (
     guard boolean NOT NULL
 );
 
+CREATE TABLE fichesanitaireversions (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    DifficultesSante text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
+    Medecin jsonb NOT NULL,
+    AutreContact jsonb NOT NULL,
+    Modified timestamp(0) with time zone NOT NULL,
+    Auteur text NOT NULL
+);
+
+CREATE TABLE identifications (
+    Id serial PRIMARY KEY,
+    Moment timestamp(0) with time zone NOT NULL,
+    Record bytea NOT NULL
+);
+
 CREATE TABLE personnes (
     Id serial PRIMARY KEY,
     Nom text NOT NULL,
@@ -94,6 +127,8 @@ CREATE TABLE dossiers (
     Id serial PRIMARY KEY,
     IdTaux integer NOT NULL,
     IdResponsable integer NOT NULL,
+    IdResponsable2 integer,
+    AccesResponsable2 smallint CHECK (AccesResponsable2 IN (0, 1)) NOT NULL,
     CopiesMails text[],
     PartageAdressesOK boolean NOT NULL,
     DemandeFondSoutien boolean NOT NULL,
@@ -154,11 +189,40 @@ CREATE TABLE camps (
     Password text NOT NULL,
     DocumentsReady boolean NOT NULL,
     DocumentsToShow DocumentsToShow NOT NULL,
+    ConformiteValidee boolean NOT NULL,
+    ConformiteEmpreinte text NOT NULL,
     Vetements jsonb NOT NULL,
     AlbumID text NOT NULL,
     Meta jsonb NOT NULL
 );
 
+CREATE TABLE candidatures (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Role smallint CHECK (Role IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)) NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Motivation text NOT NULL,
+    Diplome smallint CHECK (Diplome IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19)) NOT NULL,
+    Approfondissement smallint CHECK (Approfondissement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    Formation text NOT NULL,
+    Profession text NOT NULL,
+    ExperienceTravailJeunes text NOT NULL
+);
+
+CREATE TABLE depenses (
+    Id serial PRIMARY KEY,
+    IdEquipier integer NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4)) NOT NULL,
+    Montant Montant NOT NULL,
+    Date date NOT NULL,
+    Description text NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3, 4)) NOT NULL,
+    Commentaire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL
+);
+
 CREATE TABLE equipiers (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -227,6 +291,19 @@ CREATE TABLE projet_spis (
     Question text NOT NULL
 );
 
+CREATE TABLE soins (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Creneau smallint CHECK (Creneau IN (0, 1, 2, 3)) NOT NULL,
+    Medicament text NOT NULL,
+    Dose text NOT NULL,
+    Description text NOT NULL,
+    Auteur text NOT NULL
+);
+
 CREATE TABLE sondages (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -256,7 +333,7 @@ CREATE TABLE demandes (
     Id serial PRIMARY KEY,
     IdFile integer,
     IdDirecteur integer,
-    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)) NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16)) NOT NULL,
     Description text NOT NULL,
     MaxDocs integer NOT NULL,
     JoursValide integer NOT NULL
@@ -277,7 +354,11 @@ CREATE TABLE files (
     Id serial PRIMARY KEY,
     Taille integer NOT NULL,
     NomClient text NOT NULL,
-    Uploaded timestamp(0) with time zone NOT NULL
+    Uploaded timestamp(0) with time zone NOT NULL,
+    Perime boolean NOT NULL,
+    Revue smallint CHECK (Revue IN (0, 1, 2)) NOT NULL,
+    RevueCommentaire text NOT NULL,
+    Empreinte text NOT NULL
 );
 
 CREATE TABLE file_aides (
@@ -285,12 +366,22 @@ CREATE TABLE file_aides (
     IdAide integer NOT NULL
 );
 
+CREATE TABLE file_certifications (
+    IdFile integer NOT NULL,
+    IdCertification integer NOT NULL
+);
+
 CREATE TABLE file_camps (
     IdFile integer NOT NULL,
     IdCamp integer NOT NULL,
     IsLettre boolean NOT NULL
 );
 
+CREATE TABLE file_depenses (
+    IdFile integer NOT NULL,
+    IdDepense integer NOT NULL
+);
+
 CREATE TABLE file_personnes (
     IdFile integer NOT NULL,
     IdPersonne integer NOT NULL,
@@ -298,6 +389,17 @@ CREATE TABLE file_personnes (
     guard boolean NOT NULL
 );
 
+CREATE TABLE signatures (
+    Id serial PRIMARY KEY,
+    IdFile integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Document smallint CHECK (Document IN (0, 1, 2, 3)) NOT NULL,
+    Signataire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    IP text NOT NULL,
+    Empreinte text NOT NULL
+);
+
 CREATE TABLE inscriptions (
     Id serial PRIMARY KEY,
     IdTaux integer NOT NULL,
@@ -350,6 +452,7 @@ CREATE TABLE event_messages (
     VuEspaceperso boolean NOT NULL,
     VuFondSoutien boolean NOT NULL,
     OnlyToFondSoutien boolean NOT NULL,
+    OrigineResponsable2 boolean NOT NULL,
     guard smallint NOT NULL
 );
 
@@ -384,7 +487,7 @@ CREATE TABLE dons (
     IdPersonne integer,
     IdOrganisme integer,
     Montant Montant NOT NULL,
-    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5, 6)) NOT NULL,
     Date date NOT NULL,
     Affectation text NOT NULL,
     Details text NOT NULL,
@@ -403,6 +506,94 @@ CREATE TABLE organismes (
 
 -- generated by make_sql.go DO NOT EDIT.
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Traitement (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Vaccination (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_boolean (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'boolean';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a boolean', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Allergies (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Asthme', 'Alimentaires', 'Medicamenteuses', 'Autres', 'Aliments', 'Precisions', 'ConduiteATenir'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_boolean (data -> 'Asthme')
+        AND gomacro_validate_json_boolean (data -> 'Alimentaires')
+        AND gomacro_validate_json_boolean (data -> 'Medicamenteuses')
+        AND gomacro_validate_json_string (data -> 'Autres')
+        AND gomacro_validate_json_string (data -> 'Aliments')
+        AND gomacro_validate_json_string (data -> 'Precisions')
+        AND gomacro_validate_json_string (data -> 'ConduiteATenir');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_NomTel (data jsonb)
     RETURNS boolean
     AS $$
@@ -449,6 +640,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Medicament', 'Dose', 'Horaire', 'Duree'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Medicament')
+        AND gomacro_validate_json_string (data -> 'Dose')
+        AND gomacro_validate_json_string (data -> 'Horaire')
+        AND gomacro_validate_json_string (data -> 'Duree');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Vaccin', 'Date'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Vaccin')
+        AND gomacro_validate_json_string (data -> 'Date');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
     RETURNS boolean
     AS $$
@@ -914,6 +1151,9 @@ ALTER TABLE fichesanitaires
 ALTER TABLE fichesanitaires
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE fichesanitaireversions
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE ficheequipiers
     ADD UNIQUE (IdPersonne);
 
@@ -929,12 +1169,39 @@ ALTER TABLE ficheequipiers
 ALTER TABLE ficheequipiers
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE certifications
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
 
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
 
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
 ALTER TABLE ficheequipiers
     ADD CONSTRAINT Recommandation_gomacro CHECK (gomacro_validate_json_pers_Recommandation (Recommandation));
 
@@ -953,6 +1220,9 @@ ALTER TABLE dossiers
 ALTER TABLE dossiers
     ADD FOREIGN KEY (IdResponsable) REFERENCES personnes;
 
+ALTER TABLE dossiers
+    ADD FOREIGN KEY (IdResponsable2) REFERENCES personnes ON DELETE SET NULL;
+
 ALTER TABLE paiements
     ADD FOREIGN KEY (IdDossier) REFERENCES dossiers ON DELETE CASCADE;
 
@@ -1043,6 +1313,12 @@ ALTER TABLE aides
 ALTER TABLE aides
     ADD FOREIGN KEY (IdParticipant) REFERENCES participants ON DELETE CASCADE;
 
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps;
+
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE equipiers
     ADD UNIQUE (IdCamp, IdPersonne);
 
@@ -1058,6 +1334,18 @@ ALTER TABLE equipiers
 ALTER TABLE equipiers
     ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
 
+ALTER TABLE candidatures
+    ADD UNIQUE (IdCamp, IdPersonne);
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps ON DELETE CASCADE;
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE depenses
+    ADD FOREIGN KEY (IdEquipier) REFERENCES equipiers ON DELETE CASCADE;
+
 ALTER TABLE camps
     ADD CONSTRAINT Vetements_gomacro CHECK (gomacro_validate_json_camp_ListeVetements (Vetements));
 
@@ -1076,6 +1364,8 @@ ALTER TABLE participants
 ALTER TABLE camps
     ADD CONSTRAINT Meta_gomacro CHECK (gomacro_validate_json_map_string (Meta));
 
+CREATE INDEX ON files (Empreinte);
+
 ALTER TABLE demandes
     ADD CONSTRAINT constraint_categorie CHECK (Categorie = 0 OR IdDirecteur IS NULL);
 
@@ -1156,6 +1446,36 @@ ALTER TABLE file_aides
 ALTER TABLE file_aides
     ADD FOREIGN KEY (IdAide) REFERENCES aides;
 
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdCertification);
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdCertification) REFERENCES certifications ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdDepense) REFERENCES depenses ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE inscriptions
     ADD UNIQUE (Id, IdTaux);
 
@@ -1226,6 +1546,11 @@ ALTER TABLE event_messages
     /* Acteur.Directeur */
         OR OrigineCamp IS NULL);
 
+ALTER TABLE event_messages
+    ADD CHECK (Origine = 0
+    /* Acteur.Espaceperso */
+        OR OrigineResponsable2 = FALSE);
+
 ALTER TABLE event_messages
     ADD FOREIGN KEY (IdEvent) REFERENCES events ON DELETE CASCADE;
 
@@ -1396,7 +1721,9 @@ INSERT INTO demandes (Categorie, Description, MaxDocs, JoursValide)
         --
         (14, '', 1, 0),
         --
-        (15, '', 1, 0);
+        (15, '', 1, 0),
+        --
+        (16, 'Document établi avec le médecin pour un enfant atteint d''une maladie chronique ou d''une allergie.', 1, 0);
 
 SELECT
     setval('demandes_id_seq', (
@@ -0,0 +1,95 @@
+// Script de migration des fichiers enregistrés avant la déduplication
+// (voir la migration 017_empreinte_files.sql).
+//
+// Utilisation : go run migrations/empreintes/main.go
+//
+// La base de données est définie par les variables DB_*, et le stockage
+// est celui utilisé par le serveur (S3 si FILES_S3_ENDPOINT est défini,
+// FILES_DIR sinon, chiffré si FILES_MASTER_KEY est défini).
+// L'empreinte de chaque fichier est calculée et son contenu est déplacé
+// vers le stockage par empreinte. Le script peut être relancé sans risque,
+// y compris pendant le fonctionnement du serveur.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"registro/config"
+	"registro/sql/files"
+
+	_ "github.com/lib/pq"
+)
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	dbCreds, err := config.NewDB()
+	check(err)
+	db, err := dbCreds.ConnectPostgres()
+	check(err)
+	defer db.Close()
+	check(db.Ping())
+
+	storage, err := newStorage()
+	check(err)
+	fs := files.NewFileSystemFrom(storage)
+
+	all, err := files.SelectAllFiles(db)
+	check(err)
+	var legacy []files.IdFile
+	for _, file := range all {
+		if file.Empreinte == "" {
+			legacy = append(legacy, file.Id)
+		}
+	}
+	fmt.Printf("Migrating %d files...\n", len(legacy))
+	updated := 0
+	for i, id := range legacy {
+		migrated, err := fs.MigreEmpreinte(db, id)
+		if err != nil {
+			log.Fatalf("file %d: %s", id, err)
+		}
+		if migrated {
+			updated++
+		}
+		if (i+1)%100 == 0 {
+			fmt.Printf("%d/%d\n", i+1, len(legacy))
+		}
+	}
+	fmt.Printf("Done (%d files updated).\n", updated)
+}
+
+func newStorage() (files.Storage, error) {
+	var storage files.Storage
+	s3, err := config.NewS3()
+	if err != nil {
+		return nil, err
+	}
+	if s3.Endpoint != "" {
+		storage, err = files.NewS3Storage(s3)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		dir := os.Getenv("FILES_DIR")
+		if dir == "" {
+			return nil, errors.New("missing env FILES_DIR")
+		}
+		storage = files.NewLocalStorage(dir)
+	}
+	encryption, err := config.NewFilesEncryption()
+	if err != nil {
+		return nil, err
+	}
+	if encryption.MasterKey != "" {
+		storage = files.NewEncryptedStorage(storage, encryption)
+	}
+	return storage, nil
+}
@@ -0,0 +1,67 @@
+// Script de chiffrement des fichiers existants.
+//
+// Utilisation : go run migrations/encryptfiles/main.go
+//
+// Le stockage est celui utilisé par le serveur (S3 si FILES_S3_ENDPOINT
+// est défini, FILES_DIR sinon), et FILES_MASTER_KEY doit être défini.
+// Les fichiers en clair sont chiffrés ; les fichiers chiffrés avec une
+// clé de FILES_PREVIOUS_KEYS voient leur clé de données chiffrée à nouveau
+// avec FILES_MASTER_KEY (rotation). Le script peut être relancé sans risque.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"registro/config"
+	"registro/sql/files"
+)
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	storage, err := newStorage()
+	check(err)
+	encryption, err := config.NewFilesEncryption()
+	check(err)
+	if encryption.MasterKey == "" {
+		log.Fatal("missing env FILES_MASTER_KEY")
+	}
+	encrypted := files.NewEncryptedStorage(storage, encryption)
+
+	keys, err := storage.Keys()
+	check(err)
+	fmt.Printf("Checking %d files...\n", len(keys))
+	updated := 0
+	for _, key := range keys {
+		changed, err := encrypted.Upgrade(key)
+		if err != nil {
+			log.Fatalf("file %s: %s", key, err)
+		}
+		if changed {
+			updated++
+		}
+	}
+	fmt.Printf("Done (%d files updated).\n", updated)
+}
+
+func newStorage() (files.Storage, error) {
+	s3, err := config.NewS3()
+	if err != nil {
+		return nil, err
+	}
+	if s3.Endpoint != "" {
+		return files.NewS3Storage(s3)
+	}
+	dir := os.Getenv("FILES_DIR")
+	if dir == "" {
+		return nil, errors.New("missing env FILES_DIR")
+	}
+	return files.NewLocalStorage(dir), nil
+}
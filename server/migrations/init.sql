@@ -45,7 +45,9 @@ INSERT INTO demandes (Categorie, Description, MaxDocs, JoursValide)
         --
         (14, '', 1, 0),
         --
-        (15, '', 1, 0);
+        (15, '', 1, 0),
+        --
+        (16, 'Document établi avec le médecin pour un enfant atteint d''une maladie chronique ou d''une allergie.', 1, 0);
 
 SELECT
     setval('demandes_id_seq', (
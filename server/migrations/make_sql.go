@@ -36,7 +36,7 @@ func newStatement(s string) statement {
 		out.kind = table
 	case strings.HasPrefix(s, "CREATE OR REPLACE FUNCTION"):
 		out.kind = jsonFunc
-	case strings.HasPrefix(s, "ALTER TABLE") || strings.HasPrefix(s, "CREATE UNIQUE INDEX"):
+	case strings.HasPrefix(s, "ALTER TABLE") || strings.HasPrefix(s, "CREATE UNIQUE INDEX") || strings.HasPrefix(s, "CREATE INDEX"):
 		out.kind = constraint
 	default:
 		panic(s)
@@ -0,0 +1,107 @@
+// Script de migration du contenu des fichiers entre
+// deux supports de stockage (dossier local ou S3).
+//
+// Utilisation : go run migrations/storage/main.go -from local -to s3
+//
+// Le dossier local est défini par FILES_DIR, le stockage S3 par
+// les variables FILES_S3_* (voir config.NewS3).
+// Chaque fichier copié est relu depuis la destination et son
+// empreinte SHA-256 est comparée à celle de la source.
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"registro/config"
+	"registro/sql/files"
+)
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	from := flag.String("from", "local", "source storage (local or s3)")
+	to := flag.String("to", "s3", "destination storage (local or s3)")
+	flag.Parse()
+
+	if *from == *to {
+		log.Fatal("source and destination must differ")
+	}
+	source, err := newStorage(*from)
+	check(err)
+	dest, err := newStorage(*to)
+	check(err)
+
+	keys, err := source.Keys()
+	check(err)
+	fmt.Printf("Copying %d files from %s to %s...\n", len(keys), *from, *to)
+	for i, key := range keys {
+		err = copyFile(source, dest, key)
+		check(err)
+		if (i+1)%100 == 0 {
+			fmt.Printf("%d/%d\n", i+1, len(keys))
+		}
+	}
+	fmt.Println("Done.")
+}
+
+func newStorage(kind string) (files.Storage, error) {
+	switch kind {
+	case "local":
+		dir := os.Getenv("FILES_DIR")
+		if dir == "" {
+			return nil, errors.New("missing env FILES_DIR")
+		}
+		return files.NewLocalStorage(dir), nil
+	case "s3":
+		cfg, err := config.NewS3()
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Endpoint == "" {
+			return nil, errors.New("missing env FILES_S3_ENDPOINT")
+		}
+		return files.NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("invalid storage %s", kind)
+	}
+}
+
+func read(storage files.Storage, key string) ([]byte, error) {
+	r, err := storage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// copyFile copie [key] et vérifie l'empreinte de la copie
+func copyFile(source, dest files.Storage, key string) error {
+	content, err := read(source, key)
+	if err != nil {
+		return fmt.Errorf("reading %s: %s", key, err)
+	}
+	if err = dest.Put(key, content); err != nil {
+		return fmt.Errorf("writing %s: %s", key, err)
+	}
+	copied, err := read(dest, key)
+	if err != nil {
+		return fmt.Errorf("checking %s: %s", key, err)
+	}
+	want, got := sha256.Sum256(content), sha256.Sum256(copied)
+	if !bytes.Equal(want[:], got[:]) {
+		return fmt.Errorf("checksum mismatch for %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+-- v0.11.0
+-- add an optional second responsable on dossiers,
+-- and track the author of espace perso messages
+
+BEGIN;
+ALTER TABLE dossiers
+    ADD COLUMN IdResponsable2 integer;
+ALTER TABLE dossiers
+    ADD COLUMN AccesResponsable2 smallint CHECK (AccesResponsable2 IN (0, 1)) NOT NULL DEFAULT 0;
+ALTER TABLE dossiers
+    ALTER COLUMN AccesResponsable2 DROP DEFAULT;
+ALTER TABLE dossiers
+    ADD FOREIGN KEY (IdResponsable2) REFERENCES personnes ON DELETE SET NULL;
+--
+ALTER TABLE event_messages
+    ADD COLUMN OrigineResponsable2 boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE event_messages
+    ALTER COLUMN OrigineResponsable2 DROP DEFAULT;
+ALTER TABLE event_messages
+    ADD CHECK (Origine = 0
+    /* Acteur.Espaceperso */
+        OR OrigineResponsable2 = FALSE);
+COMMIT;
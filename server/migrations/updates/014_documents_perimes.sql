@@ -0,0 +1,9 @@
+-- v0.12.0
+-- track expired documents (see Demande.JoursValide)
+
+BEGIN;
+ALTER TABLE files
+    ADD COLUMN Perime boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE files
+    ALTER COLUMN Perime DROP DEFAULT;
+COMMIT;
@@ -0,0 +1,13 @@
+-- v0.12.0
+-- add a review state on uploaded documents
+
+BEGIN;
+ALTER TABLE files
+    ADD COLUMN Revue smallint CHECK (Revue IN (0, 1, 2)) NOT NULL DEFAULT 0;
+ALTER TABLE files
+    ADD COLUMN RevueCommentaire text NOT NULL DEFAULT '';
+ALTER TABLE files
+    ALTER COLUMN Revue DROP DEFAULT;
+ALTER TABLE files
+    ALTER COLUMN RevueCommentaire DROP DEFAULT;
+COMMIT;
@@ -0,0 +1,24 @@
+-- v0.12.0
+-- electronic signatures of charte, autorisation and fiche sanitaire
+
+BEGIN;
+CREATE TABLE signatures (
+    Id serial PRIMARY KEY,
+    IdFile integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Document smallint CHECK (Document IN (0, 1, 2, 3)) NOT NULL,
+    Signataire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    IP text NOT NULL,
+    Empreinte text NOT NULL
+);
+
+ALTER TABLE signatures
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+COMMIT;
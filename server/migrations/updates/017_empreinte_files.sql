@@ -0,0 +1,12 @@
+-- v0.12.0
+-- content addressed storage : files sharing the same content
+-- reference the same stored object ;
+-- existing files are migrated by migrations/empreintes
+
+BEGIN;
+ALTER TABLE files
+    ADD COLUMN Empreinte text NOT NULL DEFAULT '';
+ALTER TABLE files
+    ALTER COLUMN Empreinte DROP DEFAULT;
+CREATE INDEX ON files (Empreinte);
+COMMIT;
@@ -0,0 +1,197 @@
+-- v0.12.0
+-- structured medical data in the fiche sanitaire,
+-- and the builtin Demande for the PAI document
+
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Traitement (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Vaccination (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_boolean (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'boolean';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a boolean', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Allergies (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Asthme', 'Alimentaires', 'Medicamenteuses', 'Autres', 'Aliments', 'Precisions', 'ConduiteATenir'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_boolean (data -> 'Asthme')
+        AND gomacro_validate_json_boolean (data -> 'Alimentaires')
+        AND gomacro_validate_json_boolean (data -> 'Medicamenteuses')
+        AND gomacro_validate_json_string (data -> 'Autres')
+        AND gomacro_validate_json_string (data -> 'Aliments')
+        AND gomacro_validate_json_string (data -> 'Precisions')
+        AND gomacro_validate_json_string (data -> 'ConduiteATenir');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Medicament', 'Dose', 'Horaire', 'Duree'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Medicament')
+        AND gomacro_validate_json_string (data -> 'Dose')
+        AND gomacro_validate_json_string (data -> 'Horaire')
+        AND gomacro_validate_json_string (data -> 'Duree');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Vaccin', 'Date'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Vaccin')
+        AND gomacro_validate_json_string (data -> 'Date');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+ALTER TABLE fichesanitaires
+    ADD COLUMN Traitements jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE fichesanitaires
+    ADD COLUMN Allergies jsonb NOT NULL DEFAULT '{"Asthme": false, "Alimentaires": false, "Medicamenteuses": false, "Autres": "", "Aliments": "", "Precisions": "", "ConduiteATenir": ""}';
+ALTER TABLE fichesanitaires
+    ADD COLUMN Regimes smallint[];
+ALTER TABLE fichesanitaires
+    ADD COLUMN PAI boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE fichesanitaires
+    ADD COLUMN Vaccinations jsonb NOT NULL DEFAULT '[]';
+
+-- migrate the free text content
+UPDATE
+    fichesanitaires
+SET
+    Traitements = jsonb_build_array(jsonb_build_object('Medicament', TraitementMedical, 'Dose', '', 'Horaire', '', 'Duree', ''))
+WHERE
+    trim(TraitementMedical) <> '';
+UPDATE
+    fichesanitaires
+SET
+    Allergies = jsonb_set(jsonb_set(Allergies, '{Alimentaires}', 'true'), '{Aliments}', to_jsonb (AllergiesAlimentaires))
+WHERE
+    trim(AllergiesAlimentaires) <> '';
+
+ALTER TABLE fichesanitaires
+    DROP COLUMN TraitementMedical;
+ALTER TABLE fichesanitaires
+    DROP COLUMN AllergiesAlimentaires;
+ALTER TABLE fichesanitaires
+    ALTER COLUMN Traitements DROP DEFAULT;
+ALTER TABLE fichesanitaires
+    ALTER COLUMN Allergies DROP DEFAULT;
+ALTER TABLE fichesanitaires
+    ALTER COLUMN PAI DROP DEFAULT;
+ALTER TABLE fichesanitaires
+    ALTER COLUMN Vaccinations DROP DEFAULT;
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
+-- PAI document
+ALTER TABLE demandes
+    DROP CONSTRAINT demandes_categorie_check;
+ALTER TABLE demandes
+    ADD CONSTRAINT demandes_categorie_check CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16));
+INSERT INTO demandes (Categorie, Description, MaxDocs, JoursValide)
+    VALUES (16, 'Document établi avec le médecin pour un enfant atteint d''une maladie chronique ou d''une allergie.', 1, 0);
+SELECT
+    setval('demandes_id_seq', (
+            SELECT
+                max(id)
+            FROM demandes));
+COMMIT;
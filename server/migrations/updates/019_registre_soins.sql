@@ -0,0 +1,23 @@
+-- v0.12.0
+-- registre des soins (administrations de médicaments et incidents)
+
+BEGIN;
+CREATE TABLE soins (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Creneau smallint CHECK (Creneau IN (0, 1, 2, 3)) NOT NULL,
+    Medicament text NOT NULL,
+    Dose text NOT NULL,
+    Description text NOT NULL,
+    Auteur text NOT NULL
+);
+
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps;
+
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+COMMIT;
@@ -0,0 +1,54 @@
+-- v0.12.0
+-- historique des fiches sanitaires
+
+BEGIN;
+CREATE TABLE fichesanitaireversions (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    DifficultesSante text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
+    Medecin jsonb NOT NULL,
+    AutreContact jsonb NOT NULL,
+    Modified timestamp(0) with time zone NOT NULL,
+    Auteur text NOT NULL
+);
+
+ALTER TABLE fichesanitaireversions
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
+-- le contenu actuel sert de première version
+INSERT INTO fichesanitaireversions (IdPersonne, DifficultesSante, Traitements, Allergies, Regimes, PAI, Vaccinations, Medecin, AutreContact, Modified, Auteur)
+SELECT
+    IdPersonne,
+    DifficultesSante,
+    Traitements,
+    Allergies,
+    Regimes,
+    PAI,
+    Vaccinations,
+    Medecin,
+    AutreContact,
+    Modified,
+    ''
+FROM
+    fichesanitaires;
+COMMIT;
@@ -0,0 +1,28 @@
+-- v0.12.0
+-- candidatures équipiers (formulaire public)
+
+BEGIN;
+CREATE TABLE candidatures (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Role smallint CHECK (Role IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)) NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Motivation text NOT NULL,
+    Diplome smallint CHECK (Diplome IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19)) NOT NULL,
+    Approfondissement smallint CHECK (Approfondissement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    Formation text NOT NULL,
+    Profession text NOT NULL,
+    ExperienceTravailJeunes text NOT NULL
+);
+
+ALTER TABLE candidatures
+    ADD UNIQUE (IdCamp, IdPersonne);
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps ON DELETE CASCADE;
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+COMMIT;
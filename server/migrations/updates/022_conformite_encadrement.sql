@@ -0,0 +1,14 @@
+-- v0.12.0
+-- validation du rapport d'encadrement par le centre,
+-- requise avant l'envoi des documents (les séjours existants ne sont pas bloqués)
+
+BEGIN;
+ALTER TABLE camps
+    ADD COLUMN ConformiteValidee boolean NOT NULL DEFAULT TRUE;
+ALTER TABLE camps
+    ALTER COLUMN ConformiteValidee DROP DEFAULT;
+ALTER TABLE camps
+    ADD COLUMN ConformiteEmpreinte text NOT NULL DEFAULT '';
+ALTER TABLE camps
+    ALTER COLUMN ConformiteEmpreinte DROP DEFAULT;
+COMMIT;
@@ -0,0 +1,40 @@
+-- v0.12.0
+-- notes de frais des équipiers et justificatifs,
+-- nouveau mode de paiement pour les abandons de frais convertis en dons
+
+BEGIN;
+CREATE TABLE depenses (
+    Id serial PRIMARY KEY,
+    IdEquipier integer NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4)) NOT NULL,
+    Montant Montant NOT NULL,
+    Date date NOT NULL,
+    Description text NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3, 4)) NOT NULL,
+    Commentaire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL
+);
+
+ALTER TABLE depenses
+    ADD FOREIGN KEY (IdEquipier) REFERENCES equipiers ON DELETE CASCADE;
+
+CREATE TABLE file_depenses (
+    IdFile integer NOT NULL,
+    IdDepense integer NOT NULL
+);
+
+ALTER TABLE file_depenses
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdDepense) REFERENCES depenses ON DELETE CASCADE;
+
+-- abandon de frais (uniquement pour les dons)
+ALTER TABLE dons
+    DROP CONSTRAINT dons_modepaiement_check;
+ALTER TABLE dons
+    ADD CONSTRAINT dons_modepaiement_check CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5, 6));
+COMMIT;
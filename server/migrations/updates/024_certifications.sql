@@ -0,0 +1,34 @@
+-- v0.12.0
+-- certifications des équipiers (BAFA, PSC1, permis, ...)
+-- avec dates de validité et justificatif
+
+BEGIN;
+CREATE TABLE certifications (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1, 2, 3, 4, 5, 6, 7)) NOT NULL,
+    Label text NOT NULL,
+    Obtention date NOT NULL,
+    Expiration date NOT NULL
+);
+
+ALTER TABLE certifications
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+CREATE TABLE file_certifications (
+    IdFile integer NOT NULL,
+    IdCertification integer NOT NULL
+);
+
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdCertification);
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdCertification) REFERENCES certifications ON DELETE CASCADE;
+COMMIT;
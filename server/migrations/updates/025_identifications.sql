@@ -0,0 +1,11 @@
+-- v0.12.0
+-- journal des identifications de profils temporaires,
+-- qui peuvent être annulées pendant quelques heures
+
+BEGIN;
+CREATE TABLE identifications (
+    Id serial PRIMARY KEY,
+    Moment timestamp(0) with time zone NOT NULL,
+    Record bytea NOT NULL
+);
+COMMIT;
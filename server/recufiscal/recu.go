@@ -74,7 +74,10 @@ func loadAndSelect(db dons.DB, year int) (map[pr.IdPersonne]recuFiscal, pr.Perso
 		}
 
 		rf.Montant.Add(don.Montant)
-		rf.Mode = don.ModePaiement
+		// un abandon de frais n'est pas un mode de versement
+		if !ok || don.ModePaiement != ds.AbandonFrais {
+			rf.Mode = don.ModePaiement
+		}
 		if d := don.Date.Time(); rf.Date.Before(d) {
 			rf.Date = d
 		}
@@ -204,14 +207,18 @@ func champsDon(don recuFiscal) []champPdf {
 		ds.EnLigne:   "z51",
 		ds.Ancv:      "z50",
 	}
-	return []champPdf{
+	out := []champPdf{
 		{id: "z34" /* montantChiffre */, valeur: formfill.FDFText(euros.String())},
 		{id: "z35" /* montantLettre */, valeur: formfill.FDFText(montantLettre)},
 		{id: "z36" /* jourVersement */, valeur: formfill.FDFText(strconv.Itoa(date.Day()))},
 		{id: "z37" /* moisVersement */, valeur: formfill.FDFText(strconv.Itoa(int(date.Month())))},
 		{id: "z38" /* anneeVersement */, valeur: formfill.FDFText(strconv.Itoa(date.Year()))},
-		{id: modeDon[don.Mode], valeur: formfill.FDFName("Oui")},
 	}
+	// pas de case pour les abandons de frais
+	if id, ok := modeDon[don.Mode]; ok {
+		out = append(out, champPdf{id: id, valeur: formfill.FDFName("Oui")})
+	}
+	return out
 }
 
 var champsTypeDon = []champPdf{
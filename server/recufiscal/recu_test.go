@@ -70,6 +70,9 @@ func TestFields(t *testing.T) {
 	for _, field := range fields {
 		fmt.Println(field.id, field.valeur)
 	}
+
+	don.Mode = ds.AbandonFrais
+	tu.Assert(t, len(champsDon(don)) == 5) // pas de case pour le mode
 }
 
 func TestGenerate(t *testing.T) {
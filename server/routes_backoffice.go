@@ -33,6 +33,8 @@ func setupRoutesBackoffice(e *echo.Echo, ct *backoffice.Controller) {
 
 	gr.GET("/api/v1/backoffice/camps/load", ct.CampsLoad)
 	gr.GET("/api/v1/backoffice/camps/documents", ct.CampsDocuments)
+	gr.GET("/api/v1/backoffice/camps/participants-files", ct.CampsParticipantsFiles)
+	gr.POST("/api/v1/backoffice/camps/participants-files/revue", ct.CampsRevueDocument)
 
 	gr.GET("/api/v1/backoffice/camps/sondages", ct.CampsLoadSondages)
 
@@ -51,8 +53,23 @@ func setupRoutesBackoffice(e *echo.Echo, ct *backoffice.Controller) {
 
 	e.GET("/api/v1/backoffice/camps/download-participants", ct.CampsDownloadParticipants, ct.JWTMiddlewareForQuery()) // url-only
 	e.GET("/api/v1/backoffice/camps/download-equipiers", ct.CampsDownloadEquipiers, ct.JWTMiddlewareForQuery())       // url-only
+	e.GET("/api/v1/backoffice/camps/download-declaration", ct.CampsDownloadDeclaration, ct.JWTMiddlewareForQuery())   // url-only
+	e.GET("/api/v1/backoffice/camps/download-depenses", ct.CampsDepensesDownload, ct.JWTMiddlewareForQuery())         // url-only
 
 	gr.PUT("/api/v1/backoffice/camps/equipiers", ct.CampsCreateEquipier)
+	gr.GET("/api/v1/backoffice/camps/equipiers/planning", ct.CampsLoadPlanningEquipiers)
+	gr.GET("/api/v1/backoffice/camps/conformite", ct.CampsLoadConformite)
+	gr.GET("/api/v1/backoffice/camps/declaration", ct.CampsLoadDeclaration)
+	gr.POST("/api/v1/backoffice/camps/conformite", ct.CampsValideConformite)
+
+	gr.GET("/api/v1/backoffice/camps/depenses", ct.CampsDepensesGet)
+	gr.POST("/api/v1/backoffice/camps/depenses/rembourse", ct.CampsDepenseRembourse)
+	gr.POST("/api/v1/backoffice/camps/depenses/don", ct.CampsDepenseConvertit)
+
+	gr.GET("/api/v1/backoffice/camps/candidatures", ct.CandidaturesGet)
+	gr.POST("/api/v1/backoffice/camps/candidatures/statut", ct.CandidaturesSetStatut)
+	gr.POST("/api/v1/backoffice/camps/candidatures/accepte", ct.CandidaturesAccepte)
+	gr.POST("/api/v1/backoffice/camps/candidatures/refuse", ct.CandidaturesRefuse)
 
 	// Onglet Inscriptions/Dossiers
 	gr.GET("/api/v1/backoffice/pending-inscriptions", ct.InscriptionsGetPending)
@@ -64,6 +81,10 @@ func setupRoutesBackoffice(e *echo.Echo, ct *backoffice.Controller) {
 	gr.GET("/api/v1/backoffice/inscriptions/search-similaires", ct.InscriptionsSearchSimilaires)
 	gr.GET("/api/v1/backoffice/inscriptions/search-doublons", ct.InscriptionsSearchDoublons)
 	gr.POST("/api/v1/backoffice/inscriptions/identifie", ct.InscriptionsIdentifiePersonne)
+	gr.GET("/api/v1/backoffice/inscriptions/temporaires", ct.InscriptionsLoadTemporaires)
+	gr.POST("/api/v1/backoffice/inscriptions/temporaires", ct.InscriptionsIdentifieTemporaire)
+	gr.POST("/api/v1/backoffice/inscriptions/temporaires/auto", ct.InscriptionsAutoIdentifie)
+	gr.DELETE("/api/v1/backoffice/inscriptions/temporaires", ct.InscriptionsAnnuleIdentification)
 	gr.POST("/api/v1/backoffice/inscriptions/valide/hint", ct.InscriptionsHintValide)
 	gr.POST("/api/v1/backoffice/inscriptions/valide", ct.InscriptionsValide)
 
@@ -72,6 +93,7 @@ func setupRoutesBackoffice(e *echo.Echo, ct *backoffice.Controller) {
 	gr.PUT("/api/v1/backoffice/dossiers", ct.DossiersCreate)
 	gr.POST("/api/v1/backoffice/dossiers", ct.DossiersUpdate)
 	gr.DELETE("/api/v1/backoffice/dossiers", ct.DossiersDelete)
+	gr.POST("/api/v1/backoffice/dossiers/responsable2", ct.DossiersInviteResponsable2)
 
 	gr.PUT("/api/v1/backoffice/dossiers/remises-hints", ct.DossiersRemisesHint)
 	gr.POST("/api/v1/backoffice/dossiers/remises-hints", ct.DossiersApplyRemisesHints)
@@ -109,4 +131,10 @@ func setupRoutesBackoffice(e *echo.Echo, ct *backoffice.Controller) {
 	gr.GET("/api/v1/backoffice/personnes", ct.PersonnesLoad)
 	gr.PUT("/api/v1/backoffice/personnes", ct.PersonnesCreate)
 	gr.POST("/api/v1/backoffice/personnes", ct.PersonnesUpdate)
+	gr.GET("/api/v1/backoffice/personnes/export", ct.PersonnesExportDonnees)
+	gr.POST("/api/v1/backoffice/personnes/anonymise", ct.PersonnesAnonymise)
+	gr.GET("/api/v1/backoffice/retention", ct.RetentionLoadReport)
+	gr.GET("/api/v1/backoffice/personnes/doublons", ct.PersonnesLoadDoublons)
+	gr.POST("/api/v1/backoffice/personnes/doublons", ct.PersonnesRefreshDoublons)
+	gr.POST("/api/v1/backoffice/personnes/fusion", ct.PersonnesFusionne)
 }
@@ -19,8 +19,14 @@ func setupRoutesDirecteurs(e *echo.Echo, ct *directeurs.Controller) {
 	// file download URLs (see also routes_misc.go)
 	e.GET("/api/v1/directeurs/documents/stream-files", ct.DocumentsStreamFiles, ct.JWTMiddlewareForQuery())                            // url-only
 	e.GET("/api/v1/directeurs/documents/download-fiches-sanitaires", ct.DocumentsDownloadFichesSanitaires, ct.JWTMiddlewareForQuery()) // url-only
+	e.GET("/api/v1/directeurs/documents/download-completion", ct.DocumentsDownloadCompletion, ct.JWTMiddlewareForQuery())              // url-only
 	e.GET("/api/v1/directeurs/participants/download-liste", ct.ParticipantsDownloadListe, ct.JWTMiddlewareForQuery())                  // url-only
 	e.GET("/api/v1/directeurs/equipiers/files", ct.EquipiersDownloadFiles, ct.JWTMiddlewareForQuery())                                 // url-only
+	e.GET("/api/v1/directeurs/equipiers/download-declaration", ct.EquipiersDownloadDeclaration, ct.JWTMiddlewareForQuery())            // url-only
+	e.GET("/api/v1/directeurs/infirmerie/download-registre", ct.InfirmerieDownloadRegistre, ct.JWTMiddlewareForQuery())                // url-only
+	e.GET("/api/v1/directeurs/infirmerie/download-cahier", ct.InfirmerieDownloadCahier, ct.JWTMiddlewareForQuery())                    // url-only
+	e.GET("/api/v1/directeurs/cuisine/download-pdf", ct.CuisineDownloadPDF, ct.JWTMiddlewareForQuery())                                // url-only
+	e.GET("/api/v1/directeurs/cuisine/download-excel", ct.CuisineDownloadExcel, ct.JWTMiddlewareForQuery())                            // url-only
 	e.POST("/api/v1/directeurs/lettre-image", ct.LettreImageUpload, ct.JWTMiddlewareForQuery())                                        // url-only
 
 	gr := e.Group("", ct.JWTMiddleware())
@@ -41,9 +47,13 @@ func setupRoutesDirecteurs(e *echo.Echo, ct *directeurs.Controller) {
 	gr.POST("/api/v1/directeurs/participants", ct.ParticipantsUpdate)
 	gr.GET("/api/v1/directeurs/participants/fiches-sanitaires", ct.ParticipantsGetFichesSanitaires)
 	gr.GET("/api/v1/directeurs/participants/download-fiche-sanitaire", ct.ParticipantsDownloadFicheSanitaire)
+	gr.GET("/api/v1/directeurs/participants/fiche-sanitaire/historique", ct.ParticipantsFicheSanitaireHistorique)
 
 	gr.GET("/api/v1/directeurs/participants/files", ct.ParticipantsLoadFiles)
+	gr.POST("/api/v1/directeurs/participants/files/revue", ct.DocumentsRevue)
 	gr.POST("/api/v1/directeurs/participants/relance-documents", ct.ParticipantsRelanceDocuments)
+	gr.GET("/api/v1/directeurs/participants/files/completion", ct.DocumentsCompletion)
+	gr.POST("/api/v1/directeurs/participants/files/completion/relance", ct.DocumentsRelanceIncomplets)
 
 	gr.GET("/api/v1/directeurs/participants/groupes", ct.GroupesGet)
 	gr.PUT("/api/v1/directeurs/participants/groupe", ct.GroupeCreate)
@@ -64,6 +74,21 @@ func setupRoutesDirecteurs(e *echo.Echo, ct *directeurs.Controller) {
 	gr.POST("/api/v1/directeurs/equipiers/invite", ct.EquipiersInvite)
 	gr.GET("/api/v1/directeurs/equipiers/demandes", ct.EquipiersDemandesGet)
 	gr.POST("/api/v1/directeurs/equipiers/demandes", ct.EquipiersDemandeSet)
+	gr.GET("/api/v1/directeurs/equipiers/conformite", ct.EquipiersConformite)
+	gr.GET("/api/v1/directeurs/equipiers/declaration", ct.EquipiersDeclaration)
+	gr.GET("/api/v1/directeurs/equipiers/certifications", ct.EquipiersCertifications)
+	gr.GET("/api/v1/directeurs/equipiers/depenses", ct.EquipiersDepensesGet)
+	gr.POST("/api/v1/directeurs/equipiers/depenses", ct.EquipiersDepenseValide)
+	gr.GET("/api/v1/directeurs/equipiers/candidatures", ct.CandidaturesGet)
+	gr.POST("/api/v1/directeurs/equipiers/candidatures/statut", ct.CandidaturesSetStatut)
+	gr.POST("/api/v1/directeurs/equipiers/candidatures/accepte", ct.CandidaturesAccepte)
+	gr.POST("/api/v1/directeurs/equipiers/candidatures/refuse", ct.CandidaturesRefuse)
+
+	// Infirmerie
+	gr.GET("/api/v1/directeurs/infirmerie", ct.InfirmerieGet)
+
+	// Cuisine
+	gr.GET("/api/v1/directeurs/cuisine", ct.CuisineGet)
 
 	// Lettre
 	gr.GET("/api/v1/directeurs/lettre", ct.LettreGet)
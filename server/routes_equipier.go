@@ -16,4 +16,26 @@ func setupRoutesEquipier(e *echo.Echo, ct *equipier.Controller) {
 	e.POST("/api/v1/equipier/charte", ct.UpdateCharte)
 	e.PUT("/api/v1/equipier/upload", ct.UploadDocument)
 	e.DELETE("/api/v1/equipier/upload", ct.DeleteDocument)
+	e.GET("/api/v1/equipier/formulaire", ct.LoadFormulaire)
+	e.POST("/api/v1/equipier/formulaire", ct.SaveFormulaire)
+	e.GET("/api/v1/equipier/depenses", ct.DepensesLoad)
+	e.PUT("/api/v1/equipier/depenses", ct.DepensesCreate)
+	e.POST("/api/v1/equipier/depenses", ct.DepensesUpdate)
+	e.DELETE("/api/v1/equipier/depenses", ct.DepensesDelete)
+	e.PUT("/api/v1/equipier/depenses/justificatif", ct.DepensesUploadJustificatif)
+	e.DELETE("/api/v1/equipier/depenses/justificatif", ct.DepensesDeleteJustificatif)
+	e.GET("/api/v1/equipier/certifications", ct.CertificationsLoad)
+	e.PUT("/api/v1/equipier/certifications", ct.CertificationsCreate)
+	e.POST("/api/v1/equipier/certifications", ct.CertificationsUpdate)
+	e.DELETE("/api/v1/equipier/certifications", ct.CertificationsDelete)
+	e.PUT("/api/v1/equipier/certifications/justificatif", ct.CertificationsUploadJustificatif)
+	e.DELETE("/api/v1/equipier/certifications/justificatif", ct.CertificationsDeleteJustificatif)
+	e.GET("/api/v1/equipier/infirmerie", ct.InfirmerieLoad)
+	e.PUT("/api/v1/equipier/infirmerie/soin", ct.InfirmerieCreateSoin)
+	e.POST("/api/v1/equipier/infirmerie/soin", ct.InfirmerieUpdateSoin)
+	e.GET("/api/v1/equipier/infirmerie/registre", ct.InfirmerieDownloadRegistre) // url-only
+	e.GET("/api/v1/equipier/infirmerie/cahier", ct.InfirmerieDownloadCahier)     // url-only
+	e.GET("/api/v1/equipier/cuisine", ct.CuisineLoad)
+	e.GET("/api/v1/equipier/cuisine/pdf", ct.CuisineDownloadPDF)     // url-only
+	e.GET("/api/v1/equipier/cuisine/excel", ct.CuisineDownloadExcel) // url-only
 }
@@ -32,8 +32,12 @@ func setupRoutesEspaceperso(e *echo.Echo, ct *espaceperso.Controller) {
 	e.GET("/api/v1/espaceperso/documents", ct.LoadDocuments)
 	e.POST("/api/v1/espaceperso/documents", ct.UploadDocument)
 	e.DELETE("/api/v1/espaceperso/documents", ct.DeleteDocument)
+	e.GET("/api/v1/espaceperso/documents/formulaire", ct.LoadFormulaire)
+	e.POST("/api/v1/espaceperso/documents/formulaire", ct.SaveFormulaire)
 	e.POST("/api/v1/espaceperso/documents/charte", ct.AccepteCharte)
+	e.POST("/api/v1/espaceperso/documents/autorisation", ct.SigneAutorisation)
 
 	e.POST("/api/v1/espaceperso/fichesanitaires", ct.UpdateFichesanitaire)
 	e.PUT("/api/v1/espaceperso/fichesanitaires/transfert", ct.TransfertFicheSanitaire)
+	e.POST("/api/v1/espaceperso/fichesanitaires/signature", ct.SigneFichesanitaire)
 }
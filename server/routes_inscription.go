@@ -17,4 +17,5 @@ func setupRoutesInscription(e *echo.Echo, ct *inscriptions.Controller) {
 	e.PUT("/api/v1/inscription", ct.SaveInscription)
 	e.GET("/api/v1/inscription/search", ct.SearchHistory)
 	e.POST("/api/v1/inscription/check-participant", ct.CheckParticipant)
+	e.PUT("/api/v1/inscription/candidature", ct.SaveCandidature)
 }
@@ -48,11 +48,40 @@ CREATE TABLE camps (
     Password text NOT NULL,
     DocumentsReady boolean NOT NULL,
     DocumentsToShow DocumentsToShow NOT NULL,
+    ConformiteValidee boolean NOT NULL,
+    ConformiteEmpreinte text NOT NULL,
     Vetements jsonb NOT NULL,
     AlbumID text NOT NULL,
     Meta jsonb NOT NULL
 );
 
+CREATE TABLE candidatures (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Role smallint CHECK (Role IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)) NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Motivation text NOT NULL,
+    Diplome smallint CHECK (Diplome IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19)) NOT NULL,
+    Approfondissement smallint CHECK (Approfondissement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    Formation text NOT NULL,
+    Profession text NOT NULL,
+    ExperienceTravailJeunes text NOT NULL
+);
+
+CREATE TABLE depenses (
+    Id serial PRIMARY KEY,
+    IdEquipier integer NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4)) NOT NULL,
+    Montant Montant NOT NULL,
+    Date date NOT NULL,
+    Description text NOT NULL,
+    Statut smallint CHECK (Statut IN (0, 1, 2, 3, 4)) NOT NULL,
+    Commentaire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL
+);
+
 CREATE TABLE equipiers (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -121,6 +150,19 @@ CREATE TABLE projet_spis (
     Question text NOT NULL
 );
 
+CREATE TABLE soins (
+    Id serial PRIMARY KEY,
+    IdCamp integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1)) NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    Creneau smallint CHECK (Creneau IN (0, 1, 2, 3)) NOT NULL,
+    Medicament text NOT NULL,
+    Dose text NOT NULL,
+    Description text NOT NULL,
+    Auteur text NOT NULL
+);
+
 CREATE TABLE sondages (
     Id serial PRIMARY KEY,
     IdCamp integer NOT NULL,
@@ -234,6 +276,12 @@ ALTER TABLE aides
 ALTER TABLE aides
     ADD FOREIGN KEY (IdParticipant) REFERENCES participants ON DELETE CASCADE;
 
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps;
+
+ALTER TABLE soins
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE equipiers
     ADD UNIQUE (IdCamp, IdPersonne);
 
@@ -249,6 +297,18 @@ ALTER TABLE equipiers
 ALTER TABLE equipiers
     ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
 
+ALTER TABLE candidatures
+    ADD UNIQUE (IdCamp, IdPersonne);
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdCamp) REFERENCES camps ON DELETE CASCADE;
+
+ALTER TABLE candidatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+ALTER TABLE depenses
+    ADD FOREIGN KEY (IdEquipier) REFERENCES equipiers ON DELETE CASCADE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_camp_PrixParStatut (data jsonb)
     RETURNS boolean
     AS $$
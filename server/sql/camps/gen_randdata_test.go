@@ -56,6 +56,8 @@ func randCamp() Camp {
 	s.Password = randstring()
 	s.DocumentsReady = randbool()
 	s.DocumentsToShow = randDocumentsToShow()
+	s.ConformiteValidee = randbool()
+	s.ConformiteEmpreinte = randstring()
 	s.Vetements = randListeVetements()
 	s.AlbumID = randstring()
 	s.Meta = randMeta()
@@ -63,6 +65,51 @@ func randCamp() Camp {
 	return s
 }
 
+func randCandidature() Candidature {
+	var s Candidature
+	s.Id = randIdCandidature()
+	s.IdCamp = randIdCamp()
+	s.IdPersonne = randper_IdPersonne()
+	s.Role = randRole()
+	s.Statut = randStatutCandidature()
+	s.Moment = randtTime()
+	s.Motivation = randstring()
+	s.Diplome = randper_Diplome()
+	s.Approfondissement = randper_Approfondissement()
+	s.Formation = randstring()
+	s.Profession = randstring()
+	s.ExperienceTravailJeunes = randstring()
+
+	return s
+}
+
+func randCategorieDepense() CategorieDepense {
+	choix := [...]CategorieDepense{DepenseNourriture, DepenseTransport, DepenseMateriel, DepenseIndemnite, DepenseAutre}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randCreneau() Creneau {
+	choix := [...]Creneau{Matin, Midi, Soir, Coucher}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randDepense() Depense {
+	var s Depense
+	s.Id = randIdDepense()
+	s.IdEquipier = randIdEquipier()
+	s.Categorie = randCategorieDepense()
+	s.Montant = randdos_Montant()
+	s.Date = randsha_Date()
+	s.Description = randstring()
+	s.Statut = randStatutDepense()
+	s.Commentaire = randstring()
+	s.Moment = randtTime()
+
+	return s
+}
+
 func randDocumentsToShow() DocumentsToShow {
 	var s DocumentsToShow
 	s.LettreDirecteur = randbool()
@@ -121,6 +168,14 @@ func randIdCamp() IdCamp {
 	return IdCamp(randint64())
 }
 
+func randIdCandidature() IdCandidature {
+	return IdCandidature(randint64())
+}
+
+func randIdDepense() IdDepense {
+	return IdDepense(randint64())
+}
+
 func randIdEquipier() IdEquipier {
 	return IdEquipier(randint64())
 }
@@ -137,6 +192,10 @@ func randIdParticipant() IdParticipant {
 	return IdParticipant(randint64())
 }
 
+func randIdSoin() IdSoin {
+	return IdSoin(randint64())
+}
+
 func randIdSondage() IdSondage {
 	return IdSondage(randint64())
 }
@@ -363,6 +422,28 @@ func randSliceuint8() []byte {
 	return out
 }
 
+func randSoin() Soin {
+	var s Soin
+	s.Id = randIdSoin()
+	s.IdCamp = randIdCamp()
+	s.IdPersonne = randper_IdPersonne()
+	s.Kind = randSoinKind()
+	s.Moment = randtTime()
+	s.Creneau = randCreneau()
+	s.Medicament = randstring()
+	s.Dose = randstring()
+	s.Description = randstring()
+	s.Auteur = randstring()
+
+	return s
+}
+
+func randSoinKind() SoinKind {
+	choix := [...]SoinKind{Administration, Incident}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randSondage() Sondage {
 	var s Sondage
 	s.Id = randIdSondage()
@@ -390,6 +471,18 @@ func randStatutCamp() StatutCamp {
 	return choix[i]
 }
 
+func randStatutCandidature() StatutCandidature {
+	choix := [...]StatutCandidature{CandidatureAttente, Preselectionnee, Acceptee, Refusee}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randStatutDepense() StatutDepense {
+	choix := [...]StatutDepense{DepenseAttente, DepenseApprouvee, DepenseRefusee, DepenseRemboursee, DepenseConvertie}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randStatutParticipant() StatutParticipant {
 	choix := [...]StatutParticipant{AStatuer, Refuse, AttenteProfilInvalide, AttenteCampComplet, EnAttenteReponse, Inscrit}
 	i := rand.Intn(len(choix))
@@ -464,6 +557,18 @@ func randint64() int64 {
 	return int64(rand.Intn(1000000))
 }
 
+func randper_Approfondissement() personnes.Approfondissement {
+	choix := [...]personnes.Approfondissement{personnes.AAucun, personnes.AAutre, personnes.ASb, personnes.ACanoe, personnes.AVoile, personnes.AMoto}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randper_Diplome() personnes.Diplome {
+	choix := [...]personnes.Diplome{personnes.DAucun, personnes.DBafa, personnes.DBafaStag, personnes.DBafd, personnes.DBafdStag, personnes.DCap, personnes.DAssSociale, personnes.DEducSpe, personnes.DMonEduc, personnes.DInstit, personnes.DProf, personnes.DAgreg, personnes.DBjeps, personnes.DDut, personnes.DEje, personnes.DDeug, personnes.DStaps, personnes.DBapaat, personnes.DBeatep, personnes.DZzautre}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randper_IdPersonne() personnes.IdPersonne {
 	return personnes.IdPersonne(randint64())
 }
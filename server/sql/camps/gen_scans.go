@@ -248,6 +248,8 @@ func scanOneCamp(row scanner) (Camp, error) {
 		&item.Password,
 		&item.DocumentsReady,
 		&item.DocumentsToShow,
+		&item.ConformiteValidee,
+		&item.ConformiteEmpreinte,
 		&item.Vetements,
 		&item.AlbumID,
 		&item.Meta,
@@ -259,7 +261,7 @@ func ScanCamp(row *sql.Row) (Camp, error) { return scanOneCamp(row) }
 
 // SelectAll returns all the items in the camps table.
 func SelectAllCamps(db DB) (Camps, error) {
-	rows, err := db.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta FROM camps")
+	rows, err := db.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta FROM camps")
 	if err != nil {
 		return nil, err
 	}
@@ -268,13 +270,13 @@ func SelectAllCamps(db DB) (Camps, error) {
 
 // SelectCamp returns the entry matching 'id'.
 func SelectCamp(tx DB, id IdCamp) (Camp, error) {
-	row := tx.QueryRow("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta FROM camps WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta FROM camps WHERE id = $1", id)
 	return ScanCamp(row)
 }
 
 // SelectCamps returns the entry matching the given 'ids'.
 func SelectCamps(tx DB, ids ...IdCamp) (Camps, error) {
-	rows, err := tx.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta FROM camps WHERE id = ANY($1)", IdCampArrayToPQ(ids))
+	rows, err := tx.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta FROM camps WHERE id = ANY($1)", IdCampArrayToPQ(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -319,28 +321,28 @@ func ScanCamps(rs *sql.Rows) (Camps, error) {
 // Insert one Camp in the database and returns the item with id filled.
 func (item Camp) Insert(tx DB) (out Camp, err error) {
 	row := tx.QueryRow(`INSERT INTO camps (
-		idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta
+		idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta
 		) VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24
-		) RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta;
-		`, item.IdTaux, item.Nom, item.DateDebut, item.Duree, item.Lieu, item.Agrement, item.ImageURL, item.Description, item.Navette, item.Places, item.AgeMin, item.AgeMax, item.NeedEquilibreGF, item.InscriptionExterne, item.Statut, item.Prix, item.OptionPrix, item.OptionQuotientFamilial, item.Password, item.DocumentsReady, item.DocumentsToShow, item.Vetements, item.AlbumID, item.Meta)
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26
+		) RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta;
+		`, item.IdTaux, item.Nom, item.DateDebut, item.Duree, item.Lieu, item.Agrement, item.ImageURL, item.Description, item.Navette, item.Places, item.AgeMin, item.AgeMax, item.NeedEquilibreGF, item.InscriptionExterne, item.Statut, item.Prix, item.OptionPrix, item.OptionQuotientFamilial, item.Password, item.DocumentsReady, item.DocumentsToShow, item.ConformiteValidee, item.ConformiteEmpreinte, item.Vetements, item.AlbumID, item.Meta)
 	return ScanCamp(row)
 }
 
 // Update Camp in the database and returns the new version.
 func (item Camp) Update(tx DB) (out Camp, err error) {
 	row := tx.QueryRow(`UPDATE camps SET (
-		idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta
+		idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta
 		) = (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24
-		) WHERE id = $25 RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta;
-		`, item.IdTaux, item.Nom, item.DateDebut, item.Duree, item.Lieu, item.Agrement, item.ImageURL, item.Description, item.Navette, item.Places, item.AgeMin, item.AgeMax, item.NeedEquilibreGF, item.InscriptionExterne, item.Statut, item.Prix, item.OptionPrix, item.OptionQuotientFamilial, item.Password, item.DocumentsReady, item.DocumentsToShow, item.Vetements, item.AlbumID, item.Meta, item.Id)
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26
+		) WHERE id = $27 RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta;
+		`, item.IdTaux, item.Nom, item.DateDebut, item.Duree, item.Lieu, item.Agrement, item.ImageURL, item.Description, item.Navette, item.Places, item.AgeMin, item.AgeMax, item.NeedEquilibreGF, item.InscriptionExterne, item.Statut, item.Prix, item.OptionPrix, item.OptionQuotientFamilial, item.Password, item.DocumentsReady, item.DocumentsToShow, item.ConformiteValidee, item.ConformiteEmpreinte, item.Vetements, item.AlbumID, item.Meta, item.Id)
 	return ScanCamp(row)
 }
 
 // Deletes the Camp and returns the item
 func DeleteCampById(tx DB, id IdCamp) (Camp, error) {
-	row := tx.QueryRow("DELETE FROM camps WHERE id = $1 RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta;", id)
+	row := tx.QueryRow("DELETE FROM camps WHERE id = $1 RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta;", id)
 	return ScanCamp(row)
 }
 
@@ -379,7 +381,7 @@ func (items Camps) IdTauxs() []dossiers.IdTaux {
 }
 
 func SelectCampsByIdTauxs(tx DB, idTauxs_ ...dossiers.IdTaux) (Camps, error) {
-	rows, err := tx.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta FROM camps WHERE idtaux = ANY($1)", dossiers.IdTauxArrayToPQ(idTauxs_))
+	rows, err := tx.Query("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta FROM camps WHERE idtaux = ANY($1)", dossiers.IdTauxArrayToPQ(idTauxs_))
 	if err != nil {
 		return nil, err
 	}
@@ -387,7 +389,7 @@ func SelectCampsByIdTauxs(tx DB, idTauxs_ ...dossiers.IdTaux) (Camps, error) {
 }
 
 func DeleteCampsByIdTauxs(tx DB, idTauxs_ ...dossiers.IdTaux) (Camps, error) {
-	rows, err := tx.Query("DELETE FROM camps WHERE idtaux = ANY($1) RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta", dossiers.IdTauxArrayToPQ(idTauxs_))
+	rows, err := tx.Query("DELETE FROM camps WHERE idtaux = ANY($1) RETURNING id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta", dossiers.IdTauxArrayToPQ(idTauxs_))
 	if err != nil {
 		return nil, err
 	}
@@ -396,7 +398,7 @@ func DeleteCampsByIdTauxs(tx DB, idTauxs_ ...dossiers.IdTaux) (Camps, error) {
 
 // SelectCampByIdAndIdTaux return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectCampByIdAndIdTaux(tx DB, id IdCamp, idTaux dossiers.IdTaux) (item Camp, found bool, err error) {
-	row := tx.QueryRow("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, vetements, albumid, meta FROM camps WHERE Id = $1 AND IdTaux = $2", id, idTaux)
+	row := tx.QueryRow("SELECT id, idtaux, nom, datedebut, duree, lieu, agrement, imageurl, description, navette, places, agemin, agemax, needequilibregf, inscriptionexterne, statut, prix, optionprix, optionquotientfamilial, password, documentsready, documentstoshow, conformitevalidee, conformiteempreinte, vetements, albumid, meta FROM camps WHERE Id = $1 AND IdTaux = $2", id, idTaux)
 	item, err = ScanCamp(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -404,6 +406,370 @@ func SelectCampByIdAndIdTaux(tx DB, id IdCamp, idTaux dossiers.IdTaux) (item Cam
 	return item, true, err
 }
 
+func scanOneCandidature(row scanner) (Candidature, error) {
+	var item Candidature
+	err := row.Scan(
+		&item.Id,
+		&item.IdCamp,
+		&item.IdPersonne,
+		&item.Role,
+		&item.Statut,
+		&item.Moment,
+		&item.Motivation,
+		&item.Diplome,
+		&item.Approfondissement,
+		&item.Formation,
+		&item.Profession,
+		&item.ExperienceTravailJeunes,
+	)
+	return item, err
+}
+
+func ScanCandidature(row *sql.Row) (Candidature, error) { return scanOneCandidature(row) }
+
+// SelectAll returns all the items in the candidatures table.
+func SelectAllCandidatures(db DB) (Candidatures, error) {
+	rows, err := db.Query("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures")
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+// SelectCandidature returns the entry matching 'id'.
+func SelectCandidature(tx DB, id IdCandidature) (Candidature, error) {
+	row := tx.QueryRow("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures WHERE id = $1", id)
+	return ScanCandidature(row)
+}
+
+// SelectCandidatures returns the entry matching the given 'ids'.
+func SelectCandidatures(tx DB, ids ...IdCandidature) (Candidatures, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures WHERE id = ANY($1)", IdCandidatureArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+type Candidatures map[IdCandidature]Candidature
+
+func (m Candidatures) IDs() []IdCandidature {
+	out := make([]IdCandidature, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanCandidatures(rs *sql.Rows) (Candidatures, error) {
+	var (
+		s   Candidature
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Candidatures, 16)
+	for rs.Next() {
+		s, err = scanOneCandidature(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Candidature in the database and returns the item with id filled.
+func (item Candidature) Insert(tx DB) (out Candidature, err error) {
+	row := tx.QueryRow(`INSERT INTO candidatures (
+		idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) RETURNING id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes;
+		`, item.IdCamp, item.IdPersonne, item.Role, item.Statut, item.Moment, item.Motivation, item.Diplome, item.Approfondissement, item.Formation, item.Profession, item.ExperienceTravailJeunes)
+	return ScanCandidature(row)
+}
+
+// Update Candidature in the database and returns the new version.
+func (item Candidature) Update(tx DB) (out Candidature, err error) {
+	row := tx.QueryRow(`UPDATE candidatures SET (
+		idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes
+		) = (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) WHERE id = $12 RETURNING id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes;
+		`, item.IdCamp, item.IdPersonne, item.Role, item.Statut, item.Moment, item.Motivation, item.Diplome, item.Approfondissement, item.Formation, item.Profession, item.ExperienceTravailJeunes, item.Id)
+	return ScanCandidature(row)
+}
+
+// Deletes the Candidature and returns the item
+func DeleteCandidatureById(tx DB, id IdCandidature) (Candidature, error) {
+	row := tx.QueryRow("DELETE FROM candidatures WHERE id = $1 RETURNING id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes;", id)
+	return ScanCandidature(row)
+}
+
+// Deletes the Candidature in the database and returns the ids.
+func DeleteCandidaturesByIDs(tx DB, ids ...IdCandidature) ([]IdCandidature, error) {
+	rows, err := tx.Query("DELETE FROM candidatures WHERE id = ANY($1) RETURNING id", IdCandidatureArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdCandidatureArray(rows)
+}
+
+// ByIdCamp returns a map with 'IdCamp' as keys.
+func (items Candidatures) ByIdCamp() map[IdCamp]Candidatures {
+	out := make(map[IdCamp]Candidatures)
+	for _, target := range items {
+		dict := out[target.IdCamp]
+		if dict == nil {
+			dict = make(Candidatures)
+		}
+		dict[target.Id] = target
+		out[target.IdCamp] = dict
+	}
+	return out
+}
+
+// IdCamps returns the list of ids of IdCamp
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Candidatures) IdCamps() []IdCamp {
+	out := make([]IdCamp, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdCamp)
+	}
+	return out
+}
+
+func SelectCandidaturesByIdCamps(tx DB, idCamps_ ...IdCamp) (Candidatures, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures WHERE idcamp = ANY($1)", IdCampArrayToPQ(idCamps_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+func DeleteCandidaturesByIdCamps(tx DB, idCamps_ ...IdCamp) (Candidatures, error) {
+	rows, err := tx.Query("DELETE FROM candidatures WHERE idcamp = ANY($1) RETURNING id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes", IdCampArrayToPQ(idCamps_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+// ByIdPersonne returns a map with 'IdPersonne' as keys.
+func (items Candidatures) ByIdPersonne() map[personnes.IdPersonne]Candidatures {
+	out := make(map[personnes.IdPersonne]Candidatures)
+	for _, target := range items {
+		dict := out[target.IdPersonne]
+		if dict == nil {
+			dict = make(Candidatures)
+		}
+		dict[target.Id] = target
+		out[target.IdPersonne] = dict
+	}
+	return out
+}
+
+// IdPersonnes returns the list of ids of IdPersonne
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Candidatures) IdPersonnes() []personnes.IdPersonne {
+	out := make([]personnes.IdPersonne, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdPersonne)
+	}
+	return out
+}
+
+func SelectCandidaturesByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Candidatures, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures WHERE idpersonne = ANY($1)", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+func DeleteCandidaturesByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Candidatures, error) {
+	rows, err := tx.Query("DELETE FROM candidatures WHERE idpersonne = ANY($1) RETURNING id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCandidatures(rows)
+}
+
+// SelectCandidatureByIdCampAndIdPersonne return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectCandidatureByIdCampAndIdPersonne(tx DB, idCamp IdCamp, idPersonne personnes.IdPersonne) (item Candidature, found bool, err error) {
+	row := tx.QueryRow("SELECT id, idcamp, idpersonne, role, statut, moment, motivation, diplome, approfondissement, formation, profession, experiencetravailjeunes FROM candidatures WHERE IdCamp = $1 AND IdPersonne = $2", idCamp, idPersonne)
+	item, err = ScanCandidature(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func scanOneDepense(row scanner) (Depense, error) {
+	var item Depense
+	err := row.Scan(
+		&item.Id,
+		&item.IdEquipier,
+		&item.Categorie,
+		&item.Montant,
+		&item.Date,
+		&item.Description,
+		&item.Statut,
+		&item.Commentaire,
+		&item.Moment,
+	)
+	return item, err
+}
+
+func ScanDepense(row *sql.Row) (Depense, error) { return scanOneDepense(row) }
+
+// SelectAll returns all the items in the depenses table.
+func SelectAllDepenses(db DB) (Depenses, error) {
+	rows, err := db.Query("SELECT id, idequipier, categorie, montant, date, description, statut, commentaire, moment FROM depenses")
+	if err != nil {
+		return nil, err
+	}
+	return ScanDepenses(rows)
+}
+
+// SelectDepense returns the entry matching 'id'.
+func SelectDepense(tx DB, id IdDepense) (Depense, error) {
+	row := tx.QueryRow("SELECT id, idequipier, categorie, montant, date, description, statut, commentaire, moment FROM depenses WHERE id = $1", id)
+	return ScanDepense(row)
+}
+
+// SelectDepenses returns the entry matching the given 'ids'.
+func SelectDepenses(tx DB, ids ...IdDepense) (Depenses, error) {
+	rows, err := tx.Query("SELECT id, idequipier, categorie, montant, date, description, statut, commentaire, moment FROM depenses WHERE id = ANY($1)", IdDepenseArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanDepenses(rows)
+}
+
+type Depenses map[IdDepense]Depense
+
+func (m Depenses) IDs() []IdDepense {
+	out := make([]IdDepense, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanDepenses(rs *sql.Rows) (Depenses, error) {
+	var (
+		s   Depense
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Depenses, 16)
+	for rs.Next() {
+		s, err = scanOneDepense(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Depense in the database and returns the item with id filled.
+func (item Depense) Insert(tx DB) (out Depense, err error) {
+	row := tx.QueryRow(`INSERT INTO depenses (
+		idequipier, categorie, montant, date, description, statut, commentaire, moment
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) RETURNING id, idequipier, categorie, montant, date, description, statut, commentaire, moment;
+		`, item.IdEquipier, item.Categorie, item.Montant, item.Date, item.Description, item.Statut, item.Commentaire, item.Moment)
+	return ScanDepense(row)
+}
+
+// Update Depense in the database and returns the new version.
+func (item Depense) Update(tx DB) (out Depense, err error) {
+	row := tx.QueryRow(`UPDATE depenses SET (
+		idequipier, categorie, montant, date, description, statut, commentaire, moment
+		) = (
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) WHERE id = $9 RETURNING id, idequipier, categorie, montant, date, description, statut, commentaire, moment;
+		`, item.IdEquipier, item.Categorie, item.Montant, item.Date, item.Description, item.Statut, item.Commentaire, item.Moment, item.Id)
+	return ScanDepense(row)
+}
+
+// Deletes the Depense and returns the item
+func DeleteDepenseById(tx DB, id IdDepense) (Depense, error) {
+	row := tx.QueryRow("DELETE FROM depenses WHERE id = $1 RETURNING id, idequipier, categorie, montant, date, description, statut, commentaire, moment;", id)
+	return ScanDepense(row)
+}
+
+// Deletes the Depense in the database and returns the ids.
+func DeleteDepensesByIDs(tx DB, ids ...IdDepense) ([]IdDepense, error) {
+	rows, err := tx.Query("DELETE FROM depenses WHERE id = ANY($1) RETURNING id", IdDepenseArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdDepenseArray(rows)
+}
+
+// ByIdEquipier returns a map with 'IdEquipier' as keys.
+func (items Depenses) ByIdEquipier() map[IdEquipier]Depenses {
+	out := make(map[IdEquipier]Depenses)
+	for _, target := range items {
+		dict := out[target.IdEquipier]
+		if dict == nil {
+			dict = make(Depenses)
+		}
+		dict[target.Id] = target
+		out[target.IdEquipier] = dict
+	}
+	return out
+}
+
+// IdEquipiers returns the list of ids of IdEquipier
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Depenses) IdEquipiers() []IdEquipier {
+	out := make([]IdEquipier, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdEquipier)
+	}
+	return out
+}
+
+func SelectDepensesByIdEquipiers(tx DB, idEquipiers_ ...IdEquipier) (Depenses, error) {
+	rows, err := tx.Query("SELECT id, idequipier, categorie, montant, date, description, statut, commentaire, moment FROM depenses WHERE idequipier = ANY($1)", IdEquipierArrayToPQ(idEquipiers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanDepenses(rows)
+}
+
+func DeleteDepensesByIdEquipiers(tx DB, idEquipiers_ ...IdEquipier) (Depenses, error) {
+	rows, err := tx.Query("DELETE FROM depenses WHERE idequipier = ANY($1) RETURNING id, idequipier, categorie, montant, date, description, statut, commentaire, moment", IdEquipierArrayToPQ(idEquipiers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanDepenses(rows)
+}
+
 func scanOneEquipier(row scanner) (Equipier, error) {
 	var item Equipier
 	err := row.Scan(
@@ -1755,6 +2121,203 @@ func DeleteProjetSpisByIdCamps(tx DB, idCamps_ ...IdCamp) (ProjetSpis, error) {
 	return ScanProjetSpis(rows)
 }
 
+func scanOneSoin(row scanner) (Soin, error) {
+	var item Soin
+	err := row.Scan(
+		&item.Id,
+		&item.IdCamp,
+		&item.IdPersonne,
+		&item.Kind,
+		&item.Moment,
+		&item.Creneau,
+		&item.Medicament,
+		&item.Dose,
+		&item.Description,
+		&item.Auteur,
+	)
+	return item, err
+}
+
+func ScanSoin(row *sql.Row) (Soin, error) { return scanOneSoin(row) }
+
+// SelectAll returns all the items in the soins table.
+func SelectAllSoins(db DB) (Soins, error) {
+	rows, err := db.Query("SELECT id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur FROM soins")
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
+// SelectSoin returns the entry matching 'id'.
+func SelectSoin(tx DB, id IdSoin) (Soin, error) {
+	row := tx.QueryRow("SELECT id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur FROM soins WHERE id = $1", id)
+	return ScanSoin(row)
+}
+
+// SelectSoins returns the entry matching the given 'ids'.
+func SelectSoins(tx DB, ids ...IdSoin) (Soins, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur FROM soins WHERE id = ANY($1)", IdSoinArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
+type Soins map[IdSoin]Soin
+
+func (m Soins) IDs() []IdSoin {
+	out := make([]IdSoin, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanSoins(rs *sql.Rows) (Soins, error) {
+	var (
+		s   Soin
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Soins, 16)
+	for rs.Next() {
+		s, err = scanOneSoin(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Soin in the database and returns the item with id filled.
+func (item Soin) Insert(tx DB) (out Soin, err error) {
+	row := tx.QueryRow(`INSERT INTO soins (
+		idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+		) RETURNING id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur;
+		`, item.IdCamp, item.IdPersonne, item.Kind, item.Moment, item.Creneau, item.Medicament, item.Dose, item.Description, item.Auteur)
+	return ScanSoin(row)
+}
+
+// Update Soin in the database and returns the new version.
+func (item Soin) Update(tx DB) (out Soin, err error) {
+	row := tx.QueryRow(`UPDATE soins SET (
+		idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur
+		) = (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+		) WHERE id = $10 RETURNING id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur;
+		`, item.IdCamp, item.IdPersonne, item.Kind, item.Moment, item.Creneau, item.Medicament, item.Dose, item.Description, item.Auteur, item.Id)
+	return ScanSoin(row)
+}
+
+// Deletes the Soin and returns the item
+func DeleteSoinById(tx DB, id IdSoin) (Soin, error) {
+	row := tx.QueryRow("DELETE FROM soins WHERE id = $1 RETURNING id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur;", id)
+	return ScanSoin(row)
+}
+
+// Deletes the Soin in the database and returns the ids.
+func DeleteSoinsByIDs(tx DB, ids ...IdSoin) ([]IdSoin, error) {
+	rows, err := tx.Query("DELETE FROM soins WHERE id = ANY($1) RETURNING id", IdSoinArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdSoinArray(rows)
+}
+
+// ByIdCamp returns a map with 'IdCamp' as keys.
+func (items Soins) ByIdCamp() map[IdCamp]Soins {
+	out := make(map[IdCamp]Soins)
+	for _, target := range items {
+		dict := out[target.IdCamp]
+		if dict == nil {
+			dict = make(Soins)
+		}
+		dict[target.Id] = target
+		out[target.IdCamp] = dict
+	}
+	return out
+}
+
+// IdCamps returns the list of ids of IdCamp
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Soins) IdCamps() []IdCamp {
+	out := make([]IdCamp, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdCamp)
+	}
+	return out
+}
+
+func SelectSoinsByIdCamps(tx DB, idCamps_ ...IdCamp) (Soins, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur FROM soins WHERE idcamp = ANY($1)", IdCampArrayToPQ(idCamps_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
+func DeleteSoinsByIdCamps(tx DB, idCamps_ ...IdCamp) (Soins, error) {
+	rows, err := tx.Query("DELETE FROM soins WHERE idcamp = ANY($1) RETURNING id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur", IdCampArrayToPQ(idCamps_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
+// ByIdPersonne returns a map with 'IdPersonne' as keys.
+func (items Soins) ByIdPersonne() map[personnes.IdPersonne]Soins {
+	out := make(map[personnes.IdPersonne]Soins)
+	for _, target := range items {
+		dict := out[target.IdPersonne]
+		if dict == nil {
+			dict = make(Soins)
+		}
+		dict[target.Id] = target
+		out[target.IdPersonne] = dict
+	}
+	return out
+}
+
+// IdPersonnes returns the list of ids of IdPersonne
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Soins) IdPersonnes() []personnes.IdPersonne {
+	out := make([]personnes.IdPersonne, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdPersonne)
+	}
+	return out
+}
+
+func SelectSoinsByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Soins, error) {
+	rows, err := tx.Query("SELECT id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur FROM soins WHERE idpersonne = ANY($1)", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
+func DeleteSoinsByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Soins, error) {
+	rows, err := tx.Query("DELETE FROM soins WHERE idpersonne = ANY($1) RETURNING id, idcamp, idpersonne, kind, moment, creneau, medicament, dose, description, auteur", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSoins(rows)
+}
+
 func scanOneSondage(row scanner) (Sondage, error) {
 	var item Sondage
 	err := row.Scan(
@@ -2240,6 +2803,60 @@ func ScanIdCampArray(rs *sql.Rows) ([]IdCamp, error) {
 	return ints, nil
 }
 
+func IdCandidatureArrayToPQ(ids []IdCandidature) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdCandidatureArray scans the result of a query returning a
+// list of ID's.
+func ScanIdCandidatureArray(rs *sql.Rows) ([]IdCandidature, error) {
+	defer rs.Close()
+	ints := make([]IdCandidature, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdCandidature
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
+func IdDepenseArrayToPQ(ids []IdDepense) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdDepenseArray scans the result of a query returning a
+// list of ID's.
+func ScanIdDepenseArray(rs *sql.Rows) ([]IdDepense, error) {
+	defer rs.Close()
+	ints := make([]IdDepense, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdDepense
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func IdEquipierArrayToPQ(ids []IdEquipier) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -2348,6 +2965,33 @@ func ScanIdParticipantArray(rs *sql.Rows) ([]IdParticipant, error) {
 	return ints, nil
 }
 
+func IdSoinArrayToPQ(ids []IdSoin) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdSoinArray scans the result of a query returning a
+// list of ID's.
+func ScanIdSoinArray(rs *sql.Rows) ([]IdSoin, error) {
+	defer rs.Close()
+	ints := make([]IdSoin, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdSoin
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func IdSondageArrayToPQ(ids []IdSondage) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -2420,6 +3064,11 @@ func (s OptionPrixParticipant) Value() (driver.Value, error) { return dumpJSON(s
 func (s *Remises) Scan(src any) error          { return loadJSON(s, src) }
 func (s Remises) Value() (driver.Value, error) { return dumpJSON(s) }
 
+func SwitchCandidaturePersonne(db DB, target personnes.IdPersonne, temporaire personnes.IdPersonne) error {
+	_, err := db.Exec("UPDATE candidatures SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
+	return err
+}
+
 func SwitchEquipierPersonne(db DB, target personnes.IdPersonne, temporaire personnes.IdPersonne) error {
 	_, err := db.Exec("UPDATE equipiers SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
 	return err
@@ -2440,6 +3089,11 @@ func SwitchParticipantDossier(db DB, to dossiers.IdDossier, from dossiers.IdDoss
 	return err
 }
 
+func SwitchSoinPersonne(db DB, target personnes.IdPersonne, temporaire personnes.IdPersonne) error {
+	_, err := db.Exec("UPDATE soins SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
+	return err
+}
+
 func SwitchSondageDossier(db DB, to dossiers.IdDossier, from dossiers.IdDossier) error {
 	_, err := db.Exec("UPDATE sondages SET IdDossier = $1 WHERE IdDossier = $2;", to, from)
 	return err
@@ -261,6 +261,12 @@ func (c Camp) Label() string {
 
 func (cp *Camp) Plage() sh.Plage { return sh.Plage{From: cp.DateDebut, Duree: cp.Duree} }
 
+// Plage renvoie les dates de présence effectives,
+// à partir des dates [camp] du séjour.
+func (po PresenceOffsets) Plage(camp sh.Plage) sh.Plage {
+	return sh.Plage{From: camp.From.AddDays(po.Debut), Duree: camp.Duree - po.Debut + po.Fin}
+}
+
 // DateFin renvoie la date du dernier jour du camp
 func (cp *Camp) DateFin() sh.Date { return cp.Plage().To() }
 
@@ -484,3 +490,20 @@ func (ai Aide) Resolve(nbJours int) Montant {
 	}
 	return val
 }
+
+// IsModifiable renvoie `true` si la note de frais n'a pas encore
+// été traitée : l'équipier peut alors la modifier ou la supprimer.
+func (d Depense) IsModifiable() bool { return d.Statut == DepenseAttente }
+
+// IsCloturee renvoie `true` si la note de frais a été remboursée
+// ou convertie en don.
+func (d Depense) IsCloturee() bool {
+	return d.Statut == DepenseRemboursee || d.Statut == DepenseConvertie
+}
+
+// SelectDepenseForUpdate renvoie la note de frais [id], en verrouillant
+// la ligne jusqu'à la fin de la transaction.
+func SelectDepenseForUpdate(tx DB, id IdDepense) (Depense, error) {
+	row := tx.QueryRow("SELECT id, idequipier, categorie, montant, date, description, statut, commentaire, moment FROM depenses WHERE id = $1 FOR UPDATE", id)
+	return ScanDepense(row)
+}
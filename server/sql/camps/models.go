@@ -20,6 +20,9 @@ type (
 	IdEquipier      int64
 	IdStructureaide int64
 	IdAide          int64
+	IdSoin          int64
+	IdCandidature   int64
+	IdDepense       int64
 )
 
 // Camp
@@ -64,6 +67,14 @@ type Camp struct {
 	DocumentsReady  bool
 	DocumentsToShow DocumentsToShow
 
+	// ConformiteValidee est mise à true par le centre, après examen
+	// du rapport d'encadrement ; elle est requise pour [DocumentsReady].
+	ConformiteValidee bool
+	// ConformiteEmpreinte est l'empreinte du rapport validé : la validation
+	// n'est plus valable si l'équipe ou les inscrits modifient le rapport.
+	// Elle est vide pour les séjours validés avant son introduction.
+	ConformiteEmpreinte string
+
 	Vetements ListeVetements
 
 	// AlbumID est l'identifiant de l'album photos attribué
@@ -221,6 +232,27 @@ type Aide struct {
 	NbJoursMax int
 }
 
+// Soin est une entrée du registre de l'infirmerie d'un séjour
+// (cahier de soins) : administration d'un médicament ou incident.
+// Le registre est conservé après le séjour : il n'est pas lié
+// à l'inscription, mais à la personne.
+//
+// gomacro:QUERY SwitchSoinPersonne UPDATE Soin SET IdPersonne = $target$ WHERE IdPersonne = $temporaire$;
+type Soin struct {
+	Id         IdSoin
+	IdCamp     IdCamp        // intégrité : le registre n'est pas supprimé avec le séjour
+	IdPersonne pr.IdPersonne `gomacro-sql-on-delete:"CASCADE"`
+
+	Kind    SoinKind
+	Moment  time.Time
+	Creneau Creneau // pour une administration
+
+	Medicament  string // pour une administration
+	Dose        string
+	Description string // observations, soins apportés
+	Auteur      string // équipier ayant rempli l'entrée
+}
+
 // ---------------------------- Equipiers ----------------------------
 
 // Equipier représente un participant dans l'équipe d'un séjour
@@ -242,3 +274,53 @@ type Equipier struct {
 	// validation de la charte de l'asso
 	AccepteCharte sql.NullBool
 }
+
+// Candidature est une demande pour rejoindre l'équipe d'un séjour,
+// déposée sur le formulaire public "Devenir animateur".
+//
+// Le profil du candidat reste temporaire jusqu'à l'acceptation :
+// la fiche équipier est alors créée à partir de la candidature.
+//
+// gomacro:SQL ADD UNIQUE(IdCamp, IdPersonne)
+//
+// gomacro:QUERY SwitchCandidaturePersonne UPDATE Candidature SET IdPersonne = $target$ WHERE IdPersonne = $temporaire$;
+type Candidature struct {
+	Id         IdCandidature
+	IdCamp     IdCamp        `gomacro-sql-on-delete:"CASCADE"`
+	IdPersonne pr.IdPersonne `gomacro-sql-on-delete:"CASCADE"`
+
+	Role   Role // rôle souhaité
+	Statut StatutCandidature
+	Moment time.Time // dépôt de la candidature
+
+	Motivation string
+
+	// champs reportés sur la fiche équipier
+
+	Diplome                 pr.Diplome
+	Approfondissement       pr.Approfondissement
+	Formation               string
+	Profession              string
+	ExperienceTravailJeunes string
+}
+
+// Depense est une note de frais (ou une indemnité) déclarée
+// par un équipier depuis son espace.
+//
+// Les justificatifs sont enregistrés dans la table [files.FileDepense].
+// Une fois approuvée par le directeur, la dépense est remboursée
+// par le centre d'inscriptions, ou convertie en don
+// si l'équipier renonce au remboursement.
+type Depense struct {
+	Id         IdDepense
+	IdEquipier IdEquipier `gomacro-sql-on-delete:"CASCADE"`
+
+	Categorie   CategorieDepense
+	Montant     dossiers.Montant
+	Date        sh.Date // date de la dépense
+	Description string
+
+	Statut      StatutDepense
+	Commentaire string    // ajouté par le directeur (motif du refus)
+	Moment      time.Time // dépôt de la note
+}
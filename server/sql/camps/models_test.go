@@ -84,6 +84,66 @@ func TestSQL(t *testing.T) {
 		tu.AssertNoErr(t, err)
 	})
 
+	t.Run("soins", func(t *testing.T) {
+		soin := randSoin()
+		soin.IdCamp, soin.IdPersonne = camp1.Id, p2.Id
+		soin, err = soin.Insert(db)
+		tu.AssertNoErr(t, err)
+
+		soin.Kind = 3
+		_, err = soin.Update(db)
+		tu.AssertErr(t, err) // Kind invalide
+
+		soins, err := SelectSoinsByIdCamps(db, camp1.Id)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, len(soins) == 1)
+
+		err = SwitchSoinPersonne(db, p1.Id, p2.Id)
+		tu.AssertNoErr(t, err)
+		soins, err = SelectSoinsByIdPersonnes(db, p1.Id)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, len(soins) == 1)
+	})
+
+	t.Run("candidatures", func(t *testing.T) {
+		candidature := randCandidature()
+		candidature.IdCamp, candidature.IdPersonne = camp1.Id, p2.Id
+		candidature, err = candidature.Insert(db)
+		tu.AssertNoErr(t, err)
+
+		_, err = candidature.Insert(db)
+		tu.AssertErr(t, err) // une seule candidature par séjour
+
+		candidature.Statut = 4
+		_, err = candidature.Update(db)
+		tu.AssertErr(t, err) // Statut invalide
+
+		err = SwitchCandidaturePersonne(db, p1.Id, p2.Id)
+		tu.AssertNoErr(t, err)
+		_, found, err := SelectCandidatureByIdCampAndIdPersonne(db, camp1.Id, p1.Id)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, found)
+	})
+
+	t.Run("depenses", func(t *testing.T) {
+		equipiers, err := SelectEquipiersByIdCamps(db, camp1.Id)
+		tu.AssertNoErr(t, err)
+		equipier, _ := equipiers.Directeur()
+
+		depense := randDepense()
+		depense.IdEquipier = equipier.Id
+		depense, err = depense.Insert(db)
+		tu.AssertNoErr(t, err)
+
+		depense.Statut = 5
+		_, err = depense.Update(db)
+		tu.AssertErr(t, err) // Statut invalide
+
+		depenses, err := SelectDepensesByIdEquipiers(db, equipier.Id)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, len(depenses) == 1)
+	})
+
 	t.Run("dossiers et taux", func(t *testing.T) {
 		camp2 := randCamp()
 		camp2.IdTaux = defautTaux.Id
@@ -411,6 +411,55 @@ const (
 	Answered                           // Répondu
 )
 
+// StatutCandidature est l'état d'avancement d'une [Candidature].
+type StatutCandidature uint8
+
+const (
+	CandidatureAttente StatutCandidature = iota // En attente
+	Preselectionnee                             // Présélectionnée
+	Acceptee                                    // Acceptée
+	Refusee                                     // Refusée
+)
+
+// CategorieDepense précise la nature d'une [Depense].
+type CategorieDepense uint8
+
+const (
+	DepenseNourriture CategorieDepense = iota // Nourriture
+	DepenseTransport                          // Transport
+	DepenseMateriel                           // Matériel
+	DepenseIndemnite                          // Indemnité
+	DepenseAutre                              // Autre
+)
+
+func (c CategorieDepense) String() string {
+	switch c {
+	case DepenseNourriture:
+		return "Nourriture"
+	case DepenseTransport:
+		return "Transport"
+	case DepenseMateriel:
+		return "Matériel"
+	case DepenseIndemnite:
+		return "Indemnité"
+	case DepenseAutre:
+		return "Autre"
+	default:
+		return fmt.Sprintf("<catégorie inconnue %d>", c)
+	}
+}
+
+// StatutDepense est l'état d'avancement d'une [Depense].
+type StatutDepense uint8
+
+const (
+	DepenseAttente    StatutDepense = iota // En attente
+	DepenseApprouvee                       // Approuvée
+	DepenseRefusee                         // Refusée
+	DepenseRemboursee                      // Remboursée
+	DepenseConvertie                       // Convertie en don
+)
+
 // PresenceOffsets encode une différence par rapport
 // à une plage de référence (celle du séjour).
 //
@@ -467,3 +516,49 @@ const (
 	Oui                                // Oui
 	Non                                // Non
 )
+
+// SoinKind distingue les entrées du registre de l'infirmerie.
+type SoinKind uint8
+
+const (
+	Administration SoinKind = iota // Administration d'un médicament
+	Incident                       // Incident ou soin
+)
+
+func (k SoinKind) String() string {
+	switch k {
+	case Administration:
+		return "Administration d'un médicament"
+	case Incident:
+		return "Incident ou soin"
+	default:
+		return ""
+	}
+}
+
+// Creneau est le moment de la journée pour la prise d'un médicament.
+type Creneau uint8
+
+const (
+	Matin   Creneau = iota // Matin
+	Midi                   // Midi
+	Soir                   // Soir
+	Coucher                // Coucher
+)
+
+const NbCreneaux = Coucher + 1 // gomacro:no-enum
+
+func (c Creneau) String() string {
+	switch c {
+	case Matin:
+		return "Matin"
+	case Midi:
+		return "Midi"
+	case Soir:
+		return "Soir"
+	case Coucher:
+		return "Coucher"
+	default:
+		return ""
+	}
+}
@@ -4,7 +4,7 @@ CREATE TABLE dons (
     IdPersonne integer,
     IdOrganisme integer,
     Montant Montant NOT NULL,
-    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5)) NOT NULL,
+    ModePaiement smallint CHECK (ModePaiement IN (0, 1, 2, 3, 4, 5, 6)) NOT NULL,
     Date date NOT NULL,
     Affectation text NOT NULL,
     Details text NOT NULL,
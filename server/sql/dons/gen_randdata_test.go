@@ -58,7 +58,7 @@ func randdos_Currency() dossiers.Currency {
 }
 
 func randdos_ModePaiement() dossiers.ModePaiement {
-	choix := [...]dossiers.ModePaiement{dossiers.Cheque, dossiers.EnLigne, dossiers.Virement, dossiers.Especes, dossiers.Ancv, dossiers.Helloasso}
+	choix := [...]dossiers.ModePaiement{dossiers.Cheque, dossiers.EnLigne, dossiers.Virement, dossiers.Especes, dossiers.Ancv, dossiers.Helloasso, dossiers.AbandonFrais}
 	i := rand.Intn(len(choix))
 	return choix[i]
 }
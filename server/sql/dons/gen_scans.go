@@ -359,3 +359,8 @@ func ScanIdOrganismeArray(rs *sql.Rows) ([]IdOrganisme, error) {
 	}
 	return ints, nil
 }
+
+func SwitchDonPersonne(db DB, target personnes.OptIdPersonne, from personnes.OptIdPersonne) error {
+	_, err := db.Exec("UPDATE dons SET IdPersonne = $1 WHERE IdPersonne = $2;", target, from)
+	return err
+}
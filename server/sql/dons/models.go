@@ -16,6 +16,7 @@ type (
 //
 // gomacro:SQL ADD CHECK(IdPersonne <> null OR IdOrganisme <> null)
 // gomacro:SQL ADD CHECK(IdPersonne = null OR IdOrganisme = null)
+// gomacro:QUERY SwitchDonPersonne UPDATE Don SET IdPersonne = $target$ WHERE IdPersonne = $from$;
 
 type Don struct {
 	Id IdDon
@@ -10,6 +10,8 @@ CREATE TABLE dossiers (
     Id serial PRIMARY KEY,
     IdTaux integer NOT NULL,
     IdResponsable integer NOT NULL,
+    IdResponsable2 integer,
+    AccesResponsable2 smallint CHECK (AccesResponsable2 IN (0, 1)) NOT NULL,
     CopiesMails text[],
     PartageAdressesOK boolean NOT NULL,
     DemandeFondSoutien boolean NOT NULL,
@@ -53,6 +55,9 @@ ALTER TABLE dossiers
 ALTER TABLE dossiers
     ADD FOREIGN KEY (IdResponsable) REFERENCES personnes;
 
+ALTER TABLE dossiers
+    ADD FOREIGN KEY (IdResponsable2) REFERENCES personnes ON DELETE SET NULL;
+
 ALTER TABLE paiements
     ADD FOREIGN KEY (IdDossier) REFERENCES dossiers ON DELETE CASCADE;
 
@@ -3,11 +3,18 @@ package dossiers
 import (
 	"math/rand"
 	"registro/sql/personnes"
+	"registro/sql/shared"
 	"time"
 )
 
 // Code generated by gomacro/generator/go/randdata. DO NOT EDIT.
 
+func randAcces() Acces {
+	choix := [...]Acces{LectureSeule, AccesComplet}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randCurrency() Currency {
 	choix := [...]Currency{Euros, FrancsSuisse}
 	i := rand.Intn(len(choix))
@@ -19,6 +26,8 @@ func randDossier() Dossier {
 	s.Id = randIdDossier()
 	s.IdTaux = randIdTaux()
 	s.IdResponsable = randper_IdPersonne()
+	s.IdResponsable2 = randsha_OptID_per_IdPersonne()
+	s.AccesResponsable2 = randAcces()
 	s.CopiesMails = randper_Mails()
 	s.PartageAdressesOK = randbool()
 	s.DemandeFondSoutien = randbool()
@@ -112,6 +121,14 @@ func randper_Mails() personnes.Mails {
 
 var letterRunes2 = []rune("azertyuiopqsdfghjklmwxcvbn123456789é@!?&èïab ")
 
+func randsha_OptID_per_IdPersonne() shared.OptID[personnes.IdPersonne] {
+	var s shared.OptID[personnes.IdPersonne]
+	s.Id = randper_IdPersonne()
+	s.Valid = randbool()
+
+	return s
+}
+
 func randstring() string {
 	b := make([]rune, 10)
 	maxLength := len(letterRunes2)
@@ -34,6 +34,8 @@ func scanOneDossier(row scanner) (Dossier, error) {
 		&item.Id,
 		&item.IdTaux,
 		&item.IdResponsable,
+		&item.IdResponsable2,
+		&item.AccesResponsable2,
 		&item.CopiesMails,
 		&item.PartageAdressesOK,
 		&item.DemandeFondSoutien,
@@ -48,7 +50,7 @@ func ScanDossier(row *sql.Row) (Dossier, error) { return scanOneDossier(row) }
 
 // SelectAll returns all the items in the dossiers table.
 func SelectAllDossiers(db DB) (Dossiers, error) {
-	rows, err := db.Query("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers")
+	rows, err := db.Query("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers")
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +59,13 @@ func SelectAllDossiers(db DB) (Dossiers, error) {
 
 // SelectDossier returns the entry matching 'id'.
 func SelectDossier(tx DB, id IdDossier) (Dossier, error) {
-	row := tx.QueryRow("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE id = $1", id)
 	return ScanDossier(row)
 }
 
 // SelectDossiers returns the entry matching the given 'ids'.
 func SelectDossiers(tx DB, ids ...IdDossier) (Dossiers, error) {
-	rows, err := tx.Query("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE id = ANY($1)", IdDossierArrayToPQ(ids))
+	rows, err := tx.Query("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE id = ANY($1)", IdDossierArrayToPQ(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -108,28 +110,28 @@ func ScanDossiers(rs *sql.Rows) (Dossiers, error) {
 // Insert one Dossier in the database and returns the item with id filled.
 func (item Dossier) Insert(tx DB) (out Dossier, err error) {
 	row := tx.QueryRow(`INSERT INTO dossiers (
-		idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1
+		idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1
 		) VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8
-		) RETURNING id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;
-		`, item.IdTaux, item.IdResponsable, item.CopiesMails, item.PartageAdressesOK, item.DemandeFondSoutien, item.MomentInscription, item.LastLoadDocuments, item.KeyV1)
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;
+		`, item.IdTaux, item.IdResponsable, item.IdResponsable2, item.AccesResponsable2, item.CopiesMails, item.PartageAdressesOK, item.DemandeFondSoutien, item.MomentInscription, item.LastLoadDocuments, item.KeyV1)
 	return ScanDossier(row)
 }
 
 // Update Dossier in the database and returns the new version.
 func (item Dossier) Update(tx DB) (out Dossier, err error) {
 	row := tx.QueryRow(`UPDATE dossiers SET (
-		idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1
+		idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1
 		) = (
-		$1, $2, $3, $4, $5, $6, $7, $8
-		) WHERE id = $9 RETURNING id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;
-		`, item.IdTaux, item.IdResponsable, item.CopiesMails, item.PartageAdressesOK, item.DemandeFondSoutien, item.MomentInscription, item.LastLoadDocuments, item.KeyV1, item.Id)
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) WHERE id = $11 RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;
+		`, item.IdTaux, item.IdResponsable, item.IdResponsable2, item.AccesResponsable2, item.CopiesMails, item.PartageAdressesOK, item.DemandeFondSoutien, item.MomentInscription, item.LastLoadDocuments, item.KeyV1, item.Id)
 	return ScanDossier(row)
 }
 
 // Deletes the Dossier and returns the item
 func DeleteDossierById(tx DB, id IdDossier) (Dossier, error) {
-	row := tx.QueryRow("DELETE FROM dossiers WHERE id = $1 RETURNING id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;", id)
+	row := tx.QueryRow("DELETE FROM dossiers WHERE id = $1 RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1;", id)
 	return ScanDossier(row)
 }
 
@@ -168,7 +170,7 @@ func (items Dossiers) IdTauxs() []IdTaux {
 }
 
 func SelectDossiersByIdTauxs(tx DB, idTauxs_ ...IdTaux) (Dossiers, error) {
-	rows, err := tx.Query("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE idtaux = ANY($1)", IdTauxArrayToPQ(idTauxs_))
+	rows, err := tx.Query("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE idtaux = ANY($1)", IdTauxArrayToPQ(idTauxs_))
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +178,7 @@ func SelectDossiersByIdTauxs(tx DB, idTauxs_ ...IdTaux) (Dossiers, error) {
 }
 
 func DeleteDossiersByIdTauxs(tx DB, idTauxs_ ...IdTaux) (Dossiers, error) {
-	rows, err := tx.Query("DELETE FROM dossiers WHERE idtaux = ANY($1) RETURNING id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1", IdTauxArrayToPQ(idTauxs_))
+	rows, err := tx.Query("DELETE FROM dossiers WHERE idtaux = ANY($1) RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1", IdTauxArrayToPQ(idTauxs_))
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +211,7 @@ func (items Dossiers) IdResponsables() []personnes.IdPersonne {
 }
 
 func SelectDossiersByIdResponsables(tx DB, idResponsables_ ...personnes.IdPersonne) (Dossiers, error) {
-	rows, err := tx.Query("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE idresponsable = ANY($1)", personnes.IdPersonneArrayToPQ(idResponsables_))
+	rows, err := tx.Query("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE idresponsable = ANY($1)", personnes.IdPersonneArrayToPQ(idResponsables_))
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +219,36 @@ func SelectDossiersByIdResponsables(tx DB, idResponsables_ ...personnes.IdPerson
 }
 
 func DeleteDossiersByIdResponsables(tx DB, idResponsables_ ...personnes.IdPersonne) (Dossiers, error) {
-	rows, err := tx.Query("DELETE FROM dossiers WHERE idresponsable = ANY($1) RETURNING id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1", personnes.IdPersonneArrayToPQ(idResponsables_))
+	rows, err := tx.Query("DELETE FROM dossiers WHERE idresponsable = ANY($1) RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1", personnes.IdPersonneArrayToPQ(idResponsables_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanDossiers(rows)
+}
+
+// IdResponsable2s returns the list of non null IdResponsable2
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Dossiers) IdResponsable2s() []personnes.IdPersonne {
+	var out []personnes.IdPersonne
+	for _, target := range items {
+		if id := target.IdResponsable2; id.Valid {
+			out = append(out, id.Id)
+		}
+	}
+	return out
+}
+
+func SelectDossiersByIdResponsable2s(tx DB, idResponsable2s_ ...personnes.IdPersonne) (Dossiers, error) {
+	rows, err := tx.Query("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE idresponsable2 = ANY($1)", personnes.IdPersonneArrayToPQ(idResponsable2s_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanDossiers(rows)
+}
+
+func DeleteDossiersByIdResponsable2s(tx DB, idResponsable2s_ ...personnes.IdPersonne) (Dossiers, error) {
+	rows, err := tx.Query("DELETE FROM dossiers WHERE idresponsable2 = ANY($1) RETURNING id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1", personnes.IdPersonneArrayToPQ(idResponsable2s_))
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +257,7 @@ func DeleteDossiersByIdResponsables(tx DB, idResponsables_ ...personnes.IdPerson
 
 // SelectDossierByIdAndIdTaux return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectDossierByIdAndIdTaux(tx DB, id IdDossier, idTaux IdTaux) (item Dossier, found bool, err error) {
-	row := tx.QueryRow("SELECT id, idtaux, idresponsable, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE Id = $1 AND IdTaux = $2", id, idTaux)
+	row := tx.QueryRow("SELECT id, idtaux, idresponsable, idresponsable2, accesresponsable2, copiesmails, partageadressesok, demandefondsoutien, momentinscription, lastloaddocuments, keyv1 FROM dossiers WHERE Id = $1 AND IdTaux = $2", id, idTaux)
 	item, err = ScanDossier(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -642,6 +673,11 @@ func SwitchDossierPersonne(db DB, target personnes.IdPersonne, temporaire person
 	return err
 }
 
+func SwitchDossierResponsable2Personne(db DB, target personnes.OptIdPersonne, temporaire personnes.OptIdPersonne) error {
+	_, err := db.Exec("UPDATE dossiers SET IdResponsable2 = $1 WHERE IdResponsable2 = $2;", target, temporaire)
+	return err
+}
+
 func SwitchPaiementDossier(db DB, to IdDossier, from IdDossier) error {
 	_, err := db.Exec("UPDATE paiements SET IdDossier = $1 WHERE IdDossier = $2;", to, from)
 	return err
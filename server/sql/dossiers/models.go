@@ -44,12 +44,20 @@ type Taux struct {
 // gomacro:SQL ADD UNIQUE(Id, IdTaux)
 //
 // gomacro:QUERY SwitchDossierPersonne UPDATE Dossier SET IdResponsable = $target$ WHERE IdResponsable = $temporaire$;
+// gomacro:QUERY SwitchDossierResponsable2Personne UPDATE Dossier SET IdResponsable2 = $target$ WHERE IdResponsable2 = $temporaire$;
 type Dossier struct {
 	Id            IdDossier
 	IdTaux        IdTaux
 	IdResponsable pr.IdPersonne // responsable légal en charge du dossier
 	// IdTaux is used for consistency
 
+	// IdResponsable2 est un second responsable légal (optionnel),
+	// disposant de son propre lien vers l'espace personnel.
+	IdResponsable2 pr.OptIdPersonne `gomacro-sql-on-delete:"SET NULL" gomacro-sql-foreign:"Personne"`
+	// AccesResponsable2 définit les droits du second responsable
+	// sur l'espace personnel.
+	AccesResponsable2 Acces
+
 	// CopiesMails est une liste d'adresse en copies des mails envoyés,
 	// donnant entre autre accès à l'espace personnel
 	CopiesMails pr.Mails
@@ -10,6 +10,15 @@ type OptIdDossier = shared.OptID[IdDossier]
 
 func (id IdDossier) Opt() OptIdDossier { return OptIdDossier{Id: id, Valid: true} }
 
+// Acces définit les droits du second responsable légal
+// sur l'espace personnel.
+type Acces uint8
+
+const (
+	LectureSeule Acces = iota // Lecture seule
+	AccesComplet              // Accès complet
+)
+
 // Mode de paiement
 type ModePaiement uint8
 
@@ -20,7 +29,8 @@ const (
 	Especes                      // Espèces
 	Ancv                         // ANCV
 	// uniquement pour les dons
-	Helloasso // Helloasso
+	Helloasso    // Helloasso
+	AbandonFrais // Abandon de frais
 )
 
 func (mp ModePaiement) String() string {
@@ -37,6 +47,8 @@ func (mp ModePaiement) String() string {
 		return "ANCV"
 	case Helloasso:
 		return "Helloasso"
+	case AbandonFrais:
+		return "Abandon de frais"
 	default:
 		return "unknown ModePaiement"
 	}
@@ -28,6 +28,7 @@ CREATE TABLE event_messages (
     VuEspaceperso boolean NOT NULL,
     VuFondSoutien boolean NOT NULL,
     OnlyToFondSoutien boolean NOT NULL,
+    OrigineResponsable2 boolean NOT NULL,
     guard smallint NOT NULL
 );
 
@@ -101,6 +102,11 @@ ALTER TABLE event_messages
     /* Acteur.Directeur */
         OR OrigineCamp IS NULL);
 
+ALTER TABLE event_messages
+    ADD CHECK (Origine = 0
+    /* Acteur.Espaceperso */
+        OR OrigineResponsable2 = FALSE);
+
 ALTER TABLE event_messages
     ADD FOREIGN KEY (IdEvent) REFERENCES events ON DELETE CASCADE;
 
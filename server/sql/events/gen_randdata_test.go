@@ -65,6 +65,7 @@ func randEventMessage() EventMessage {
 	s.VuEspaceperso = randbool()
 	s.VuFondSoutien = randbool()
 	s.OnlyToFondSoutien = randbool()
+	s.OrigineResponsable2 = randbool()
 
 	return s
 }
@@ -473,6 +473,7 @@ func scanOneEventMessage(row scanner) (EventMessage, error) {
 		&item.VuEspaceperso,
 		&item.VuFondSoutien,
 		&item.OnlyToFondSoutien,
+		&item.OrigineResponsable2,
 	)
 	return item, err
 }
@@ -481,7 +482,7 @@ func ScanEventMessage(row *sql.Row) (EventMessage, error) { return scanOneEventM
 
 // SelectAll returns all the items in the event_messages table.
 func SelectAllEventMessages(db DB) (EventMessages, error) {
-	rows, err := db.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien FROM event_messages")
+	rows, err := db.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2 FROM event_messages")
 	if err != nil {
 		return nil, err
 	}
@@ -517,11 +518,11 @@ func ScanEventMessages(rs *sql.Rows) (EventMessages, error) {
 
 func (item EventMessage) Insert(db DB) error {
 	_, err := db.Exec(`INSERT INTO event_messages (
-			idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien
+			idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2
 			) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
 			);
-			`, item.IdEvent, item.Contenu, item.Origine, item.OrigineCamp, item.VuBackoffice, item.VuEspaceperso, item.VuFondSoutien, item.OnlyToFondSoutien)
+			`, item.IdEvent, item.Contenu, item.Origine, item.OrigineCamp, item.VuBackoffice, item.VuEspaceperso, item.VuFondSoutien, item.OnlyToFondSoutien, item.OrigineResponsable2)
 	if err != nil {
 		return err
 	}
@@ -544,13 +545,14 @@ func InsertManyEventMessages(tx *sql.Tx, items ...EventMessage) error {
 		"vuespaceperso",
 		"vufondsoutien",
 		"onlytofondsoutien",
+		"origineresponsable2",
 	))
 	if err != nil {
 		return err
 	}
 
 	for _, item := range items {
-		_, err = stmt.Exec(item.IdEvent, item.Contenu, item.Origine, item.OrigineCamp, item.VuBackoffice, item.VuEspaceperso, item.VuFondSoutien, item.OnlyToFondSoutien)
+		_, err = stmt.Exec(item.IdEvent, item.Contenu, item.Origine, item.OrigineCamp, item.VuBackoffice, item.VuEspaceperso, item.VuFondSoutien, item.OnlyToFondSoutien, item.OrigineResponsable2)
 		if err != nil {
 			return err
 		}
@@ -776,7 +778,7 @@ func (items EventMessages) IdEvents() []IdEvent {
 
 // SelectEventMessageByIdEvent return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectEventMessageByIdEvent(tx DB, idEvent IdEvent) (item EventMessage, found bool, err error) {
-	row := tx.QueryRow("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien FROM event_messages WHERE idevent = $1", idEvent)
+	row := tx.QueryRow("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2 FROM event_messages WHERE idevent = $1", idEvent)
 	item, err = ScanEventMessage(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -785,7 +787,7 @@ func SelectEventMessageByIdEvent(tx DB, idEvent IdEvent) (item EventMessage, fou
 }
 
 func SelectEventMessagesByIdEvents(tx DB, idEvents_ ...IdEvent) (EventMessages, error) {
-	rows, err := tx.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien FROM event_messages WHERE idevent = ANY($1)", IdEventArrayToPQ(idEvents_))
+	rows, err := tx.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2 FROM event_messages WHERE idevent = ANY($1)", IdEventArrayToPQ(idEvents_))
 	if err != nil {
 		return nil, err
 	}
@@ -793,7 +795,7 @@ func SelectEventMessagesByIdEvents(tx DB, idEvents_ ...IdEvent) (EventMessages,
 }
 
 func DeleteEventMessagesByIdEvents(tx DB, idEvents_ ...IdEvent) (EventMessages, error) {
-	rows, err := tx.Query("DELETE FROM event_messages WHERE idevent = ANY($1) RETURNING idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien", IdEventArrayToPQ(idEvents_))
+	rows, err := tx.Query("DELETE FROM event_messages WHERE idevent = ANY($1) RETURNING idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2", IdEventArrayToPQ(idEvents_))
 	if err != nil {
 		return nil, err
 	}
@@ -814,7 +816,7 @@ func (items EventMessages) OrigineCamps() []camps.IdCamp {
 }
 
 func SelectEventMessagesByOrigineCamps(tx DB, origineCamps_ ...camps.IdCamp) (EventMessages, error) {
-	rows, err := tx.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien FROM event_messages WHERE originecamp = ANY($1)", camps.IdCampArrayToPQ(origineCamps_))
+	rows, err := tx.Query("SELECT idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2 FROM event_messages WHERE originecamp = ANY($1)", camps.IdCampArrayToPQ(origineCamps_))
 	if err != nil {
 		return nil, err
 	}
@@ -822,7 +824,7 @@ func SelectEventMessagesByOrigineCamps(tx DB, origineCamps_ ...camps.IdCamp) (Ev
 }
 
 func DeleteEventMessagesByOrigineCamps(tx DB, origineCamps_ ...camps.IdCamp) (EventMessages, error) {
-	rows, err := tx.Query("DELETE FROM event_messages WHERE originecamp = ANY($1) RETURNING idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien", camps.IdCampArrayToPQ(origineCamps_))
+	rows, err := tx.Query("DELETE FROM event_messages WHERE originecamp = ANY($1) RETURNING idevent, contenu, origine, originecamp, vubackoffice, vuespaceperso, vufondsoutien, onlytofondsoutien, origineresponsable2", camps.IdCampArrayToPQ(origineCamps_))
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +44,7 @@ type EventValidation struct {
 //
 // gomacro:SQL ADD CHECK(Origine <> #[Acteur.Directeur] OR OrigineCamp IS NOT NULL)
 // gomacro:SQL ADD CHECK(Origine = #[Acteur.Directeur] OR OrigineCamp IS NULL)
+// gomacro:SQL ADD CHECK(Origine = #[Acteur.Espaceperso] OR OrigineResponsable2 = false)
 type EventMessage struct {
 	IdEvent IdEvent `gomacro-sql-on-delete:"CASCADE"`
 
@@ -60,6 +61,11 @@ type EventMessage struct {
 	// Ce champ n'est utilisé que pour les messages avec Origine == Espaceperso
 	OnlyToFondSoutien bool
 
+	// OrigineResponsable2 est true si le message a été envoyé
+	// par le second responsable légal du dossier.
+	// Ce champ n'est utilisé que pour les messages avec Origine == Espaceperso
+	OrigineResponsable2 bool
+
 	guard EventKind `gomacro-sql-guard:"#[EventKind.Message]"`
 }
 
@@ -0,0 +1,250 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"registro/config"
+)
+
+// Format d'un fichier chiffré :
+//
+//	magic | empreinte de la clé maître (8) | nonce (12) | clé de données chiffrée (48) | contenu
+//
+// Le contenu est découpé en blocs de [encChunkSize] octets, chiffrés
+// indépendamment (AES-GCM) avec la clé de données, ce qui permet
+// de déchiffrer au fil de la lecture.
+const (
+	encMagic     = "RGENC1"
+	encChunkSize = 64 * 1024
+
+	fingerprintSize = 8
+	wrappedKeySize  = 32 + 16
+	encHeaderSize   = len(encMagic) + fingerprintSize + 12 + wrappedKeySize
+)
+
+type masterKey struct {
+	key         [32]byte
+	fingerprint [fingerprintSize]byte
+}
+
+// newMasterKey dérive la clé maître de [password],
+// comme [crypto.NewEncrypter]
+func newMasterKey(password string) masterKey {
+	key := sha256.Sum256([]byte(password))
+	fp := sha256.Sum256(key[:])
+	return masterKey{key, [fingerprintSize]byte(fp[:fingerprintSize])}
+}
+
+func newGCM(key []byte) cipher.AEAD {
+	block, _ := aes.NewCipher(key) // key has always a valid size
+	aead, _ := cipher.NewGCM(block)
+	return aead
+}
+
+// EncryptedStorage chiffre le contenu des fichiers (chiffrement
+// par enveloppe) : chaque fichier utilise une clé de données aléatoire,
+// elle-même chiffrée par la clé maître et stockée en en-tête.
+//
+// Les fichiers non chiffrés (antérieurs à l'activation) sont lus tels quels.
+// Les clés maîtres précédentes permettent de lire les fichiers
+// pas encore migrés après une rotation (voir [EncryptedStorage.Upgrade]).
+type EncryptedStorage struct {
+	storage  Storage
+	current  masterKey
+	previous []masterKey
+}
+
+// NewEncryptedStorage chiffre le contenu stocké dans [storage].
+// [cfg.MasterKey] ne doit pas être vide.
+func NewEncryptedStorage(storage Storage, cfg config.FilesEncryption) EncryptedStorage {
+	out := EncryptedStorage{storage: storage, current: newMasterKey(cfg.MasterKey)}
+	for _, key := range cfg.PreviousKeys {
+		out.previous = append(out.previous, newMasterKey(key))
+	}
+	return out
+}
+
+// wrap renvoie l'en-tête contenant [dataKey] chiffrée
+func (es EncryptedStorage) wrap(dataKey []byte) []byte {
+	var nonce [12]byte
+	rand.Read(nonce[:])
+	header := make([]byte, 0, encHeaderSize)
+	header = append(header, encMagic...)
+	header = append(header, es.current.fingerprint[:]...)
+	header = append(header, nonce[:]...)
+	return newGCM(es.current.key[:]).Seal(header, nonce[:], dataKey, nil)
+}
+
+// unwrap renvoie la clé de données contenue dans [header],
+// et indique si elle est chiffrée avec la clé maître courante.
+func (es EncryptedStorage) unwrap(header []byte) (dataKey []byte, isCurrent bool, _ error) {
+	fp := [fingerprintSize]byte(header[len(encMagic):])
+	nonce := header[len(encMagic)+fingerprintSize : len(encMagic)+fingerprintSize+12]
+	wrapped := header[len(encMagic)+fingerprintSize+12:]
+	for i, key := range append([]masterKey{es.current}, es.previous...) {
+		if key.fingerprint != fp {
+			continue
+		}
+		dataKey, err := newGCM(key.key[:]).Open(nil, nonce, wrapped, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid data key: %s", err)
+		}
+		return dataKey, i == 0, nil
+	}
+	return nil, false, errors.New("unknown master key")
+}
+
+func chunkNonce(counter uint64, last bool) []byte {
+	var nonce [12]byte
+	binary.BigEndian.PutUint64(nonce[:], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce[:]
+}
+
+func encryptContent(dataKey, content []byte) []byte {
+	aead := newGCM(dataKey)
+	out := make([]byte, 0, len(content)+(len(content)/encChunkSize+1)*aead.Overhead())
+	for counter := uint64(0); ; counter++ {
+		chunk := content[:min(encChunkSize, len(content))]
+		content = content[len(chunk):]
+		last := len(content) == 0
+		out = aead.Seal(out, chunkNonce(counter, last), chunk, nil)
+		if last {
+			return out
+		}
+	}
+}
+
+// decryptReader déchiffre le contenu bloc par bloc
+type decryptReader struct {
+	aead   cipher.AEAD
+	src    *bufio.Reader
+	closer io.Closer
+
+	counter uint64
+	pending []byte // déchiffré mais pas encore lu
+	done    bool
+}
+
+func (dr *decryptReader) readChunk() error {
+	chunk := make([]byte, encChunkSize+dr.aead.Overhead())
+	n, err := io.ReadFull(dr.src, chunk)
+	last := false
+	switch err {
+	case nil: // le bloc est le dernier si plus rien ne suit
+		if _, err := dr.src.Peek(1); err == io.EOF {
+			last = true
+		}
+	case io.ErrUnexpectedEOF:
+		last = true
+	case io.EOF:
+		return errors.New("truncated encrypted file")
+	default:
+		return err
+	}
+	plain, err := dr.aead.Open(chunk[:0], chunkNonce(dr.counter, last), chunk[:n], nil)
+	if err != nil {
+		return errors.New("corrupted encrypted file")
+	}
+	dr.counter++
+	dr.pending, dr.done = plain, last
+	return nil
+}
+
+func (dr *decryptReader) Read(p []byte) (int, error) {
+	for len(dr.pending) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.pending)
+	dr.pending = dr.pending[n:]
+	return n, nil
+}
+
+func (dr *decryptReader) Close() error { return dr.closer.Close() }
+
+type plainReader struct {
+	*bufio.Reader
+	io.Closer
+}
+
+func (es EncryptedStorage) Put(key string, content []byte) error {
+	var dataKey [32]byte
+	rand.Read(dataKey[:])
+	data := append(es.wrap(dataKey[:]), encryptContent(dataKey[:], content)...)
+	return es.storage.Put(key, data)
+}
+
+// Get déchiffre le contenu au fil de la lecture.
+func (es EncryptedStorage) Get(key string) (io.ReadCloser, error) {
+	r, err := es.storage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	src := bufio.NewReaderSize(r, encChunkSize+32)
+	if magic, _ := src.Peek(len(encMagic)); string(magic) != encMagic {
+		return plainReader{src, r}, nil // fichier non chiffré
+	}
+	header := make([]byte, encHeaderSize)
+	if _, err = io.ReadFull(src, header); err != nil {
+		r.Close()
+		return nil, fmt.Errorf("invalid encrypted file: %s", err)
+	}
+	dataKey, _, err := es.unwrap(header)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return &decryptReader{aead: newGCM(dataKey), src: src, closer: r}, nil
+}
+
+func (es EncryptedStorage) Delete(key string) error { return es.storage.Delete(key) }
+
+func (es EncryptedStorage) Keys() ([]string, error) { return es.storage.Keys() }
+
+// Upgrade chiffre le fichier [key] s'il est stocké en clair,
+// ou chiffre à nouveau sa clé de données avec la clé maître courante
+// si besoin (le contenu n'est alors pas déchiffré).
+// Renvoie true si le fichier a été modifié.
+func (es EncryptedStorage) Upgrade(key string) (bool, error) {
+	r, err := es.storage.Get(key)
+	if err != nil {
+		return false, err
+	}
+	raw, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.HasPrefix(raw, []byte(encMagic)) {
+		return true, es.Put(key, raw)
+	}
+	if len(raw) < encHeaderSize {
+		return false, errors.New("invalid encrypted file")
+	}
+	dataKey, isCurrent, err := es.unwrap(raw[:encHeaderSize])
+	if err != nil {
+		return false, err
+	}
+	if isCurrent {
+		return false, nil
+	}
+	data := append(es.wrap(dataKey), raw[encHeaderSize:]...)
+	return true, es.storage.Put(key, data)
+}
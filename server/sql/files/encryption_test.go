@@ -0,0 +1,91 @@
+package files
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"registro/config"
+	tu "registro/utils/testutils"
+)
+
+func readAll(t *testing.T, storage Storage, key string) []byte {
+	r, err := storage.Get(key)
+	tu.AssertNoErr(t, err)
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	tu.AssertNoErr(t, err)
+	return content
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	local := NewLocalStorage(t.TempDir())
+	storage := NewEncryptedStorage(local, config.FilesEncryption{MasterKey: "secret"})
+	testStorage(t, storage)
+
+	big := make([]byte, 3*encChunkSize+17)
+	rand.Read(big)
+	for _, content := range [][]byte{nil, []byte("court"), big[:encChunkSize], big[:2*encChunkSize], big} {
+		tu.AssertNoErr(t, storage.Put("file", content))
+		tu.Assert(t, bytes.Equal(readAll(t, storage, "file"), content))
+		raw := readAll(t, local, "file")
+		tu.Assert(t, !bytes.Contains(raw, []byte("court")))
+	}
+
+	// altération et troncature
+	raw := readAll(t, local, "file")
+	tu.AssertNoErr(t, local.Put("file", raw[:len(raw)-20]))
+	r, err := storage.Get("file")
+	tu.AssertNoErr(t, err)
+	_, err = io.ReadAll(r)
+	tu.AssertErr(t, err)
+	raw[encHeaderSize+3] ^= 1
+	tu.AssertNoErr(t, local.Put("file", raw))
+	r, err = storage.Get("file")
+	tu.AssertNoErr(t, err)
+	_, err = io.ReadAll(r)
+	tu.AssertErr(t, err)
+
+	// mauvaise clé
+	tu.AssertNoErr(t, storage.Put("file", []byte("court")))
+	_, err = NewEncryptedStorage(local, config.FilesEncryption{MasterKey: "other"}).Get("file")
+	tu.AssertErr(t, err)
+}
+
+func TestEncryptedStorageUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	local := NewLocalStorage(dir)
+	tu.AssertNoErr(t, local.Put("plain", []byte("en clair")))
+
+	v1 := NewEncryptedStorage(local, config.FilesEncryption{MasterKey: "v1"})
+	// lecture transparente des fichiers en clair
+	tu.Assert(t, string(readAll(t, v1, "plain")) == "en clair")
+
+	changed, err := v1.Upgrade("plain")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, changed)
+	raw, err := os.ReadFile(filepath.Join(dir, "plain"))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !bytes.Contains(raw, []byte("en clair")))
+	changed, err = v1.Upgrade("plain")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !changed)
+
+	// rotation
+	v2 := NewEncryptedStorage(local, config.FilesEncryption{MasterKey: "v2", PreviousKeys: []string{"v1"}})
+	tu.Assert(t, string(readAll(t, v2, "plain")) == "en clair")
+	changed, err = v2.Upgrade("plain")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, changed)
+	rotated, err := os.ReadFile(filepath.Join(dir, "plain"))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, bytes.Equal(raw[encHeaderSize:], rotated[encHeaderSize:])) // contenu inchangé
+
+	onlyV2 := NewEncryptedStorage(local, config.FilesEncryption{MasterKey: "v2"})
+	tu.Assert(t, string(readAll(t, onlyV2, "plain")) == "en clair")
+	_, err = v1.Get("plain")
+	tu.AssertErr(t, err)
+}
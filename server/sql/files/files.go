@@ -2,89 +2,324 @@ package files
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"registro/utils"
 )
 
-// filepath returns the file location
-func (f IdFile) filepath(dir string, forMiniature bool) string {
+// key returns the storage key of the file
+func (f IdFile) key(forMiniature bool) string {
 	id := fmt.Sprintf("file_%d", f)
 	if forMiniature {
 		id += "_min"
 	}
-	return filepath.Join(dir, id)
+	return id
 }
 
 // FileSystem controle l'accès au contenu
 // des fichiers (et leurs miniatures)
 type FileSystem struct {
-	root string
+	storage Storage
+	scanner Scanner // optionnel
+
+	maxDocumentSize int // optionnel, voir [FileSystem.MaxDocumentSize]
 }
 
 // [root] est le dossier dans lequel les fichiers sont stockés
-func NewFileSystem(root string) FileSystem { return FileSystem{root: root} }
+func NewFileSystem(root string) FileSystem { return FileSystem{storage: NewLocalStorage(root)} }
 
-// Delete supprime le contenu et la miniature des fichiers donnés.
-func (fs FileSystem) Delete(ids ...IdFile) error {
-	for _, doc := range ids {
-		filepath := doc.filepath(fs.root, false)
-		err := os.Remove(filepath)
-		if err != nil {
-			return fmt.Errorf("failed to remove document (ID %d) : %s", doc, err)
-		}
+// NewFileSystemFrom utilise le support de stockage donné.
+func NewFileSystemFrom(storage Storage) FileSystem { return FileSystem{storage: storage} }
+
+// WithScanner renvoie un [FileSystem] analysant chaque fichier
+// avec [scanner] avant son enregistrement.
+func (fs FileSystem) WithScanner(scanner Scanner) FileSystem {
+	fs.scanner = scanner
+	return fs
+}
+
+// backend renvoie le stockage utilisé : le zero value
+// correspond au dossier courant.
+func (fs FileSystem) backend() Storage {
+	if fs.storage == nil {
+		return LocalStorage{}
+	}
+	return fs.storage
+}
+
+// contentKey returns the storage key of a deduplicated content
+func contentKey(empreinte string, forMiniature bool) string {
+	id := "sha256_" + empreinte
+	if forMiniature {
+		id += "_min"
+	}
+	return id
+}
+
+// storageKey returns the storage key of the file content,
+// which may be shared with other files.
+func (f File) storageKey(forMiniature bool) string {
+	if f.Empreinte == "" { // stored before deduplication
+		return f.Id.key(forMiniature)
+	}
+	return contentKey(f.Empreinte, forMiniature)
+}
+
+// Empreinte renvoie le hash SHA-256 (hexadécimal) de [content].
+func Empreinte(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// SelectFilesByEmpreinte renvoie les fichiers partageant le contenu [empreinte].
+func SelectFilesByEmpreinte(db DB, empreinte string) (Files, error) {
+	rows, err := db.Query("SELECT id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte FROM files WHERE Empreinte = $1", empreinte)
+	if err != nil {
+		return nil, err
+	}
+	return ScanFiles(rows)
+}
+
+// countReferences renvoie le nombre de fichiers utilisant le contenu [empreinte]
+func countReferences(db DB, empreinte string) (int, error) {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM files WHERE Empreinte = $1", empreinte).Scan(&count)
+	return count, err
+}
 
-		filepath = doc.filepath(fs.root, true)
-		err = os.Remove(filepath)
+// lockEmpreinte verrouille le contenu [empreinte] jusqu'à la fin de la transaction,
+// de sorte que le décompte de ses références ne soit pas modifié
+// par un envoi concurrent (voir [UploadFile] et [FileSystem.Delete]).
+func lockEmpreinte(tx DB, empreinte string) error {
+	_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", empreinte)
+	return err
+}
+
+// inTx exécute [fn] dans une transaction, sauf si [db]
+// est déjà une transaction.
+func inTx(db DB, fn func(tx DB) error) error {
+	if db, ok := db.(*sql.DB); ok {
+		return utils.InTx(db, func(tx *sql.Tx) error { return fn(tx) })
+	}
+	return fn(db)
+}
+
+// Delete supprime le contenu et la miniature des fichiers donnés,
+// qui doivent avoir été supprimés de la base de données [db] au préalable.
+// Un contenu partagé n'est supprimé qu'avec sa dernière référence :
+// le décompte est effectué en verrouillant le contenu (voir [lockEmpreinte]).
+func (fs FileSystem) Delete(db DB, files ...File) error {
+	done := make(map[string]bool)
+	for _, file := range files {
+		if file.Empreinte == "" { // stored before deduplication
+			if err := fs.deleteContent(file); err != nil {
+				return err
+			}
+			continue
+		}
+		if done[file.Empreinte] {
+			continue
+		}
+		done[file.Empreinte] = true
+		err := inTx(db, func(tx DB) error {
+			if err := lockEmpreinte(tx, file.Empreinte); err != nil {
+				return err
+			}
+			count, err := countReferences(tx, file.Empreinte)
+			if err != nil {
+				return err
+			}
+			if count != 0 { // still used
+				return nil
+			}
+			return fs.deleteContent(file)
+		})
 		if err != nil {
-			return fmt.Errorf("failed to remove document miniature (ID %d) : %s", doc, err)
+			return utils.SQLError(err)
 		}
 	}
 	return nil
 }
 
-func (fs FileSystem) Load(id IdFile, miniature bool) ([]byte, error) {
-	filepath := id.filepath(fs.root, miniature)
-	content, err := os.ReadFile(filepath)
+func (fs FileSystem) deleteContent(file File) error {
+	err := fs.backend().Delete(file.storageKey(false))
+	if err != nil {
+		return fmt.Errorf("failed to remove document (ID %d) : %s", file.Id, err)
+	}
+
+	err = fs.backend().Delete(file.storageKey(true))
+	if err != nil {
+		return fmt.Errorf("failed to remove document miniature (ID %d) : %s", file.Id, err)
+	}
+	return nil
+}
+
+// DeleteFiles supprime les fichiers [ids] de la base de données
+// et les renvoie : leur contenu doit ensuite être supprimé
+// avec [FileSystem.Delete].
+func DeleteFiles(tx DB, ids ...IdFile) (Files, error) {
+	files, err := SelectFiles(tx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	_, err = DeleteFilesByIDs(tx, ids...)
+	return files, err
+}
+
+// Open renvoie le contenu du fichier, sans le charger en mémoire.
+// Le résultat doit être fermé par l'appelant.
+func (fs FileSystem) Open(file File, miniature bool) (io.ReadCloser, error) {
+	r, err := fs.backend().Get(file.storageKey(miniature))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document (ID %d) : %s", file.Id, err)
+	}
+	return r, nil
+}
+
+func (fs FileSystem) Load(file File, miniature bool) ([]byte, error) {
+	r, err := fs.Open(file, miniature)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load document (ID %d) : %s", id, err)
+		return nil, err
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document (ID %d) : %s", file.Id, err)
 	}
 	return content, nil
 }
 
-func (fs FileSystem) Save(doc IdFile, fileContent []byte, miniature bool) error {
-	filepath := doc.filepath(fs.root, miniature)
-	err := os.WriteFile(filepath, fileContent, os.ModePerm)
+func (fs FileSystem) Save(file File, fileContent []byte, miniature bool) error {
+	err := fs.backend().Put(file.storageKey(miniature), fileContent)
 	if err != nil {
-		return fmt.Errorf("failed to save document (ID %d) : %s", doc, err)
+		return fmt.Errorf("failed to save document (ID %d) : %s", file.Id, err)
 	}
 	return nil
 }
 
-// UploadFile computes the miniature, stores the content on the file system
-// and updates the metadata.
+// UploadFile checks the file format (see [CheckFormat]), scans the content,
+// removes active PDF content (see [StripPDF]), computes the miniature,
+// stores the content on the file system and updates the metadata.
+// The content is stored once, identified by its SHA-256 hash, and
+// the previous content of the file is removed if no longer used.
+// The content is locked until the end of the transaction [db] (see [lockEmpreinte]),
+// which should thus be a [*sql.Tx].
 // The file size is not checked.
 func UploadFile(fs FileSystem, db DB, id IdFile, fileContent []byte, filename string) (File, error) {
+	if err := CheckFormat(filename, fileContent); err != nil {
+		return File{}, err
+	}
+	if err := fs.scan(fileContent); err != nil {
+		if infected, ok := err.(ErrInfected); ok {
+			log.Printf("upload of %s rejected (quarantined): %s", filename, infected.Menace)
+		}
+		return File{}, err
+	}
 	ext := filepath.Ext(filename)
+	if strings.ToLower(ext) == ".pdf" {
+		var err error
+		fileContent, err = StripPDF(fileContent)
+		if err != nil {
+			return File{}, err
+		}
+	}
 	minContent, err := computeMiniature(ext, bytes.NewReader(fileContent))
 	if err != nil {
 		return File{}, err
 	}
-	meta := File{Id: id, Taille: len(fileContent), NomClient: filename, Uploaded: time.Now().Truncate(time.Second)}
+	previous, err := SelectFile(db, id)
+	if err != nil {
+		return File{}, utils.SQLError(err)
+	}
+	meta := File{Id: id, Taille: len(fileContent), NomClient: filename, Uploaded: time.Now().Truncate(time.Second), Empreinte: Empreinte(fileContent)}
+	if err = lockEmpreinte(db, meta.Empreinte); err != nil {
+		return File{}, utils.SQLError(err)
+	}
 	meta, err = meta.Update(db)
 	if err != nil {
 		return File{}, utils.SQLError(err)
 	}
-	err = fs.Save(id, fileContent, false)
+	err = fs.Save(meta, fileContent, false)
 	if err != nil {
 		return File{}, err
 	}
-	err = fs.Save(id, minContent, true)
+	err = fs.Save(meta, minContent, true)
 	if err != nil {
 		return File{}, err
 	}
+	// an empty [previous] has no content yet
+	if previous.Taille != 0 && previous.storageKey(false) != meta.storageKey(false) {
+		if err = fs.Delete(db, previous); err != nil {
+			return File{}, err
+		}
+	}
 	return meta, nil
 }
+
+// MigreEmpreinte enregistre le contenu du fichier [id], stocké avant
+// la déduplication, sous son empreinte (voir [Empreinte]), puis supprime
+// l'ancien contenu. Elle renvoie false si le fichier était déjà à jour.
+func (fs FileSystem) MigreEmpreinte(db *sql.DB, id IdFile) (bool, error) {
+	file, err := SelectFile(db, id)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	if file.Empreinte != "" || file.Taille == 0 { // already migrated, or no content
+		return false, nil
+	}
+	content, err := fs.Load(file, false)
+	if err != nil {
+		return false, err
+	}
+	minContent, err := fs.Load(file, true)
+	if err != nil {
+		return false, err
+	}
+	legacy := file
+
+	migrated := false
+	err = utils.InTx(db, func(tx *sql.Tx) error {
+		// the file may have been concurrently uploaded
+		current, err := ScanFile(tx.QueryRow("SELECT id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte FROM files WHERE id = $1 FOR UPDATE", id))
+		if err != nil {
+			return err
+		}
+		if current.Empreinte != "" {
+			return nil
+		}
+		current.Empreinte = Empreinte(content)
+		if err = lockEmpreinte(tx, current.Empreinte); err != nil {
+			return err
+		}
+		if err = fs.Save(current, content, false); err != nil {
+			return err
+		}
+		if err = fs.Save(current, minContent, true); err != nil {
+			return err
+		}
+		_, err = current.Update(tx)
+		migrated = true
+		return err
+	})
+	if err != nil || !migrated {
+		return false, err
+	}
+
+	// a concurrent upload may already have removed the legacy content
+	for _, miniature := range [2]bool{false, true} {
+		err = fs.backend().Delete(legacy.storageKey(miniature))
+		if err != nil && !os.IsNotExist(err) {
+			return true, fmt.Errorf("failed to remove legacy document (ID %d) : %s", legacy.Id, err)
+		}
+	}
+	return true, nil
+}
@@ -8,9 +8,14 @@ import (
 	tu "registro/utils/testutils"
 )
 
-func TestFilepath(t *testing.T) {
-	tu.Assert(t, IdFile(4).filepath("root", false) == "root/file_4")
-	tu.Assert(t, IdFile(4).filepath("root", true) == "root/file_4_min")
+func TestFileKey(t *testing.T) {
+	tu.Assert(t, IdFile(4).key(false) == "file_4")
+	tu.Assert(t, IdFile(4).key(true) == "file_4_min")
+
+	tu.Assert(t, File{Id: 4}.storageKey(false) == "file_4") // legacy
+	file := File{Id: 4, Empreinte: Empreinte([]byte("content"))}
+	tu.Assert(t, file.storageKey(false) == "sha256_"+file.Empreinte)
+	tu.Assert(t, file.storageKey(true) == "sha256_"+file.Empreinte+"_min")
 }
 
 func TestUploadFile(t *testing.T) {
@@ -32,3 +37,87 @@ func TestUploadFile(t *testing.T) {
 	tu.Assert(t, meta.Taille == len(f))
 	tu.Assert(t, meta.Uploaded.YearDay() == time.Now().YearDay())
 }
+
+func TestDeduplication(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	fs := NewFileSystemFrom(storage)
+	db := tu.NewTestDB(t, "../personnes/gen_create.sql", "../dossiers/gen_create.sql", "../camps/gen_create.sql", "gen_create.sql")
+	defer db.Remove()
+
+	img1, err := os.ReadFile("test/img1.png")
+	tu.AssertNoErr(t, err)
+	img2, err := os.ReadFile("test/img2.JPG")
+	tu.AssertNoErr(t, err)
+
+	file1, err := File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file2, err := File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	file1, err = UploadFile(fs, db, file1.Id, img1, "img1.png")
+	tu.AssertNoErr(t, err)
+	file2, err = UploadFile(fs, db, file2.Id, img1, "copie.png")
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, file1.Empreinte == file2.Empreinte)
+
+	keys, err := storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 2) // content and miniature, stored once
+
+	same, err := SelectFilesByEmpreinte(db, file1.Empreinte)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(same) == 2)
+
+	// re-upload : the previous content is still used by file2
+	file1, err = UploadFile(fs, db, file1.Id, img2, "img2.jpg")
+	tu.AssertNoErr(t, err)
+	keys, err = storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 4)
+
+	// the content is removed with its last reference
+	deleted, err := DeleteFiles(db, file2.Id)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, fs.Delete(db, deleted[file2.Id]))
+	keys, err = storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 2)
+
+	content, err := fs.Load(file1, false)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(content) == len(img2))
+}
+
+func TestMigreEmpreinte(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	fs := NewFileSystemFrom(storage)
+	db := tu.NewTestDB(t, "../personnes/gen_create.sql", "../dossiers/gen_create.sql", "../camps/gen_create.sql", "gen_create.sql")
+	defer db.Remove()
+
+	img1, err := os.ReadFile("test/img1.png")
+	tu.AssertNoErr(t, err)
+
+	// stored before deduplication
+	file, err := File{Taille: len(img1), NomClient: "img1.png"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, fs.Save(file, img1, false))
+	tu.AssertNoErr(t, fs.Save(file, []byte("miniature"), true))
+
+	migrated, err := fs.MigreEmpreinte(db.DB, file.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, migrated)
+
+	file, err = SelectFile(db, file.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, file.Empreinte == Empreinte(img1))
+	keys, err := storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 2 && keys[0] == contentKey(file.Empreinte, false) && keys[1] == contentKey(file.Empreinte, true))
+	content, err := fs.Load(file, false)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(content) == len(img1))
+
+	migrated, err = fs.MigreEmpreinte(db.DB, file.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !migrated)
+}
@@ -0,0 +1,150 @@
+package files
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/benoitkugler/pdf/model"
+)
+
+// detectFormat renvoie l'extension correspondant aux premiers
+// octets de [content], ou une chaîne vide pour un format inconnu.
+func detectFormat(content []byte) string {
+	has := func(offset int, magic string) bool {
+		return len(content) >= offset+len(magic) && string(content[offset:offset+len(magic)]) == magic
+	}
+	switch {
+	case has(0, "%PDF-"):
+		return ".pdf"
+	case has(0, "\x89PNG\r\n\x1a\n"):
+		return ".png"
+	case has(0, "\xff\xd8\xff"):
+		return ".jpg"
+	case has(0, "GIF87a"), has(0, "GIF89a"):
+		return ".gif"
+	case has(0, "BM"):
+		return ".bmp"
+	case has(0, "RIFF") && has(8, "WEBP"):
+		return ".webp"
+	case has(0, "II*\x00"), has(0, "MM\x00*"):
+		return ".tif"
+	case has(4, "ftyp"):
+		for _, brand := range [...]string{"heic", "heix", "hevc", "heim", "heis", "mif1", "msf1"} {
+			if has(8, brand) {
+				return ".heic"
+			}
+		}
+	}
+	return ""
+}
+
+// sameFormat normalise les extensions équivalentes
+func sameFormat(ext string) string {
+	switch ext = strings.ToLower(ext); ext {
+	case ".jpeg":
+		return ".jpg"
+	case ".tiff":
+		return ".tif"
+	case ".heif":
+		return ".heic"
+	default:
+		return ext
+	}
+}
+
+// CheckFormat vérifie que le contenu du fichier (déterminé par
+// ses premiers octets) est un PDF ou une image supportée, et
+// correspond à l'extension de [filename].
+func CheckFormat(filename string, content []byte) error {
+	ext := filepath.Ext(filename)
+	detected := detectFormat(content)
+	if detected == "" {
+		return fmt.Errorf("Le format du fichier %s n'est pas supporté : merci d'envoyer un document PDF ou une image.", filename)
+	}
+	if sameFormat(ext) != detected {
+		return fmt.Errorf("Le contenu du fichier %s ne correspond pas à son extension.", filename)
+	}
+	return nil
+}
+
+// isSafeAction renvoie true pour les actions de navigation
+func isSafeAction(action model.Action) bool {
+	switch action.ActionType.(type) {
+	case nil, model.ActionGoTo, model.ActionURI:
+	default:
+		return false
+	}
+	for _, next := range action.Next {
+		if !isSafeAction(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// stripAnnotation supprime les actions d'une annotation, et renvoie
+// false si l'annotation elle-même doit être supprimée.
+func stripAnnotation(annot *model.AnnotationDict) bool {
+	switch subtype := annot.Subtype.(type) {
+	case model.AnnotationLink:
+		if !isSafeAction(subtype.A) {
+			subtype.A = model.Action{}
+		}
+		annot.Subtype = subtype
+	case model.AnnotationWidget:
+		if !isSafeAction(subtype.A) {
+			subtype.A = model.Action{}
+		}
+		subtype.AA = model.AnnotationAdditionalActions{}
+		annot.Subtype = subtype
+	case model.AnnotationScreen, model.AnnotationFileAttachment:
+		return false
+	}
+	return true
+}
+
+func stripFields(fields []*model.FormFieldDict) {
+	for _, field := range fields {
+		field.AA = model.FormFielAdditionalActions{}
+		for _, widget := range field.Widgets {
+			if widget.AnnotationDict != nil {
+				stripAnnotation(widget.AnnotationDict)
+			}
+		}
+		stripFields(field.Kids)
+	}
+}
+
+// StripPDF supprime le contenu actif d'un document PDF :
+// scripts, actions automatiques, lancement d'applications,
+// fichiers joints, formulaires XFA.
+func StripPDF(content []byte) ([]byte, error) {
+	doc, err := parsePDF(content)
+	if err != nil {
+		return nil, fmt.Errorf("Le document PDF est invalide ou endommagé : %s", err)
+	}
+	// les scripts de niveau document (/Names/JavaScript) et
+	// les formulaires XFA ne sont pas conservés par [model.Document]
+	if !isSafeAction(doc.Catalog.OpenAction) {
+		doc.Catalog.OpenAction = model.Action{}
+	}
+	doc.Catalog.Names.EmbeddedFiles = nil
+	for _, page := range doc.Catalog.Pages.Flatten() {
+		annots := page.Annots[:0]
+		for _, annot := range page.Annots {
+			if stripAnnotation(annot) {
+				annots = append(annots, annot)
+			}
+		}
+		page.Annots = annots
+	}
+	stripFields(doc.Catalog.AcroForm.Fields)
+
+	var out bytes.Buffer
+	if err = doc.Write(&out, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+package files
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	tu "registro/utils/testutils"
+
+	"github.com/benoitkugler/pdf/model"
+)
+
+func TestCheckFormat(t *testing.T) {
+	png, err := os.ReadFile("test/img1.png")
+	tu.AssertNoErr(t, err)
+	jpg, err := os.ReadFile("test/img2.JPG")
+	tu.AssertNoErr(t, err)
+	pdf, err := os.ReadFile("test/doc3.pdf")
+	tu.AssertNoErr(t, err)
+
+	tu.AssertNoErr(t, CheckFormat("img1.png", png))
+	tu.AssertNoErr(t, CheckFormat("img2.JPG", jpg))
+	tu.AssertNoErr(t, CheckFormat("img2.jpeg", jpg))
+	tu.AssertNoErr(t, CheckFormat("doc3.pdf", pdf))
+
+	tu.AssertErr(t, CheckFormat("img1.pdf", png))   // wrong extension
+	tu.AssertErr(t, CheckFormat("doc3.png", pdf))   // wrong extension
+	tu.AssertErr(t, CheckFormat("script.exe", pdf)) // unsupported extension
+	tu.AssertErr(t, CheckFormat("test.pdf", []byte("MZ\x90\x00")))
+	tu.AssertErr(t, CheckFormat("", nil))
+}
+
+func TestStripPDF(t *testing.T) {
+	var doc model.Document
+	page := &model.PageObject{
+		MediaBox: &model.Rectangle{Urx: 100, Ury: 100},
+		Annots: []*model.AnnotationDict{
+			{Subtype: model.AnnotationLink{A: model.Action{ActionType: model.ActionURI{URI: "https://example.com"}}}},
+			{Subtype: model.AnnotationLink{A: model.Action{ActionType: model.ActionJavaScript{JS: "app.alert('link')"}}}},
+		},
+	}
+	doc.Catalog.Pages.Kids = []model.PageNode{page}
+	doc.Catalog.OpenAction = model.Action{ActionType: model.ActionJavaScript{JS: "app.alert('open')"}}
+	var buf bytes.Buffer
+	tu.AssertNoErr(t, doc.Write(&buf, nil))
+	tu.Assert(t, bytes.Contains(buf.Bytes(), []byte("/JavaScript")))
+
+	out, err := StripPDF(buf.Bytes())
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !bytes.Contains(out, []byte("/JavaScript")))
+
+	stripped, err := parsePDF(out)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, stripped.Catalog.OpenAction.ActionType == nil)
+	annots := stripped.Catalog.Pages.Flatten()[0].Annots
+	tu.Assert(t, len(annots) == 2)
+	tu.Assert(t, annots[0].Subtype.(model.AnnotationLink).A.ActionType != nil) // URI is kept
+	tu.Assert(t, annots[1].Subtype.(model.AnnotationLink).A.ActionType == nil)
+
+	_, err = StripPDF([]byte("%PDF-1.7 invalid"))
+	tu.AssertErr(t, err)
+}
@@ -0,0 +1,279 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/benoitkugler/pdf/formfill"
+	"github.com/benoitkugler/pdf/model"
+	"github.com/benoitkugler/pdf/reader"
+)
+
+// TypeChamp est le type d'un champ de formulaire PDF
+type TypeChamp uint8
+
+const (
+	ChampTexte TypeChamp = iota // Texte
+	ChampCase                   // Case à cocher
+	ChampRadio                  // Choix exclusif
+	ChampListe                  // Liste de choix
+)
+
+// ChampFormulaire est un champ modifiable d'un formulaire PDF (AcroForm)
+type ChampFormulaire struct {
+	Nom    string // nom complet, identifiant le champ
+	Label  string // nom alternatif, ou [Nom]
+	Type   TypeChamp
+	MaxLen int // pour les champs texte, 0 pour aucune limite
+	// Valeurs possibles pour les [ChampRadio] et [ChampListe],
+	// état coché pour une [ChampCase]
+	Options []string
+	Valeur  string // valeur actuelle (vide pour une case non cochée)
+}
+
+func parsePDF(content []byte) (model.Document, error) {
+	doc, _, err := reader.ParsePDFReader(bytes.NewReader(content), reader.Options{})
+	if err != nil {
+		return model.Document{}, fmt.Errorf("invalid PDF file: %s", err)
+	}
+	return doc, nil
+}
+
+// onStates renvoie les états "cochés" utilisés par les widgets
+// du champ (ou de ses enfants pour un groupe de boutons radio)
+func onStates(field *model.FormFieldDict) (out []string) {
+	keys := field.AppearanceKeys()
+	for _, kid := range field.Kids {
+		keys = append(keys, kid.AppearanceKeys()...)
+	}
+	for _, key := range keys {
+		if key != "Off" && !slices.Contains(out, string(key)) {
+			out = append(out, string(key))
+		}
+	}
+	return out
+}
+
+// isGroup renvoie true si les enfants de [field] sont
+// de simples widgets (anonymes), comme pour un groupe de boutons radio
+func isGroup(field *model.FormFieldDict) bool {
+	if len(field.Kids) == 0 {
+		return false
+	}
+	for _, kid := range field.Kids {
+		if kid.T != "" || len(kid.Kids) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ChampsFormulaire renvoie les champs modifiables du formulaire
+// contenu dans le document PDF [content], triés par nom.
+// Un document sans formulaire renvoie une liste vide.
+func ChampsFormulaire(content []byte) ([]ChampFormulaire, error) {
+	doc, err := parsePDF(content)
+	if err != nil {
+		return nil, err
+	}
+	var out []ChampFormulaire
+	for nom, field := range doc.Catalog.AcroForm.Flatten() {
+		isWidget := field.Field.Parent != nil && isGroup(field.Field.Parent)
+		if isWidget || (len(field.Field.Kids) != 0 && !isGroup(field.Field)) || field.Merged.Ff&model.ReadOnly != 0 {
+			continue
+		}
+		champ := ChampFormulaire{Nom: nom, Label: field.Field.TU}
+		if champ.Label == "" {
+			champ.Label = nom
+		}
+		switch ft := field.Merged.FT.(type) {
+		case model.FormFieldText:
+			champ.Type = ChampTexte
+			champ.Valeur = ft.V
+			if ml, ok := ft.MaxLen.(model.ObjInt); ok {
+				champ.MaxLen = int(ml)
+			}
+		case model.FormFieldButton:
+			if field.Merged.Ff&model.Pushbutton != 0 {
+				continue
+			}
+			champ.Type = ChampCase
+			if field.Merged.Ff&model.Radio != 0 {
+				champ.Type = ChampRadio
+			}
+			champ.Options = onStates(field.Field)
+			if ft.V != "Off" {
+				champ.Valeur = string(ft.V)
+			}
+		case model.FormFieldChoice:
+			champ.Type = ChampListe
+			for _, opt := range ft.Opt {
+				if opt.Export != "" {
+					champ.Options = append(champ.Options, opt.Export)
+				} else {
+					champ.Options = append(champ.Options, opt.Name)
+				}
+			}
+			if len(ft.V) != 0 {
+				champ.Valeur = ft.V[0]
+			}
+		default: // signature
+			continue
+		}
+		out = append(out, champ)
+	}
+	slices.SortFunc(out, func(a, b ChampFormulaire) int { return strings.Compare(a.Nom, b.Nom) })
+	return out, nil
+}
+
+// RemplitFormulaire remplit le formulaire contenu dans le document PDF [content]
+// avec les [valeurs] (indexées par [ChampFormulaire.Nom]) et renvoie le document
+// aplati : les champs sont remplacés par leur apparence (voir [flattenForm]).
+// Pour les cases à cocher, une valeur vide décoche la case.
+func RemplitFormulaire(content []byte, valeurs map[string]string) ([]byte, error) {
+	champs, err := ChampsFormulaire(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(champs) == 0 {
+		return nil, errors.New("Le document ne contient pas de formulaire.")
+	}
+	doc, err := parsePDF(content)
+	if err != nil {
+		return nil, err
+	}
+	acroFields := doc.Catalog.AcroForm.Flatten()
+
+	var fields []formfill.FDFField
+	for _, champ := range champs {
+		valeur, has := valeurs[champ.Nom]
+		if !has {
+			continue
+		}
+		var v formfill.FDFValue
+		switch champ.Type {
+		case ChampTexte, ChampListe:
+			v = formfill.FDFText(valeur)
+		case ChampCase, ChampRadio:
+			if valeur == "" {
+				valeur = "Off"
+			} else if !slices.Contains(champ.Options, valeur) {
+				return nil, fmt.Errorf("invalid value %s for field %s", valeur, champ.Nom)
+			}
+			v = formfill.FDFName(valeur)
+		}
+		field := acroFields[champ.Nom].Field
+		if !isGroup(field) {
+			fields = append(fields, formfill.FDFField{T: champ.Nom, Values: formfill.Values{V: v}})
+			continue
+		}
+		// l'état est porté par chaque widget
+		for i := range field.Kids {
+			fields = append(fields, formfill.FDFField{T: fmt.Sprintf("%s.%d", champ.Nom, i), Values: formfill.Values{V: v}})
+		}
+		if ft, ok := field.FT.(model.FormFieldButton); ok {
+			ft.V = model.ObjName(valeur)
+			field.FT = ft
+		}
+	}
+
+	err = formfill.FillForm(&doc, formfill.FDFDict{Fields: fields}, true)
+	if err != nil {
+		return nil, fmt.Errorf("filling PDF form: %s", err)
+	}
+	flattenForm(&doc)
+	var out bytes.Buffer
+	if err = doc.Write(&out, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// appearance renvoie l'apparence (normale) à afficher pour [annot], ou nil
+func appearance(annot *model.AnnotationDict) *model.XObjectForm {
+	if annot.AP == nil || annot.F&model.AHidden != 0 {
+		return nil
+	}
+	if form := annot.AP.N[""]; len(annot.AP.N) == 1 && form != nil {
+		return form
+	}
+	return annot.AP.N[annot.AS] // état des cases à cocher
+}
+
+// appearanceMatrix renvoie la transformation plaçant l'apparence [form]
+// dans le rectangle [rect] de l'annotation (voir la section 12.5.5 de la spécification PDF).
+func appearanceMatrix(form *model.XObjectForm, rect model.Rectangle) model.Matrix {
+	m := form.Matrix
+	if m == (model.Matrix{}) {
+		m = model.Matrix{1, 0, 0, 1, 0, 0}
+	}
+	// boîte englobante de la BBox transformée par [m]
+	box := model.Rectangle{Llx: math.MaxFloat32, Lly: math.MaxFloat32, Urx: -math.MaxFloat32, Ury: -math.MaxFloat32}
+	bbox := form.BBox
+	for _, pt := range [4][2]model.Fl{{bbox.Llx, bbox.Lly}, {bbox.Urx, bbox.Lly}, {bbox.Llx, bbox.Ury}, {bbox.Urx, bbox.Ury}} {
+		x, y := m[0]*pt[0]+m[2]*pt[1]+m[4], m[1]*pt[0]+m[3]*pt[1]+m[5]
+		box.Llx, box.Urx = min(box.Llx, x), max(box.Urx, x)
+		box.Lly, box.Ury = min(box.Lly, y), max(box.Ury, y)
+	}
+	sx, sy := model.Fl(1), model.Fl(1)
+	if w := box.Width(); w != 0 {
+		sx = rect.Width() / w
+	}
+	if h := box.Height(); h != 0 {
+		sy = rect.Height() / h
+	}
+	return model.Matrix{sx, 0, 0, sy, rect.Llx - box.Llx*sx, rect.Lly - box.Lly*sy}
+}
+
+// flattenForm intègre l'apparence des champs du formulaire au contenu
+// des pages, puis supprime les champs : le document n'est plus modifiable.
+func flattenForm(doc *model.Document) {
+	pages, inherited := doc.Catalog.Pages.Flatten(), doc.Catalog.Pages.FlattenInherit()
+	for i, page := range pages {
+		var (
+			annots  []*model.AnnotationDict
+			content bytes.Buffer
+		)
+		for _, annot := range page.Annots {
+			if _, isWidget := annot.Subtype.(model.AnnotationWidget); !isWidget {
+				annots = append(annots, annot)
+				continue
+			}
+			form := appearance(annot)
+			if form == nil {
+				continue
+			}
+			if page.Resources == nil { // copie les ressources héritées
+				page.Resources = new(model.ResourcesDict)
+				if res := inherited[i].Resources; res != nil {
+					*page.Resources = *res
+				}
+			}
+			xObjects := make(map[model.Name]model.XObject, len(page.Resources.XObject)+1)
+			for name, xObject := range page.Resources.XObject {
+				xObjects[name] = xObject
+			}
+			name := model.Name(fmt.Sprintf("Champ%d", len(xObjects)))
+			for _, has := xObjects[name]; has; _, has = xObjects[name] {
+				name += "_"
+			}
+			xObjects[name] = form
+			page.Resources.XObject = xObjects
+
+			m := appearanceMatrix(form, annot.Rect)
+			fmt.Fprintf(&content, "q %s cm %s Do Q\n", strings.Trim(m.String(), "[]"), name)
+		}
+		page.Annots = annots
+		if content.Len() == 0 {
+			continue
+		}
+		// isole l'état graphique du contenu existant
+		page.Contents = append([]model.ContentStream{{Stream: model.Stream{Content: []byte("q\n")}}}, page.Contents...)
+		page.Contents = append(page.Contents, model.ContentStream{Stream: model.Stream{Content: append([]byte("Q\n"), content.Bytes()...)}})
+	}
+	doc.Catalog.AcroForm = model.AcroForm{}
+}
@@ -0,0 +1,67 @@
+package files
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	tu "registro/utils/testutils"
+
+	"github.com/benoitkugler/pdf/model"
+)
+
+func TestFormulaire(t *testing.T) {
+	content, err := os.ReadFile("test/formulaire.pdf")
+	tu.AssertNoErr(t, err)
+	champs, err := ChampsFormulaire(content)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(champs) == 13)
+	byNom := map[string]ChampFormulaire{}
+	for _, champ := range champs {
+		byNom[champ.Nom] = champ
+	}
+	tu.Assert(t, byNom["Text1"].Type == ChampTexte)
+	tu.Assert(t, byNom["Check Box7"].Type == ChampCase && len(byNom["Check Box7"].Options) == 1)
+	tu.Assert(t, byNom["Group11"].Type == ChampRadio && len(byNom["Group11"].Options) == 4)
+	tu.Assert(t, byNom["Dropdown12"].Type == ChampListe)
+
+	_, err = RemplitFormulaire(content, map[string]string{"Check Box7": "invalid"})
+	tu.AssertErr(t, err)
+
+	filled, err := RemplitFormulaire(content, map[string]string{
+		"Text1":      "Benoît",
+		"Check Box7": "Yes",
+		"Group11":    "Choice3",
+		"Dropdown12": "b",
+	})
+	tu.AssertNoErr(t, err)
+	tu.Write(t, "formulaire_rempli.pdf", filled)
+
+	// le document est aplati
+	champs, err = ChampsFormulaire(filled)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(champs) == 0)
+
+	doc, err := parsePDF(filled)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(doc.Catalog.AcroForm.Fields) == 0)
+	var appearances []string
+	for _, page := range doc.Catalog.Pages.Flatten() {
+		for _, annot := range page.Annots {
+			_, isWidget := annot.Subtype.(model.AnnotationWidget)
+			tu.Assert(t, !isWidget)
+		}
+		for _, xObject := range page.Resources.XObject {
+			if form, ok := xObject.(*model.XObjectForm); ok {
+				content, err := form.Decode()
+				tu.AssertNoErr(t, err)
+				appearances = append(appearances, string(content))
+			}
+		}
+	}
+	tu.Assert(t, slices.ContainsFunc(appearances, func(s string) bool { return strings.Contains(s, "Beno") }))
+
+	_, err = RemplitFormulaire(filled, nil)
+	tu.AssertErr(t, err)
+}
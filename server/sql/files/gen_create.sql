@@ -3,7 +3,7 @@ CREATE TABLE demandes (
     Id serial PRIMARY KEY,
     IdFile integer,
     IdDirecteur integer,
-    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)) NOT NULL,
+    Categorie smallint CHECK (Categorie IN (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16)) NOT NULL,
     Description text NOT NULL,
     MaxDocs integer NOT NULL,
     JoursValide integer NOT NULL
@@ -24,7 +24,11 @@ CREATE TABLE files (
     Id serial PRIMARY KEY,
     Taille integer NOT NULL,
     NomClient text NOT NULL,
-    Uploaded timestamp(0) with time zone NOT NULL
+    Uploaded timestamp(0) with time zone NOT NULL,
+    Perime boolean NOT NULL,
+    Revue smallint CHECK (Revue IN (0, 1, 2)) NOT NULL,
+    RevueCommentaire text NOT NULL,
+    Empreinte text NOT NULL
 );
 
 CREATE TABLE file_aides (
@@ -32,12 +36,22 @@ CREATE TABLE file_aides (
     IdAide integer NOT NULL
 );
 
+CREATE TABLE file_certifications (
+    IdFile integer NOT NULL,
+    IdCertification integer NOT NULL
+);
+
 CREATE TABLE file_camps (
     IdFile integer NOT NULL,
     IdCamp integer NOT NULL,
     IsLettre boolean NOT NULL
 );
 
+CREATE TABLE file_depenses (
+    IdFile integer NOT NULL,
+    IdDepense integer NOT NULL
+);
+
 CREATE TABLE file_personnes (
     IdFile integer NOT NULL,
     IdPersonne integer NOT NULL,
@@ -45,7 +59,20 @@ CREATE TABLE file_personnes (
     guard boolean NOT NULL
 );
 
+CREATE TABLE signatures (
+    Id serial PRIMARY KEY,
+    IdFile integer NOT NULL,
+    IdPersonne integer NOT NULL,
+    Document smallint CHECK (Document IN (0, 1, 2, 3)) NOT NULL,
+    Signataire text NOT NULL,
+    Moment timestamp(0) with time zone NOT NULL,
+    IP text NOT NULL,
+    Empreinte text NOT NULL
+);
+
 -- constraints
+CREATE INDEX ON files (Empreinte);
+
 ALTER TABLE demandes
     ADD CONSTRAINT constraint_categorie CHECK (Categorie = 0 OR IdDirecteur IS NULL);
 
@@ -126,3 +153,33 @@ ALTER TABLE file_aides
 ALTER TABLE file_aides
     ADD FOREIGN KEY (IdAide) REFERENCES aides;
 
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_certifications
+    ADD UNIQUE (IdCertification);
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_certifications
+    ADD FOREIGN KEY (IdCertification) REFERENCES certifications ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE file_depenses
+    ADD FOREIGN KEY (IdDepense) REFERENCES depenses ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD UNIQUE (IdFile);
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdFile) REFERENCES files ON DELETE CASCADE;
+
+ALTER TABLE signatures
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
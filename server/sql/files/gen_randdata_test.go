@@ -11,7 +11,7 @@ import (
 // Code generated by gomacro/generator/go/randdata. DO NOT EDIT.
 
 func randCategorie() Categorie {
-	choix := [...]Categorie{NoBuiltin, CarteId, Permis, SB, Secourisme, Bafa, Bafd, CarteVitale, Vaccins, Haccp, BafdEquiv, BafaEquiv, CertMedicalCuisine, PhotoIdentite, ExtraitCasierJudiciaire, Autre}
+	choix := [...]Categorie{NoBuiltin, CarteId, Permis, SB, Secourisme, Bafa, Bafd, CarteVitale, Vaccins, Haccp, BafdEquiv, BafaEquiv, CertMedicalCuisine, PhotoIdentite, ExtraitCasierJudiciaire, Autre, PAI}
 	i := rand.Intn(len(choix))
 	return choix[i]
 }
@@ -46,12 +46,22 @@ func randDemandeEquipier() DemandeEquipier {
 	return s
 }
 
+func randDocumentSigne() DocumentSigne {
+	choix := [...]DocumentSigne{CharteParticipant, CharteEquipier, AutorisationParentale, FicheSanitaire}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randFile() File {
 	var s File
 	s.Id = randIdFile()
 	s.Taille = randint()
 	s.NomClient = randstring()
 	s.Uploaded = randtTime()
+	s.Perime = randbool()
+	s.Revue = randRevue()
+	s.RevueCommentaire = randstring()
+	s.Empreinte = randstring()
 
 	return s
 }
@@ -64,6 +74,14 @@ func randFileAide() FileAide {
 	return s
 }
 
+func randFileCertification() FileCertification {
+	var s FileCertification
+	s.IdFile = randIdFile()
+	s.IdCertification = randper_IdCertification()
+
+	return s
+}
+
 func randFileCamp() FileCamp {
 	var s FileCamp
 	s.IdFile = randIdFile()
@@ -73,6 +91,14 @@ func randFileCamp() FileCamp {
 	return s
 }
 
+func randFileDepense() FileDepense {
+	var s FileDepense
+	s.IdFile = randIdFile()
+	s.IdDepense = randcam_IdDepense()
+
+	return s
+}
+
 func randFilePersonne() FilePersonne {
 	var s FilePersonne
 	s.IdFile = randIdFile()
@@ -90,6 +116,30 @@ func randIdFile() IdFile {
 	return IdFile(randint64())
 }
 
+func randIdSignature() IdSignature {
+	return IdSignature(randint64())
+}
+
+func randRevue() Revue {
+	choix := [...]Revue{EnAttente, Accepte, Refuse}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randSignature() Signature {
+	var s Signature
+	s.Id = randIdSignature()
+	s.IdFile = randIdFile()
+	s.IdPersonne = randper_IdPersonne()
+	s.Document = randDocumentSigne()
+	s.Signataire = randstring()
+	s.Moment = randtTime()
+	s.IP = randstring()
+	s.Empreinte = randstring()
+
+	return s
+}
+
 func randbool() bool {
 	i := rand.Int31n(2)
 	return i == 1
@@ -103,6 +153,10 @@ func randcam_IdCamp() camps.IdCamp {
 	return camps.IdCamp(randint64())
 }
 
+func randcam_IdDepense() camps.IdDepense {
+	return camps.IdDepense(randint64())
+}
+
 func randcam_IdEquipier() camps.IdEquipier {
 	return camps.IdEquipier(randint64())
 }
@@ -115,6 +169,10 @@ func randint64() int64 {
 	return int64(rand.Intn(1000000))
 }
 
+func randper_IdCertification() personnes.IdCertification {
+	return personnes.IdCertification(randint64())
+}
+
 func randper_IdPersonne() personnes.IdPersonne {
 	return personnes.IdPersonne(randint64())
 }
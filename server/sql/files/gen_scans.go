@@ -583,6 +583,10 @@ func scanOneFile(row scanner) (File, error) {
 		&item.Taille,
 		&item.NomClient,
 		&item.Uploaded,
+		&item.Perime,
+		&item.Revue,
+		&item.RevueCommentaire,
+		&item.Empreinte,
 	)
 	return item, err
 }
@@ -591,7 +595,7 @@ func ScanFile(row *sql.Row) (File, error) { return scanOneFile(row) }
 
 // SelectAll returns all the items in the files table.
 func SelectAllFiles(db DB) (Files, error) {
-	rows, err := db.Query("SELECT id, taille, nomclient, uploaded FROM files")
+	rows, err := db.Query("SELECT id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte FROM files")
 	if err != nil {
 		return nil, err
 	}
@@ -600,13 +604,13 @@ func SelectAllFiles(db DB) (Files, error) {
 
 // SelectFile returns the entry matching 'id'.
 func SelectFile(tx DB, id IdFile) (File, error) {
-	row := tx.QueryRow("SELECT id, taille, nomclient, uploaded FROM files WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte FROM files WHERE id = $1", id)
 	return ScanFile(row)
 }
 
 // SelectFiles returns the entry matching the given 'ids'.
 func SelectFiles(tx DB, ids ...IdFile) (Files, error) {
-	rows, err := tx.Query("SELECT id, taille, nomclient, uploaded FROM files WHERE id = ANY($1)", IdFileArrayToPQ(ids))
+	rows, err := tx.Query("SELECT id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte FROM files WHERE id = ANY($1)", IdFileArrayToPQ(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -651,28 +655,28 @@ func ScanFiles(rs *sql.Rows) (Files, error) {
 // Insert one File in the database and returns the item with id filled.
 func (item File) Insert(tx DB) (out File, err error) {
 	row := tx.QueryRow(`INSERT INTO files (
-		taille, nomclient, uploaded
+		taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte
 		) VALUES (
-		$1, $2, $3
-		) RETURNING id, taille, nomclient, uploaded;
-		`, item.Taille, item.NomClient, item.Uploaded)
+		$1, $2, $3, $4, $5, $6, $7
+		) RETURNING id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte;
+		`, item.Taille, item.NomClient, item.Uploaded, item.Perime, item.Revue, item.RevueCommentaire, item.Empreinte)
 	return ScanFile(row)
 }
 
 // Update File in the database and returns the new version.
 func (item File) Update(tx DB) (out File, err error) {
 	row := tx.QueryRow(`UPDATE files SET (
-		taille, nomclient, uploaded
+		taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte
 		) = (
-		$1, $2, $3
-		) WHERE id = $4 RETURNING id, taille, nomclient, uploaded;
-		`, item.Taille, item.NomClient, item.Uploaded, item.Id)
+		$1, $2, $3, $4, $5, $6, $7
+		) WHERE id = $8 RETURNING id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte;
+		`, item.Taille, item.NomClient, item.Uploaded, item.Perime, item.Revue, item.RevueCommentaire, item.Empreinte, item.Id)
 	return ScanFile(row)
 }
 
 // Deletes the File and returns the item
 func DeleteFileById(tx DB, id IdFile) (File, error) {
-	row := tx.QueryRow("DELETE FROM files WHERE id = $1 RETURNING id, taille, nomclient, uploaded;", id)
+	row := tx.QueryRow("DELETE FROM files WHERE id = $1 RETURNING id, taille, nomclient, uploaded, perime, revue, revuecommentaire, empreinte;", id)
 	return ScanFile(row)
 }
 
@@ -876,6 +880,199 @@ func DeleteFileAidesByIdAides(tx DB, idAides_ ...camps.IdAide) (FileAides, error
 	return ScanFileAides(rows)
 }
 
+func scanOneFileCertification(row scanner) (FileCertification, error) {
+	var item FileCertification
+	err := row.Scan(
+		&item.IdFile,
+		&item.IdCertification,
+	)
+	return item, err
+}
+
+func ScanFileCertification(row *sql.Row) (FileCertification, error) {
+	return scanOneFileCertification(row)
+}
+
+// SelectAll returns all the items in the file_certifications table.
+func SelectAllFileCertifications(db DB) (FileCertifications, error) {
+	rows, err := db.Query("SELECT idfile, idcertification FROM file_certifications")
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileCertifications(rows)
+}
+
+type FileCertifications []FileCertification
+
+func ScanFileCertifications(rs *sql.Rows) (FileCertifications, error) {
+	var (
+		item FileCertification
+		err  error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(FileCertifications, 0, 16)
+	for rs.Next() {
+		item, err = scanOneFileCertification(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, item)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func (item FileCertification) Insert(db DB) error {
+	_, err := db.Exec(`INSERT INTO file_certifications (
+			idfile, idcertification
+			) VALUES (
+			$1, $2
+			);
+			`, item.IdFile, item.IdCertification)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Insert the links FileCertification in the database.
+// It is a no-op if 'items' is empty.
+func InsertManyFileCertifications(tx *sql.Tx, items ...FileCertification) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("file_certifications",
+		"idfile",
+		"idcertification",
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = stmt.Exec(item.IdFile, item.IdCertification)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete the link FileCertification from the database.
+// Only the foreign keys IdFile, IdCertification fields are used in 'item'.
+func (item FileCertification) Delete(tx DB) error {
+	_, err := tx.Exec(`DELETE FROM file_certifications WHERE IdFile = $1 AND IdCertification = $2;`, item.IdFile, item.IdCertification)
+	return err
+}
+
+// ByIdFile returns a map with 'IdFile' as keys.
+func (items FileCertifications) ByIdFile() map[IdFile]FileCertification {
+	out := make(map[IdFile]FileCertification, len(items))
+	for _, target := range items {
+		out[target.IdFile] = target
+	}
+	return out
+}
+
+// IdFiles returns the list of ids of IdFile
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items FileCertifications) IdFiles() []IdFile {
+	out := make([]IdFile, len(items))
+	for index, target := range items {
+		out[index] = target.IdFile
+	}
+	return out
+}
+
+// SelectFileCertificationByIdFile return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectFileCertificationByIdFile(tx DB, idFile IdFile) (item FileCertification, found bool, err error) {
+	row := tx.QueryRow("SELECT idfile, idcertification FROM file_certifications WHERE idfile = $1", idFile)
+	item, err = ScanFileCertification(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func SelectFileCertificationsByIdFiles(tx DB, idFiles_ ...IdFile) (FileCertifications, error) {
+	rows, err := tx.Query("SELECT idfile, idcertification FROM file_certifications WHERE idfile = ANY($1)", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileCertifications(rows)
+}
+
+func DeleteFileCertificationsByIdFiles(tx DB, idFiles_ ...IdFile) (FileCertifications, error) {
+	rows, err := tx.Query("DELETE FROM file_certifications WHERE idfile = ANY($1) RETURNING idfile, idcertification", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileCertifications(rows)
+}
+
+// ByIdCertification returns a map with 'IdCertification' as keys.
+func (items FileCertifications) ByIdCertification() map[personnes.IdCertification]FileCertification {
+	out := make(map[personnes.IdCertification]FileCertification, len(items))
+	for _, target := range items {
+		out[target.IdCertification] = target
+	}
+	return out
+}
+
+// IdCertifications returns the list of ids of IdCertification
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items FileCertifications) IdCertifications() []personnes.IdCertification {
+	out := make([]personnes.IdCertification, len(items))
+	for index, target := range items {
+		out[index] = target.IdCertification
+	}
+	return out
+}
+
+// SelectFileCertificationByIdCertification return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectFileCertificationByIdCertification(tx DB, idCertification personnes.IdCertification) (item FileCertification, found bool, err error) {
+	row := tx.QueryRow("SELECT idfile, idcertification FROM file_certifications WHERE idcertification = $1", idCertification)
+	item, err = ScanFileCertification(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func SelectFileCertificationsByIdCertifications(tx DB, idCertifications_ ...personnes.IdCertification) (FileCertifications, error) {
+	rows, err := tx.Query("SELECT idfile, idcertification FROM file_certifications WHERE idcertification = ANY($1)", personnes.IdCertificationArrayToPQ(idCertifications_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileCertifications(rows)
+}
+
+func DeleteFileCertificationsByIdCertifications(tx DB, idCertifications_ ...personnes.IdCertification) (FileCertifications, error) {
+	rows, err := tx.Query("DELETE FROM file_certifications WHERE idcertification = ANY($1) RETURNING idfile, idcertification", personnes.IdCertificationArrayToPQ(idCertifications_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileCertifications(rows)
+}
+
 func scanOneFileCamp(row scanner) (FileCamp, error) {
 	var item FileCamp
 	err := row.Scan(
@@ -1059,6 +1256,187 @@ func DeleteFileCampsByIdCamps(tx DB, idCamps_ ...camps.IdCamp) (FileCamps, error
 	return ScanFileCamps(rows)
 }
 
+func scanOneFileDepense(row scanner) (FileDepense, error) {
+	var item FileDepense
+	err := row.Scan(
+		&item.IdFile,
+		&item.IdDepense,
+	)
+	return item, err
+}
+
+func ScanFileDepense(row *sql.Row) (FileDepense, error) { return scanOneFileDepense(row) }
+
+// SelectAll returns all the items in the file_depenses table.
+func SelectAllFileDepenses(db DB) (FileDepenses, error) {
+	rows, err := db.Query("SELECT idfile, iddepense FROM file_depenses")
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileDepenses(rows)
+}
+
+type FileDepenses []FileDepense
+
+func ScanFileDepenses(rs *sql.Rows) (FileDepenses, error) {
+	var (
+		item FileDepense
+		err  error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(FileDepenses, 0, 16)
+	for rs.Next() {
+		item, err = scanOneFileDepense(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, item)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func (item FileDepense) Insert(db DB) error {
+	_, err := db.Exec(`INSERT INTO file_depenses (
+			idfile, iddepense
+			) VALUES (
+			$1, $2
+			);
+			`, item.IdFile, item.IdDepense)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Insert the links FileDepense in the database.
+// It is a no-op if 'items' is empty.
+func InsertManyFileDepenses(tx *sql.Tx, items ...FileDepense) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("file_depenses",
+		"idfile",
+		"iddepense",
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = stmt.Exec(item.IdFile, item.IdDepense)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete the link FileDepense from the database.
+// Only the foreign keys IdFile, IdDepense fields are used in 'item'.
+func (item FileDepense) Delete(tx DB) error {
+	_, err := tx.Exec(`DELETE FROM file_depenses WHERE IdFile = $1 AND IdDepense = $2;`, item.IdFile, item.IdDepense)
+	return err
+}
+
+// ByIdFile returns a map with 'IdFile' as keys.
+func (items FileDepenses) ByIdFile() map[IdFile]FileDepense {
+	out := make(map[IdFile]FileDepense, len(items))
+	for _, target := range items {
+		out[target.IdFile] = target
+	}
+	return out
+}
+
+// IdFiles returns the list of ids of IdFile
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items FileDepenses) IdFiles() []IdFile {
+	out := make([]IdFile, len(items))
+	for index, target := range items {
+		out[index] = target.IdFile
+	}
+	return out
+}
+
+// SelectFileDepenseByIdFile return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectFileDepenseByIdFile(tx DB, idFile IdFile) (item FileDepense, found bool, err error) {
+	row := tx.QueryRow("SELECT idfile, iddepense FROM file_depenses WHERE idfile = $1", idFile)
+	item, err = ScanFileDepense(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func SelectFileDepensesByIdFiles(tx DB, idFiles_ ...IdFile) (FileDepenses, error) {
+	rows, err := tx.Query("SELECT idfile, iddepense FROM file_depenses WHERE idfile = ANY($1)", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileDepenses(rows)
+}
+
+func DeleteFileDepensesByIdFiles(tx DB, idFiles_ ...IdFile) (FileDepenses, error) {
+	rows, err := tx.Query("DELETE FROM file_depenses WHERE idfile = ANY($1) RETURNING idfile, iddepense", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileDepenses(rows)
+}
+
+// ByIdDepense returns a map with 'IdDepense' as keys.
+func (items FileDepenses) ByIdDepense() map[camps.IdDepense]FileDepenses {
+	out := make(map[camps.IdDepense]FileDepenses)
+	for _, target := range items {
+		out[target.IdDepense] = append(out[target.IdDepense], target)
+	}
+	return out
+}
+
+// IdDepenses returns the list of ids of IdDepense
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items FileDepenses) IdDepenses() []camps.IdDepense {
+	out := make([]camps.IdDepense, len(items))
+	for index, target := range items {
+		out[index] = target.IdDepense
+	}
+	return out
+}
+
+func SelectFileDepensesByIdDepenses(tx DB, idDepenses_ ...camps.IdDepense) (FileDepenses, error) {
+	rows, err := tx.Query("SELECT idfile, iddepense FROM file_depenses WHERE iddepense = ANY($1)", camps.IdDepenseArrayToPQ(idDepenses_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileDepenses(rows)
+}
+
+func DeleteFileDepensesByIdDepenses(tx DB, idDepenses_ ...camps.IdDepense) (FileDepenses, error) {
+	rows, err := tx.Query("DELETE FROM file_depenses WHERE iddepense = ANY($1) RETURNING idfile, iddepense", camps.IdDepenseArrayToPQ(idDepenses_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFileDepenses(rows)
+}
+
 func scanOneFilePersonne(row scanner) (FilePersonne, error) {
 	var item FilePersonne
 	err := row.Scan(
@@ -1278,6 +1656,206 @@ func DeleteFilePersonnesByIdDemandes(tx DB, idDemandes_ ...IdDemande) (FilePerso
 	return ScanFilePersonnes(rows)
 }
 
+func scanOneSignature(row scanner) (Signature, error) {
+	var item Signature
+	err := row.Scan(
+		&item.Id,
+		&item.IdFile,
+		&item.IdPersonne,
+		&item.Document,
+		&item.Signataire,
+		&item.Moment,
+		&item.IP,
+		&item.Empreinte,
+	)
+	return item, err
+}
+
+func ScanSignature(row *sql.Row) (Signature, error) { return scanOneSignature(row) }
+
+// SelectAll returns all the items in the signatures table.
+func SelectAllSignatures(db DB) (Signatures, error) {
+	rows, err := db.Query("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures")
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
+// SelectSignature returns the entry matching 'id'.
+func SelectSignature(tx DB, id IdSignature) (Signature, error) {
+	row := tx.QueryRow("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures WHERE id = $1", id)
+	return ScanSignature(row)
+}
+
+// SelectSignatures returns the entry matching the given 'ids'.
+func SelectSignatures(tx DB, ids ...IdSignature) (Signatures, error) {
+	rows, err := tx.Query("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures WHERE id = ANY($1)", IdSignatureArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
+type Signatures map[IdSignature]Signature
+
+func (m Signatures) IDs() []IdSignature {
+	out := make([]IdSignature, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanSignatures(rs *sql.Rows) (Signatures, error) {
+	var (
+		s   Signature
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Signatures, 16)
+	for rs.Next() {
+		s, err = scanOneSignature(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Signature in the database and returns the item with id filled.
+func (item Signature) Insert(tx DB) (out Signature, err error) {
+	row := tx.QueryRow(`INSERT INTO signatures (
+		idfile, idpersonne, document, signataire, moment, ip, empreinte
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+		) RETURNING id, idfile, idpersonne, document, signataire, moment, ip, empreinte;
+		`, item.IdFile, item.IdPersonne, item.Document, item.Signataire, item.Moment, item.IP, item.Empreinte)
+	return ScanSignature(row)
+}
+
+// Update Signature in the database and returns the new version.
+func (item Signature) Update(tx DB) (out Signature, err error) {
+	row := tx.QueryRow(`UPDATE signatures SET (
+		idfile, idpersonne, document, signataire, moment, ip, empreinte
+		) = (
+		$1, $2, $3, $4, $5, $6, $7
+		) WHERE id = $8 RETURNING id, idfile, idpersonne, document, signataire, moment, ip, empreinte;
+		`, item.IdFile, item.IdPersonne, item.Document, item.Signataire, item.Moment, item.IP, item.Empreinte, item.Id)
+	return ScanSignature(row)
+}
+
+// Deletes the Signature and returns the item
+func DeleteSignatureById(tx DB, id IdSignature) (Signature, error) {
+	row := tx.QueryRow("DELETE FROM signatures WHERE id = $1 RETURNING id, idfile, idpersonne, document, signataire, moment, ip, empreinte;", id)
+	return ScanSignature(row)
+}
+
+// Deletes the Signature in the database and returns the ids.
+func DeleteSignaturesByIDs(tx DB, ids ...IdSignature) ([]IdSignature, error) {
+	rows, err := tx.Query("DELETE FROM signatures WHERE id = ANY($1) RETURNING id", IdSignatureArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdSignatureArray(rows)
+}
+
+// ByIdFile returns a map with 'IdFile' as keys.
+func (items Signatures) ByIdFile() map[IdFile]Signature {
+	out := make(map[IdFile]Signature, len(items))
+	for _, target := range items {
+		out[target.IdFile] = target
+	}
+	return out
+}
+
+// IdFiles returns the list of ids of IdFile
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Signatures) IdFiles() []IdFile {
+	out := make([]IdFile, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdFile)
+	}
+	return out
+}
+
+// SelectSignatureByIdFile return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectSignatureByIdFile(tx DB, idFile IdFile) (item Signature, found bool, err error) {
+	row := tx.QueryRow("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures WHERE idfile = $1", idFile)
+	item, err = ScanSignature(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func SelectSignaturesByIdFiles(tx DB, idFiles_ ...IdFile) (Signatures, error) {
+	rows, err := tx.Query("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures WHERE idfile = ANY($1)", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
+func DeleteSignaturesByIdFiles(tx DB, idFiles_ ...IdFile) (Signatures, error) {
+	rows, err := tx.Query("DELETE FROM signatures WHERE idfile = ANY($1) RETURNING id, idfile, idpersonne, document, signataire, moment, ip, empreinte", IdFileArrayToPQ(idFiles_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
+// ByIdPersonne returns a map with 'IdPersonne' as keys.
+func (items Signatures) ByIdPersonne() map[personnes.IdPersonne]Signatures {
+	out := make(map[personnes.IdPersonne]Signatures)
+	for _, target := range items {
+		dict := out[target.IdPersonne]
+		if dict == nil {
+			dict = make(Signatures)
+		}
+		dict[target.Id] = target
+		out[target.IdPersonne] = dict
+	}
+	return out
+}
+
+// IdPersonnes returns the list of ids of IdPersonne
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Signatures) IdPersonnes() []personnes.IdPersonne {
+	out := make([]personnes.IdPersonne, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdPersonne)
+	}
+	return out
+}
+
+func SelectSignaturesByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Signatures, error) {
+	rows, err := tx.Query("SELECT id, idfile, idpersonne, document, signataire, moment, ip, empreinte FROM signatures WHERE idpersonne = ANY($1)", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
+func DeleteSignaturesByIdPersonnes(tx DB, idPersonnes_ ...personnes.IdPersonne) (Signatures, error) {
+	rows, err := tx.Query("DELETE FROM signatures WHERE idpersonne = ANY($1) RETURNING id, idfile, idpersonne, document, signataire, moment, ip, empreinte", personnes.IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanSignatures(rows)
+}
+
 func IdDemandeArrayToPQ(ids []IdDemande) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -1332,6 +1910,33 @@ func ScanIdFileArray(rs *sql.Rows) ([]IdFile, error) {
 	return ints, nil
 }
 
+func IdSignatureArrayToPQ(ids []IdSignature) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdSignatureArray scans the result of a query returning a
+// list of ID's.
+func ScanIdSignatureArray(rs *sql.Rows) ([]IdSignature, error) {
+	defer rs.Close()
+	ints := make([]IdSignature, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdSignature
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func SwitchDemandePersonne(db DB, target personnes.OptIdPersonne, temporaire personnes.OptIdPersonne) error {
 	_, err := db.Exec("UPDATE demandes SET IdDirecteur = $1 WHERE IdDirecteur = $2;", target, temporaire)
 	return err
@@ -1341,3 +1946,8 @@ func SwitchFilePersonnePersonne(db DB, target personnes.IdPersonne, temporaire p
 	_, err := db.Exec("UPDATE file_personnes SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
 	return err
 }
+
+func SwitchSignaturePersonne(db DB, target personnes.IdPersonne, temporaire personnes.IdPersonne) error {
+	_, err := db.Exec("UPDATE signatures SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
+	return err
+}
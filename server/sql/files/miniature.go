@@ -3,7 +3,6 @@ package files
 import (
 	"bytes"
 	"fmt"
-	"image"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
@@ -29,7 +28,7 @@ func Init() error {
 }
 
 // computeMiniature réduit le document entrant à une image png.
-// Les formats supportés sont .pdf, .png, .jpg, .jpeg
+// Les formats supportés sont .pdf et les images (voir [isImage]).
 //
 // Le format .pdf requiert l'utilisation de Ghostscript.
 func computeMiniature(extension string, doc io.Reader) ([]byte, error) {
@@ -44,8 +43,12 @@ func computeMiniature(extension string, doc io.Reader) ([]byte, error) {
 		if err := cmd.Run(); err != nil {
 			return nil, fmt.Errorf("creating PDF miniature : %s", err)
 		}
-	case ".png", ".jpg", ".jpeg":
-		srcImage, _, err := image.Decode(doc)
+	case ".png", ".jpg", ".jpeg", ".gif", ".bmp", ".webp", ".tif", ".tiff", ".heic", ".heif":
+		content, err := io.ReadAll(doc)
+		if err != nil {
+			return nil, fmt.Errorf("creating image miniature : %s", err)
+		}
+		srcImage, err := decodeImage(ext, content)
 		if err != nil {
 			return nil, fmt.Errorf("creating image miniature : %s", err)
 		}
@@ -10,14 +10,16 @@ import (
 )
 
 type (
-	IdFile    int64
-	IdDemande int64
+	IdFile      int64
+	IdDemande   int64
+	IdSignature int64
 )
 
 // File représente les méta données d'un document stocké sur le serveur
 //
 // Le contenu et la miniature sont stockés dans un dossier, pour ne pas alourdir la
 // base de données.
+// gomacro:SQL CREATE INDEX ON File(Empreinte)
 type File struct {
 	Id IdFile
 
@@ -27,6 +29,21 @@ type File struct {
 	// different from the file path
 	NomClient string
 	Uploaded  time.Time
+
+	// Perime est vrai si la validité du document (voir [Demande.JoursValide])
+	// expire avant le prochain séjour : un nouveau document est alors demandé.
+	Perime bool
+
+	// Revue est l'état de la vérification du document
+	// par le directeur ou le centre
+	Revue Revue
+	// Motif du refus, affiché à l'expéditeur
+	RevueCommentaire string
+
+	// Empreinte SHA-256 (hexadécimal) du contenu, qui identifie
+	// le contenu stocké, éventuellement partagé entre plusieurs fichiers.
+	// Vide pour les fichiers stockés avant la déduplication.
+	Empreinte string
 }
 
 func (id IdFile) Opt() OptIdFile { return OptIdFile{Id: id, Valid: true} }
@@ -64,7 +81,7 @@ type Demande struct {
 	MaxDocs int
 
 	// JoursValide, si > 0, indique un document temporaire :
-	// une alerte est donnée pour les documents périmés
+	// les documents périmés sont signalés et redemandés (voir [File.Perime])
 	JoursValide int
 }
 
@@ -129,3 +146,42 @@ type FileAide struct {
 	IdFile IdFile `gomacro-sql-on-delete:"CASCADE"`
 	IdAide cps.IdAide
 }
+
+// FileCertification est une table de lien pour les justificatifs
+// des certifications des équipiers.
+//
+// gomacro:SQL ADD UNIQUE(IdFile)
+// gomacro:SQL ADD UNIQUE(IdCertification)
+type FileCertification struct {
+	IdFile          IdFile             `gomacro-sql-on-delete:"CASCADE"`
+	IdCertification pr.IdCertification `gomacro-sql-on-delete:"CASCADE"`
+}
+
+// FileDepense est une table de lien pour les justificatifs
+// des notes de frais (plusieurs par note).
+//
+// gomacro:SQL ADD UNIQUE(IdFile)
+type FileDepense struct {
+	IdFile    IdFile        `gomacro-sql-on-delete:"CASCADE"`
+	IdDepense cps.IdDepense `gomacro-sql-on-delete:"CASCADE"`
+}
+
+// Signature enregistre l'acceptation (signature électronique) d'un document
+// par une personne : charte, autorisation parentale ou fiche sanitaire.
+//
+// Le document signé, complété d'une page d'audit, est stocké dans [IdFile].
+//
+// gomacro:SQL ADD UNIQUE(IdFile)
+//
+// gomacro:QUERY SwitchSignaturePersonne UPDATE Signature SET IdPersonne = $target$ WHERE IdPersonne = $temporaire$;
+type Signature struct {
+	Id         IdSignature
+	IdFile     IdFile        `gomacro-sql-on-delete:"CASCADE"`
+	IdPersonne pr.IdPersonne `gomacro-sql-on-delete:"CASCADE"` // personne concernée
+	Document   DocumentSigne
+
+	Signataire string    // nom saisi par le signataire
+	Moment     time.Time // horodatage
+	IP         string    // adresse IP du signataire
+	Empreinte  string    // empreinte SHA-256 (hexadécimal) du contenu accepté
+}
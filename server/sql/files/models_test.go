@@ -39,6 +39,33 @@ func TestSQL(t *testing.T) {
 	err = FileCamp{IdCamp: camp.Id, IdFile: file3.Id, IsLettre: false}.Insert(db)
 	tu.AssertNoErr(t, err)
 
+	// justificatifs des notes de frais
+	equipier, err := camps.Equipier{IdCamp: camp.Id, IdPersonne: pers.Id, Roles: camps.Roles{camps.Animation}}.Insert(db)
+	tu.AssertNoErr(t, err)
+	depense, err := camps.Depense{IdEquipier: equipier.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file4, err := File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	file5, err := File{}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = FileDepense{file4.Id, depense.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = FileDepense{file5.Id, depense.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = camps.DeleteDepenseById(db, depense.Id)
+	tu.AssertNoErr(t, err)
+	links, err := SelectFileDepensesByIdFiles(db, file4.Id, file5.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(links) == 0) // cascade
+
+	// justificatif des certifications
+	certification, err := personnes.Certification{IdPersonne: pers.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = FileCertification{file4.Id, certification.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = FileCertification{file5.Id, certification.Id}.Insert(db)
+	tu.AssertErr(t, err) // un seul justificatif
+
 	// demandes
 	_, err = Demande{MaxDocs: 1, Categorie: Vaccins}.Insert(db)
 	tu.AssertNoErr(t, err)
@@ -0,0 +1,234 @@
+package files
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/benoitkugler/pdf/contentstream"
+	"github.com/benoitkugler/pdf/model"
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// Upload est un fichier envoyé par un utilisateur.
+type Upload struct {
+	Filename string
+	Content  []byte
+}
+
+// taille maximale par défaut (en octets) des documents produits par [FileSystem.NormaliseToPDF]
+const defaultMaxDocumentSize = 2_000_000
+
+// WithMaxDocumentSize renvoie un [FileSystem] utilisant [size] (en octets)
+// comme taille maximale des documents convertis en PDF.
+// Une valeur nulle correspond à la taille par défaut.
+func (fs FileSystem) WithMaxDocumentSize(size int) FileSystem {
+	fs.maxDocumentSize = size
+	return fs
+}
+
+// MaxDocumentSize renvoie la taille maximale (en octets)
+// des documents convertis en PDF.
+func (fs FileSystem) MaxDocumentSize() int {
+	if fs.maxDocumentSize == 0 {
+		return defaultMaxDocumentSize
+	}
+	return fs.maxDocumentSize
+}
+
+// isImage renvoie true pour les formats d'image supportés
+func isImage(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".bmp", ".webp", ".tif", ".tiff", ".heic", ".heif":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeImage décode l'image, en appliquant l'orientation EXIF.
+//
+// Les formats HEIC/HEIF requièrent l'utilisation de ImageMagick.
+func decodeImage(ext string, content []byte) (image.Image, error) {
+	switch strings.ToLower(ext) {
+	case ".heic", ".heif":
+		var out bytes.Buffer
+		cmd := exec.Command("convert", "heic:-", "-auto-orient", "jpeg:-")
+		cmd.Stdin = bytes.NewReader(content)
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("converting HEIC image : %s", err)
+		}
+		content = out.Bytes()
+	}
+	img, err := imaging.Decode(bytes.NewReader(content), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image : %s", err)
+	}
+	return img, nil
+}
+
+// isDocx vérifie que [content] est une archive contenant
+// un document Word (format OOXML).
+func isDocx(content []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return false
+	}
+	for _, file := range archive.File {
+		if file.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
+
+// docxToPDF convertit un document Word en PDF.
+//
+// La conversion requiert l'utilisation de LibreOffice.
+func docxToPDF(content []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "registro-docx")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "document.docx")
+	if err = os.WriteFile(input, content, 0o600); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("soffice", "--headless", "--convert-to", "pdf", "--outdir", dir, input)
+	cmd.Env = append(os.Environ(), "HOME="+dir) // profil LibreOffice temporaire
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("converting DOCX document : %s (%s)", err, out)
+	}
+	return os.ReadFile(filepath.Join(dir, "document.pdf"))
+}
+
+// paramètres de compression essayés successivement,
+// jusqu'à respecter la taille maximale
+var compressionSteps = [...]struct {
+	maxPixels int // plus grande dimension
+	quality   int // qualité JPEG
+}{
+	{2480, 85}, // A4 à 300 dpi
+	{2000, 75},
+	{1600, 65},
+	{1200, 55},
+	{900, 45},
+}
+
+// NormaliseToPDF vérifie le format des fichiers envoyés (voir [CheckFormat])
+// et les convertit en un unique document PDF :
+//   - un document PDF est conservé tel quel (il ne peut pas être combiné avec d'autres fichiers)
+//   - un document Word (.docx) est converti en PDF (il ne peut pas non plus être combiné)
+//   - les images (éventuellement plusieurs) sont placées une par page, et compressées
+//     si besoin pour respecter la taille maximale (voir [FileSystem.WithMaxDocumentSize])
+func (fs FileSystem) NormaliseToPDF(uploads []Upload) (Upload, error) {
+	if len(uploads) == 0 {
+		return Upload{}, errors.New("missing file")
+	}
+	maxDocumentSize := fs.MaxDocumentSize()
+	name := strings.TrimSuffix(uploads[0].Filename, filepath.Ext(uploads[0].Filename)) + ".pdf"
+	var images []image.Image
+	for _, upload := range uploads {
+		ext := filepath.Ext(upload.Filename)
+		isDocument := strings.ToLower(ext) == ".pdf" || strings.ToLower(ext) == ".docx"
+		if isDocument && len(uploads) != 1 {
+			return Upload{}, errors.New("Un document PDF ou Word ne peut pas être combiné avec d'autres fichiers.")
+		}
+		if strings.ToLower(ext) == ".docx" {
+			if !isDocx(upload.Content) {
+				return Upload{}, fmt.Errorf("Le contenu du fichier %s ne correspond pas à son extension.", upload.Filename)
+			}
+			content, err := docxToPDF(upload.Content)
+			if err != nil {
+				return Upload{}, err
+			}
+			if len(content) > maxDocumentSize {
+				return Upload{}, fmt.Errorf("Le document est trop volumineux (maximum %d Ko).", maxDocumentSize/1000)
+			}
+			return Upload{name, content}, nil
+		}
+		if err := CheckFormat(upload.Filename, upload.Content); err != nil {
+			return Upload{}, err
+		}
+		if isDocument {
+			return upload, nil
+		}
+		if !isImage(ext) {
+			return Upload{}, fmt.Errorf("Le format %s n'est pas supporté : merci d'envoyer un document PDF, Word ou une image.", ext)
+		}
+		img, err := decodeImage(ext, upload.Content)
+		if err != nil {
+			return Upload{}, err
+		}
+		images = append(images, img)
+	}
+
+	for _, step := range compressionSteps {
+		content, err := imagesToPDF(images, step.maxPixels, step.quality)
+		if err != nil {
+			return Upload{}, err
+		}
+		if len(content) <= maxDocumentSize {
+			return Upload{name, content}, nil
+		}
+	}
+	return Upload{}, fmt.Errorf("Le document est trop volumineux (maximum %d Ko).", maxDocumentSize/1000)
+}
+
+// dimensions d'une page A4, en points
+const a4Width, a4Height = 595, 842
+
+// imagesToPDF place chaque image sur une page A4 (orientée selon l'image),
+// après l'avoir réduite et compressée en JPEG.
+func imagesToPDF(images []image.Image, maxPixels, quality int) ([]byte, error) {
+	var doc model.Document
+	for _, img := range images {
+		if b := img.Bounds(); b.Dx() > maxPixels || b.Dy() > maxPixels {
+			img = imaging.Fit(img, maxPixels, maxPixels, imaging.Lanczos)
+		}
+		// les zones transparentes sont rendues en blanc
+		flat := imaging.New(img.Bounds().Dx(), img.Bounds().Dy(), color.White)
+		flat = imaging.Overlay(flat, img, image.Point{}, 1)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		xObject, _, err := contentstream.ParseImage(&buf, "image/jpeg")
+		if err != nil {
+			return nil, err
+		}
+
+		pageW, pageH := model.Fl(a4Width), model.Fl(a4Height)
+		if xObject.Width > xObject.Height {
+			pageW, pageH = pageH, pageW
+		}
+		const margin = 20
+		scale := min((pageW-2*margin)/model.Fl(xObject.Width), (pageH-2*margin)/model.Fl(xObject.Height))
+		w, h := scale*model.Fl(xObject.Width), scale*model.Fl(xObject.Height)
+
+		gs := contentstream.NewGraphicStream(model.Rectangle{Urx: pageW, Ury: pageH})
+		gs.AddXObjectDims(xObject, (pageW-w)/2, (pageH-h)/2, w, h)
+		page := new(model.PageObject)
+		gs.ApplyToPageObject(page, true)
+		doc.Catalog.Pages.Kids = append(doc.Catalog.Pages.Kids, page)
+	}
+	var out bytes.Buffer
+	if err := doc.Write(&out, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package files
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/benoitkugler/pdf/reader"
+	"github.com/disintegration/imaging"
+
+	tu "registro/utils/testutils"
+)
+
+func TestNormaliseToPDF(t *testing.T) {
+	var fs FileSystem
+	img1, err := os.ReadFile("test/img1.png")
+	tu.AssertNoErr(t, err)
+	img2, err := os.ReadFile("test/img2.JPG")
+	tu.AssertNoErr(t, err)
+	doc3, err := os.ReadFile("test/doc3.pdf")
+	tu.AssertNoErr(t, err)
+
+	_, err = fs.NormaliseToPDF(nil)
+	tu.AssertErr(t, err)
+	_, err = fs.NormaliseToPDF([]Upload{{"lettre.docx", nil}})
+	tu.AssertErr(t, err)
+	_, err = fs.NormaliseToPDF([]Upload{{"doc.pdf", doc3}, {"img.png", img1}})
+	tu.AssertErr(t, err)
+	_, err = fs.NormaliseToPDF([]Upload{{"img.png", img1}, {"lettre.docx", newDocx(t, "word/document.xml")}})
+	tu.AssertErr(t, err)
+	_, err = fs.NormaliseToPDF([]Upload{{"archive.docx", newDocx(t, "readme.txt")}})
+	tu.AssertErr(t, err)
+	_, err = fs.NormaliseToPDF([]Upload{{"img.pdf", img1}})
+	tu.AssertErr(t, err)
+
+	// PDF conservé tel quel
+	out, err := fs.NormaliseToPDF([]Upload{{"doc.pdf", doc3}})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, bytes.Equal(out.Content, doc3))
+
+	out, err = fs.NormaliseToPDF([]Upload{{"scan recto.png", img1}, {"scan verso.JPG", img2}})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, out.Filename == "scan recto.pdf")
+	tu.Assert(t, len(out.Content) <= defaultMaxDocumentSize)
+	doc, _, err := reader.ParsePDFReader(bytes.NewReader(out.Content), reader.Options{})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(doc.Catalog.Pages.Flatten()) == 2)
+	tu.Write(t, "normalise.pdf", out.Content)
+}
+
+func TestNormaliseCompression(t *testing.T) {
+	var fs FileSystem
+
+	// une image bruitée, difficile à compresser
+	noise := image.NewNRGBA(image.Rect(0, 0, 3000, 2000))
+	for i := range noise.Pix {
+		noise.Pix[i] = uint8(i * 7919 % 251)
+	}
+	var buf bytes.Buffer
+	tu.AssertNoErr(t, png.Encode(&buf, noise))
+
+	fs = fs.WithMaxDocumentSize(400_000)
+	out, err := fs.NormaliseToPDF([]Upload{{"photo.png", buf.Bytes()}})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(out.Content) <= 400_000)
+
+	fs = fs.WithMaxDocumentSize(1000)
+	_, err = fs.NormaliseToPDF([]Upload{{"photo.png", buf.Bytes()}})
+	tu.AssertErr(t, err)
+}
+
+// withOrientation ajoute un segment EXIF indiquant l'orientation [o]
+func withOrientation(jpg []byte, o byte) []byte {
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08" + // en-tête TIFF
+		"\x00\x01" + // une entrée
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00" + string(o) + "\x00\x00" + // Orientation (SHORT)
+		"\x00\x00\x00\x00")
+	segment := append([]byte{0xFF, 0xE1, 0, byte(len(exif) + 2)}, exif...)
+	return append(append([]byte{0xFF, 0xD8}, segment...), jpg[2:]...)
+}
+
+func TestDecodeImageOrientation(t *testing.T) {
+	img := imaging.New(30, 10, image.White.C)
+	var buf bytes.Buffer
+	tu.AssertNoErr(t, imaging.Encode(&buf, img, imaging.JPEG))
+
+	decoded, err := decodeImage(".jpg", buf.Bytes())
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, decoded.Bounds().Dx() == 30)
+
+	decoded, err = decodeImage(".jpeg", withOrientation(buf.Bytes(), 6)) // rotation de 90°
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, decoded.Bounds().Dx() == 10 && decoded.Bounds().Dy() == 30)
+}
+
+// newDocx renvoie une archive contenant les fichiers (vides) [names]
+func newDocx(t *testing.T, names ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		_, err := w.Create(name)
+		tu.AssertNoErr(t, err)
+	}
+	tu.AssertNoErr(t, w.Close())
+	return buf.Bytes()
+}
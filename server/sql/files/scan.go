@@ -0,0 +1,140 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Scanner analyse le contenu d'un fichier envoyé,
+// avant son enregistrement (voir [UploadFile]).
+type Scanner interface {
+	// Scan renvoie une erreur [ErrInfected] si le contenu est refusé,
+	// ou une autre erreur si l'analyse n'a pas pu être menée.
+	Scan(content []byte) error
+}
+
+// ErrInfected est renvoyée pour un fichier refusé par l'analyse.
+type ErrInfected struct {
+	Menace string // nom de la menace détectée
+}
+
+func (err ErrInfected) Error() string {
+	return fmt.Sprintf("Le document a été refusé par l'antivirus (%s).", err.Menace)
+}
+
+// ClamdScanner utilise un démon clamd, joint par un socket Unix local.
+type ClamdScanner struct {
+	socket  string
+	timeout time.Duration
+}
+
+// NewClamdScanner utilise le socket Unix [socket],
+// par exemple /var/run/clamav/clamd.ctl
+func NewClamdScanner(socket string) ClamdScanner {
+	return ClamdScanner{socket: socket, timeout: 30 * time.Second}
+}
+
+// taille des morceaux envoyés à clamd
+const clamdChunkSize = 1 << 16
+
+// Scan utilise la commande INSTREAM de clamd.
+func (cl ClamdScanner) Scan(content []byte) error {
+	conn, err := net.DialTimeout("unix", cl.socket, cl.timeout)
+	if err != nil {
+		return fmt.Errorf("connecting to clamd: %s", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(cl.timeout))
+
+	if err = writeInstream(conn, content); err != nil {
+		return fmt.Errorf("sending to clamd: %s", err)
+	}
+	response, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("reading clamd response: %s", err)
+	}
+	return parseClamdResponse(response)
+}
+
+// writeInstream envoie [content] par morceaux, chacun précédé
+// de sa taille, et termine par un morceau vide.
+func writeInstream(w io.Writer, content []byte) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	var size [4]byte
+	for len(content) > 0 {
+		chunk := content[:min(len(content), clamdChunkSize)]
+		content = content[len(chunk):]
+		binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+		if _, err := w.Write(size[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	_, err := w.Write(size[:])
+	return err
+}
+
+// parseClamdResponse interprète les réponses de la forme
+//
+//	stream: OK
+//	stream: Eicar-Signature FOUND
+//	INSTREAM size limit exceeded. ERROR
+func parseClamdResponse(response string) error {
+	response = strings.TrimSpace(strings.TrimRight(response, "\x00"))
+	switch {
+	case strings.HasSuffix(response, " OK"):
+		return nil
+	case strings.HasSuffix(response, " FOUND"):
+		menace := strings.TrimSuffix(response, " FOUND")
+		menace = strings.TrimSpace(strings.TrimPrefix(menace, "stream:"))
+		return ErrInfected{Menace: menace}
+	default:
+		return fmt.Errorf("clamd error: %s", response)
+	}
+}
+
+// eicar est le fichier de test standard des antivirus
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// StubScanner est utilisé pour les tests : seul le
+// fichier de test EICAR est refusé.
+type StubScanner struct{}
+
+func (StubScanner) Scan(content []byte) error {
+	if bytes.Contains(content, []byte(eicar)) {
+		return ErrInfected{Menace: "Eicar-Test-Signature"}
+	}
+	return nil
+}
+
+// quarantineKey renvoie la clé de stockage d'un fichier refusé
+func quarantineKey(moment time.Time) string {
+	return fmt.Sprintf("quarantine_%d", moment.UnixNano())
+}
+
+// scan analyse [content] avec le [Scanner] configuré, et
+// place un fichier refusé en quarantaine.
+func (fs FileSystem) scan(content []byte) error {
+	if fs.scanner == nil {
+		return nil
+	}
+	err := fs.scanner.Scan(content)
+	if infected, ok := err.(ErrInfected); ok {
+		if errQ := fs.backend().Put(quarantineKey(time.Now()), content); errQ != nil {
+			return fmt.Errorf("failed to quarantine document: %s", errQ)
+		}
+		return infected
+	}
+	return err
+}
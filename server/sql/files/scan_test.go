@@ -0,0 +1,118 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tu "registro/utils/testutils"
+)
+
+func TestParseClamdResponse(t *testing.T) {
+	tu.AssertNoErr(t, parseClamdResponse("stream: OK\x00"))
+
+	err := parseClamdResponse("stream: Win.Test.EICAR_HDB-1 FOUND\x00")
+	infected, ok := err.(ErrInfected)
+	tu.Assert(t, ok && infected.Menace == "Win.Test.EICAR_HDB-1")
+
+	err = parseClamdResponse("INSTREAM size limit exceeded. ERROR\x00")
+	_, ok = err.(ErrInfected)
+	tu.Assert(t, err != nil && !ok)
+}
+
+// readInstream décode une commande INSTREAM
+func readInstream(r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	command, err := br.ReadString(0)
+	if err != nil {
+		return nil, err
+	}
+	if command != "zINSTREAM\x00" {
+		return nil, io.ErrUnexpectedEOF
+	}
+	var out []byte
+	for {
+		var size uint32
+		if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return out, nil
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		out = append(out, chunk...)
+	}
+}
+
+func TestWriteInstream(t *testing.T) {
+	content := bytes.Repeat([]byte("abcd"), clamdChunkSize) // several chunks
+	var buf bytes.Buffer
+	tu.AssertNoErr(t, writeInstream(&buf, content))
+	got, err := readInstream(&buf)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, bytes.Equal(got, content))
+}
+
+func TestClamdScanner(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "clamd.sock")
+	listener, err := net.Listen("unix", socket)
+	tu.AssertNoErr(t, err)
+	defer listener.Close()
+
+	// serveur clamd minimal, utilisant [StubScanner]
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			content, err := readInstream(conn)
+			switch {
+			case err != nil:
+				io.WriteString(conn, "INSTREAM: invalid command. ERROR\x00")
+			case StubScanner{}.Scan(content) != nil:
+				io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+			default:
+				io.WriteString(conn, "stream: OK\x00")
+			}
+			conn.Close()
+		}
+	}()
+
+	scanner := NewClamdScanner(socket)
+	tu.AssertNoErr(t, scanner.Scan(tu.PngData))
+	err = scanner.Scan([]byte(eicar))
+	_, ok := err.(ErrInfected)
+	tu.Assert(t, ok)
+
+	err = NewClamdScanner(filepath.Join(t.TempDir(), "missing.sock")).Scan(tu.PngData)
+	tu.AssertErr(t, err)
+}
+
+func TestQuarantine(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	fs := NewFileSystemFrom(storage).WithScanner(StubScanner{})
+
+	tu.AssertNoErr(t, fs.scan(tu.PngData))
+	keys, err := storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 0)
+
+	err = fs.scan([]byte(eicar))
+	_, ok := err.(ErrInfected)
+	tu.Assert(t, ok)
+	keys, err = storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 1 && strings.HasPrefix(keys[0], "quarantine_"))
+
+	// no scanner
+	tu.AssertNoErr(t, NewFileSystemFrom(storage).scan([]byte(eicar)))
+}
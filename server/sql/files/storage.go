@@ -0,0 +1,265 @@
+package files
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"registro/config"
+)
+
+// Storage abstrait le support utilisé pour stocker le contenu
+// des fichiers, chacun étant identifié par une clé (voir [IdFile.key]).
+type Storage interface {
+	// Put enregistre [content], en remplaçant un éventuel contenu existant.
+	Put(key string, content []byte) error
+	// Get renvoie le contenu associé à [key], qui doit être fermé par l'appelant.
+	Get(key string) (io.ReadCloser, error)
+	// Delete supprime le contenu associé à [key].
+	Delete(key string) error
+	// Keys renvoie toutes les clés stockées.
+	Keys() ([]string, error)
+}
+
+// LocalStorage stocke les fichiers dans un dossier local.
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage utilise [root] comme dossier de stockage.
+func NewLocalStorage(root string) LocalStorage { return LocalStorage{root: root} }
+
+func (ls LocalStorage) Put(key string, content []byte) error {
+	return os.WriteFile(filepath.Join(ls.root, key), content, os.ModePerm)
+}
+
+func (ls LocalStorage) Get(key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(ls.root, key))
+}
+
+func (ls LocalStorage) Delete(key string) error {
+	return os.Remove(filepath.Join(ls.root, key))
+}
+
+func (ls LocalStorage) Keys() ([]string, error) {
+	entries, err := os.ReadDir(ls.root)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			out = append(out, entry.Name())
+		}
+	}
+	return out, nil
+}
+
+// S3Storage stocke les fichiers dans un bucket compatible S3
+// (AWS, Scaleway, OVH, MinIO, ...), en utilisant des URLs
+// de la forme <endpoint>/<bucket>/<key>.
+//
+// Les requêtes sont signées avec AWS Signature Version 4.
+type S3Storage struct {
+	endpoint  url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+
+	client *http.Client
+}
+
+// NewS3Storage vérifie [cfg] et renvoie le stockage S3 correspondant.
+func NewS3Storage(cfg config.S3) (S3Storage, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return S3Storage{}, fmt.Errorf("invalid S3 endpoint %s", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return S3Storage{}, errors.New("missing S3 bucket")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return S3Storage{*u, region, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, &http.Client{Timeout: 5 * time.Minute}}, nil
+}
+
+func (s3 S3Storage) Put(key string, content []byte) error {
+	resp, err := s3.do(http.MethodPut, key, nil, content)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s3 S3Storage) Get(key string) (io.ReadCloser, error) {
+	resp, err := s3.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s3 S3Storage) Delete(key string) error {
+	resp, err := s3.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// Keys utilise ListObjectsV2, en suivant la pagination.
+func (s3 S3Storage) Keys() ([]string, error) {
+	var (
+		out   []string
+		token string
+	)
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s3.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3 list response: %s", err)
+		}
+		for _, item := range page.Contents {
+			out = append(out, item.Key)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// do envoie une requête signée, et renvoie une erreur
+// si le statut de la réponse n'est pas 2xx.
+func (s3 S3Storage) do(method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := s3.endpoint
+	u.Path = u.Path + "/" + s3.bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	s3.sign(req, body, time.Now().UTC())
+
+	resp, err := s3.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request failed: %s", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("S3 %s %s failed: %s (%s)", method, key, resp.Status, msg)
+	}
+	return resp, nil
+}
+
+func hashHex(content []byte) string {
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Escape encode selon la RFC 3986, comme attendu par AWS.
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var chunks []string
+	for _, k := range keys {
+		values := slices.Clone(query[k])
+		sort.Strings(values)
+		for _, v := range values {
+			chunks = append(chunks, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(chunks, "&")
+}
+
+// sign ajoute les en-têtes d'authentification AWS Signature Version 4
+func (s3 S3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := hashHex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s3.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hashHex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s3.secretKey), day)
+	signingKey = hmacSHA256(signingKey, s3.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3.accessKey, scope, signedHeaders, signature))
+}
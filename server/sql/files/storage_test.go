@@ -0,0 +1,137 @@
+package files
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"registro/config"
+	tu "registro/utils/testutils"
+)
+
+// fakeS3 est un serveur S3 minimal (en mémoire), qui vérifie
+// la présence de la signature et l'empreinte du contenu.
+type fakeS3 struct {
+	bucket   string
+	pageSize int
+
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		http.Error(w, "missing signature", http.StatusForbidden)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != f.bucket {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	switch {
+	case r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		if hashHex(content) != r.Header.Get("x-amz-content-sha256") {
+			http.Error(w, "XAmzContentSHA256Mismatch", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = content
+	case r.Method == http.MethodGet && key == "":
+		var keys []string
+		for k := range f.objects {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		start := 0
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			fmt.Sscanf(token, "%d", &start)
+		}
+		var page listBucketResult
+		end := min(start+f.pageSize, len(keys))
+		for _, k := range keys[start:end] {
+			page.Contents = append(page.Contents, struct{ Key string }{k})
+		}
+		if end < len(keys) {
+			page.IsTruncated = true
+			page.NextContinuationToken = fmt.Sprint(end)
+		}
+		xml.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodGet:
+		content, has := f.objects[key]
+		if !has {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func testStorage(t *testing.T, storage Storage) {
+	fs := NewFileSystemFrom(storage)
+	for id := IdFile(1); id <= 3; id++ {
+		tu.AssertNoErr(t, fs.Save(File{Id: id}, []byte(fmt.Sprintf("content %d", id)), false))
+		tu.AssertNoErr(t, fs.Save(File{Id: id}, []byte("miniature"), true))
+	}
+
+	content, err := fs.Load(File{Id: 2}, false)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, string(content) == "content 2")
+
+	r, err := fs.Open(File{Id: 3}, true)
+	tu.AssertNoErr(t, err)
+	content, err = io.ReadAll(r)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, r.Close())
+	tu.Assert(t, string(content) == "miniature")
+
+	keys, err := storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 6)
+
+	tu.AssertNoErr(t, fs.Delete(nil, File{Id: 2})) // legacy content: no DB access
+	_, err = fs.Load(File{Id: 2}, false)
+	tu.AssertErr(t, err)
+	keys, err = storage.Keys()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(keys) == 4)
+}
+
+func TestLocalStorage(t *testing.T) {
+	testStorage(t, NewLocalStorage(t.TempDir()))
+}
+
+func TestS3Storage(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{bucket: "registro", pageSize: 4, objects: map[string][]byte{}})
+	defer server.Close()
+
+	_, err := NewS3Storage(config.S3{Endpoint: "invalid"})
+	tu.AssertErr(t, err)
+
+	storage, err := NewS3Storage(config.S3{Endpoint: server.URL, Bucket: "registro", AccessKey: "access", SecretKey: "secret"})
+	tu.AssertNoErr(t, err)
+	testStorage(t, storage)
+
+	bad, err := NewS3Storage(config.S3{Endpoint: server.URL, Bucket: "other", AccessKey: "access", SecretKey: "secret"})
+	tu.AssertNoErr(t, err)
+	tu.AssertErr(t, bad.Put("file_1", nil))
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	tu.Assert(t, canonicalQuery(nil) == "")
+	tu.Assert(t, canonicalQuery(map[string][]string{"list-type": {"2"}, "continuation-token": {"a b+c/"}}) ==
+		"continuation-token=a%20b%2Bc%2F&list-type=2")
+}
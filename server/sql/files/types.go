@@ -31,10 +31,59 @@ const (
 	ExtraitCasierJudiciaire // Extrait du casier judiciaire
 	Autre                   // Autre
 
+	PAI // Projet d'accueil individualisé (PAI)
 )
 
+// les catégories suivant [Autre] ne sont pas demandées aux équipiers
 const nbCategorieEquipier = int(Autre) + 1
 
+// DocumentSigne est le type d'un document signé électroniquement
+type DocumentSigne uint8
+
+const (
+	CharteParticipant     DocumentSigne = iota // Charte participant
+	CharteEquipier                             // Charte équipier
+	AutorisationParentale                      // Autorisation parentale
+	FicheSanitaire                             // Fiche sanitaire
+)
+
+func (d DocumentSigne) String() string {
+	switch d {
+	case CharteParticipant:
+		return "Charte participant"
+	case CharteEquipier:
+		return "Charte équipier"
+	case AutorisationParentale:
+		return "Autorisation parentale"
+	case FicheSanitaire:
+		return "Fiche sanitaire"
+	default:
+		return ""
+	}
+}
+
+// Revue indique si un document envoyé a été vérifié.
+type Revue uint8
+
+const (
+	EnAttente Revue = iota // En attente
+	Accepte                // Accepté
+	Refuse                 // Refusé
+)
+
+func (r Revue) String() string {
+	switch r {
+	case EnAttente:
+		return "En attente"
+	case Accepte:
+		return "Accepté"
+	case Refuse:
+		return "Refusé"
+	default:
+		return ""
+	}
+}
+
 func (c Categorie) String() string {
 	switch c {
 	case CarteId:
@@ -67,6 +116,8 @@ func (c Categorie) String() string {
 		return "Extrait du casier judiciaire"
 	case Autre:
 		return "Autre"
+	case PAI:
+		return "Projet d'accueil individualisé (PAI)"
 	default:
 		return ""
 	}
@@ -91,7 +142,7 @@ func (bs Builtins) List() []Demande {
 
 func (ds Demandes) builtins() (out [nbCategorieEquipier]Demande, err error) {
 	for _, demande := range ds {
-		if demande.Categorie == NoBuiltin {
+		if demande.Categorie == NoBuiltin || int(demande.Categorie) >= nbCategorieEquipier {
 			continue
 		}
 		out[demande.Categorie] = demande
@@ -29,6 +29,7 @@ func TestBuiltins(t *testing.T) {
 		13: {Id: 13, Categorie: 13},
 		14: {Id: 14, Categorie: 14},
 		15: {Id: 15, Categorie: 15},
+		16: {Id: 16, Categorie: PAI}, // ignoré
 	}.builtins()
 	tu.AssertNoErr(t, err)
 }
@@ -43,6 +43,68 @@ func (s Sexe) String() string {
 	}
 }
 
+func (d Diplome) String() string {
+	switch d {
+	case DBafa:
+		return "BAFA Titulaire"
+	case DBafaStag:
+		return "BAFA Stagiaire"
+	case DBafd:
+		return "BAFD titulaire"
+	case DBafdStag:
+		return "BAFD stagiaire"
+	case DCap:
+		return "CAP petit enfance"
+	case DAssSociale:
+		return "Assitante Sociale"
+	case DEducSpe:
+		return "Educ. spé."
+	case DMonEduc:
+		return "Moniteur educateur"
+	case DInstit:
+		return "Professeur des écoles"
+	case DProf:
+		return "Enseignant du secondaire"
+	case DAgreg:
+		return "Agrégé"
+	case DBjeps:
+		return "BPJEPS"
+	case DDut:
+		return "DUT carrière sociale"
+	case DEje:
+		return "EJE"
+	case DDeug:
+		return "DEUG"
+	case DStaps:
+		return "STAPS"
+	case DBapaat:
+		return "BAPAAT"
+	case DBeatep:
+		return "BEATEP"
+	case DZzautre:
+		return "AUTRE"
+	default:
+		return "Aucun"
+	}
+}
+
+func (a Approfondissement) String() string {
+	switch a {
+	case AAutre:
+		return "Approfondissement"
+	case ASb:
+		return "Surveillant de baignade"
+	case ACanoe:
+		return "Canoë - Kayak"
+	case AVoile:
+		return "Voile"
+	case AMoto:
+		return "Loisirs motocyclistes"
+	default:
+		return "Non effectué"
+	}
+}
+
 // Accord returns "e" for women
 func (s Sexe) Accord() string {
 	if s == Woman {
@@ -83,3 +145,24 @@ func (t Tels) String() string { return renderTels(t, innerTelSep, ";") }
 
 // StringLines renvoie une chaine sur plusieurs lignes, au format HTML
 func (t Tels) StringHTML() string { return renderTels(t, innerTelSep, "<br/>") }
+
+func (c CertificationKind) String() string {
+	switch c {
+	case CBafa:
+		return "BAFA"
+	case CBafd:
+		return "BAFD"
+	case CPsc1:
+		return "PSC1"
+	case CSurveillantBaignade:
+		return "Surveillant de baignade"
+	case CBnssa:
+		return "BNSSA"
+	case CPermisB:
+		return "Permis B"
+	case CPermisD:
+		return "Permis D"
+	default:
+		return "Autre"
+	}
+}
@@ -15,6 +15,15 @@ CREATE TYPE Publicite AS (
     Eonews boolean
 );
 
+CREATE TABLE certifications (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    Kind smallint CHECK (Kind IN (0, 1, 2, 3, 4, 5, 6, 7)) NOT NULL,
+    Label text NOT NULL,
+    Obtention date NOT NULL,
+    Expiration date NOT NULL
+);
+
 CREATE TABLE ficheequipiers (
     IdPersonne integer NOT NULL,
     SecuriteSociale text NOT NULL,
@@ -39,8 +48,11 @@ CREATE TABLE ficheequipiers (
 CREATE TABLE fichesanitaires (
     IdPersonne integer NOT NULL,
     DifficultesSante text NOT NULL,
-    AllergiesAlimentaires text NOT NULL,
-    TraitementMedical text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
     Medecin jsonb NOT NULL,
     AutreContact jsonb NOT NULL,
     Modified timestamp(0) with time zone NOT NULL,
@@ -48,6 +60,27 @@ CREATE TABLE fichesanitaires (
     guard boolean NOT NULL
 );
 
+CREATE TABLE fichesanitaireversions (
+    Id serial PRIMARY KEY,
+    IdPersonne integer NOT NULL,
+    DifficultesSante text NOT NULL,
+    Traitements jsonb NOT NULL,
+    Allergies jsonb NOT NULL,
+    Regimes smallint[],
+    PAI boolean NOT NULL,
+    Vaccinations jsonb NOT NULL,
+    Medecin jsonb NOT NULL,
+    AutreContact jsonb NOT NULL,
+    Modified timestamp(0) with time zone NOT NULL,
+    Auteur text NOT NULL
+);
+
+CREATE TABLE identifications (
+    Id serial PRIMARY KEY,
+    Moment timestamp(0) with time zone NOT NULL,
+    Record bytea NOT NULL
+);
+
 CREATE TABLE personnes (
     Id serial PRIMARY KEY,
     Nom text NOT NULL,
@@ -85,6 +118,9 @@ ALTER TABLE fichesanitaires
 ALTER TABLE fichesanitaires
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE fichesanitaireversions
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
 ALTER TABLE ficheequipiers
     ADD UNIQUE (IdPersonne);
 
@@ -100,6 +136,97 @@ ALTER TABLE ficheequipiers
 ALTER TABLE ficheequipiers
     ADD CHECK (guard = FALSE);
 
+ALTER TABLE certifications
+    ADD FOREIGN KEY (IdPersonne) REFERENCES personnes ON DELETE CASCADE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Traitement (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_pers_Vaccination (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_boolean (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'boolean';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a boolean', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Allergies (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Asthme', 'Alimentaires', 'Medicamenteuses', 'Autres', 'Aliments', 'Precisions', 'ConduiteATenir'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_boolean (data -> 'Asthme')
+        AND gomacro_validate_json_boolean (data -> 'Alimentaires')
+        AND gomacro_validate_json_boolean (data -> 'Medicamenteuses')
+        AND gomacro_validate_json_string (data -> 'Autres')
+        AND gomacro_validate_json_string (data -> 'Aliments')
+        AND gomacro_validate_json_string (data -> 'Precisions')
+        AND gomacro_validate_json_string (data -> 'ConduiteATenir');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_NomTel (data jsonb)
     RETURNS boolean
     AS $$
@@ -146,6 +273,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Traitement (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Medicament', 'Dose', 'Horaire', 'Duree'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Medicament')
+        AND gomacro_validate_json_string (data -> 'Dose')
+        AND gomacro_validate_json_string (data -> 'Horaire')
+        AND gomacro_validate_json_string (data -> 'Duree');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_pers_Vaccination (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(KEY IN ('Vaccin', 'Date'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Vaccin')
+        AND gomacro_validate_json_string (data -> 'Date');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
     RETURNS boolean
     AS $$
@@ -161,12 +334,36 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
 
 ALTER TABLE fichesanitaires
     ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
 
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaires
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Allergies_gomacro CHECK (gomacro_validate_json_pers_Allergies (Allergies));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT AutreContact_gomacro CHECK (gomacro_validate_json_pers_NomTel (AutreContact));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Medecin_gomacro CHECK (gomacro_validate_json_pers_NomTel (Medecin));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Traitements_gomacro CHECK (gomacro_validate_json_array_pers_Traitement (Traitements));
+
+ALTER TABLE fichesanitaireversions
+    ADD CONSTRAINT Vaccinations_gomacro CHECK (gomacro_validate_json_array_pers_Vaccination (Vaccinations));
+
 ALTER TABLE ficheequipiers
     ADD CONSTRAINT Recommandation_gomacro CHECK (gomacro_validate_json_pers_Recommandation (Recommandation));
 
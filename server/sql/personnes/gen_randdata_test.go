@@ -8,12 +8,43 @@ import (
 
 // Code generated by gomacro/generator/go/randdata. DO NOT EDIT.
 
+func randAllergies() Allergies {
+	var s Allergies
+	s.Asthme = randbool()
+	s.Alimentaires = randbool()
+	s.Medicamenteuses = randbool()
+	s.Autres = randstring()
+	s.Aliments = randstring()
+	s.Precisions = randstring()
+	s.ConduiteATenir = randstring()
+
+	return s
+}
+
 func randApprofondissement() Approfondissement {
 	choix := [...]Approfondissement{AAucun, AAutre, ASb, ACanoe, AVoile, AMoto}
 	i := rand.Intn(len(choix))
 	return choix[i]
 }
 
+func randCertification() Certification {
+	var s Certification
+	s.Id = randIdCertification()
+	s.IdPersonne = randIdPersonne()
+	s.Kind = randCertificationKind()
+	s.Label = randstring()
+	s.Obtention = randsha_Date()
+	s.Expiration = randsha_Date()
+
+	return s
+}
+
+func randCertificationKind() CertificationKind {
+	choix := [...]CertificationKind{CAutre, CBafa, CBafd, CPsc1, CSurveillantBaignade, CBnssa, CPermisB, CPermisD}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randDiplome() Diplome {
 	choix := [...]Diplome{DAucun, DBafa, DBafaStag, DBafd, DBafdStag, DCap, DAssSociale, DEducSpe, DMonEduc, DInstit, DProf, DAgreg, DBjeps, DDut, DEje, DDeug, DStaps, DBapaat, DBeatep, DZzautre}
 	i := rand.Intn(len(choix))
@@ -53,8 +84,11 @@ func randFichesanitaire() Fichesanitaire {
 	var s Fichesanitaire
 	s.IdPersonne = randIdPersonne()
 	s.DifficultesSante = randstring()
-	s.AllergiesAlimentaires = randstring()
-	s.TraitementMedical = randstring()
+	s.Traitements = randTraitements()
+	s.Allergies = randAllergies()
+	s.Regimes = randRegimes()
+	s.PAI = randbool()
+	s.Vaccinations = randVaccinations()
 	s.Medecin = randNomTel()
 	s.AutreContact = randNomTel()
 	s.Modified = randtTime()
@@ -63,10 +97,49 @@ func randFichesanitaire() Fichesanitaire {
 	return s
 }
 
+func randFichesanitaireVersion() FichesanitaireVersion {
+	var s FichesanitaireVersion
+	s.Id = randIdFichesanitaireVersion()
+	s.IdPersonne = randIdPersonne()
+	s.DifficultesSante = randstring()
+	s.Traitements = randTraitements()
+	s.Allergies = randAllergies()
+	s.Regimes = randRegimes()
+	s.PAI = randbool()
+	s.Vaccinations = randVaccinations()
+	s.Medecin = randNomTel()
+	s.AutreContact = randNomTel()
+	s.Modified = randtTime()
+	s.Auteur = randstring()
+
+	return s
+}
+
+func randIdCertification() IdCertification {
+	return IdCertification(randint64())
+}
+
+func randIdFichesanitaireVersion() IdFichesanitaireVersion {
+	return IdFichesanitaireVersion(randint64())
+}
+
+func randIdIdentification() IdIdentification {
+	return IdIdentification(randint64())
+}
+
 func randIdPersonne() IdPersonne {
 	return IdPersonne(randint64())
 }
 
+func randIdentification() Identification {
+	var s Identification
+	s.Id = randIdIdentification()
+	s.Moment = randtTime()
+	s.Record = randSliceuint8()
+
+	return s
+}
+
 func randMails() Mails {
 	return Mails(randSlicestring())
 }
@@ -132,12 +205,49 @@ func randRecommandation() Recommandation {
 	return s
 }
 
+func randRegimeAlimentaire() RegimeAlimentaire {
+	choix := [...]RegimeAlimentaire{Vegetarien, Vegetalien, SansPorc, SansGluten, SansLactose}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
+func randRegimes() Regimes {
+	return Regimes(randSliceRegimeAlimentaire())
+}
+
 func randSexe() Sexe {
 	choix := [...]Sexe{NoSexe, Woman, Man}
 	i := rand.Intn(len(choix))
 	return choix[i]
 }
 
+func randSliceRegimeAlimentaire() []RegimeAlimentaire {
+	l := 3 + rand.Intn(5)
+	out := make([]RegimeAlimentaire, l)
+	for i := range out {
+		out[i] = randRegimeAlimentaire()
+	}
+	return out
+}
+
+func randSliceTraitement() []Traitement {
+	l := 3 + rand.Intn(5)
+	out := make([]Traitement, l)
+	for i := range out {
+		out[i] = randTraitement()
+	}
+	return out
+}
+
+func randSliceVaccination() []Vaccination {
+	l := 3 + rand.Intn(5)
+	out := make([]Vaccination, l)
+	for i := range out {
+		out[i] = randVaccination()
+	}
+	return out
+}
+
 func randSlicestring() []string {
 	l := 3 + rand.Intn(5)
 	out := make([]string, l)
@@ -147,6 +257,15 @@ func randSlicestring() []string {
 	return out
 }
 
+func randSliceuint8() []byte {
+	l := 3 + rand.Intn(5)
+	out := make([]byte, l)
+	for i := range out {
+		out[i] = randuint8()
+	}
+	return out
+}
+
 func randTel() Tel {
 	return Tel(randstring())
 }
@@ -155,6 +274,32 @@ func randTels() Tels {
 	return Tels(randSlicestring())
 }
 
+func randTraitement() Traitement {
+	var s Traitement
+	s.Medicament = randstring()
+	s.Dose = randstring()
+	s.Horaire = randstring()
+	s.Duree = randstring()
+
+	return s
+}
+
+func randTraitements() Traitements {
+	return Traitements(randSliceTraitement())
+}
+
+func randVaccination() Vaccination {
+	var s Vaccination
+	s.Vaccin = randstring()
+	s.Date = randsha_Date()
+
+	return s
+}
+
+func randVaccinations() Vaccinations {
+	return Vaccinations(randSliceVaccination())
+}
+
 func randbool() bool {
 	i := rand.Int31n(2)
 	return i == 1
@@ -190,3 +335,7 @@ func randtDate() time.Time {
 func randtTime() time.Time {
 	return time.Unix(int64(rand.Int31()), 5)
 }
+
+func randuint8() uint8 {
+	return uint8(rand.Intn(1000000))
+}
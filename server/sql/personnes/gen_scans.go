@@ -26,6 +26,158 @@ type DB interface {
 	Prepare(query string) (*sql.Stmt, error)
 }
 
+func scanOneCertification(row scanner) (Certification, error) {
+	var item Certification
+	err := row.Scan(
+		&item.Id,
+		&item.IdPersonne,
+		&item.Kind,
+		&item.Label,
+		&item.Obtention,
+		&item.Expiration,
+	)
+	return item, err
+}
+
+func ScanCertification(row *sql.Row) (Certification, error) { return scanOneCertification(row) }
+
+// SelectAll returns all the items in the certifications table.
+func SelectAllCertifications(db DB) (Certifications, error) {
+	rows, err := db.Query("SELECT id, idpersonne, kind, label, obtention, expiration FROM certifications")
+	if err != nil {
+		return nil, err
+	}
+	return ScanCertifications(rows)
+}
+
+// SelectCertification returns the entry matching 'id'.
+func SelectCertification(tx DB, id IdCertification) (Certification, error) {
+	row := tx.QueryRow("SELECT id, idpersonne, kind, label, obtention, expiration FROM certifications WHERE id = $1", id)
+	return ScanCertification(row)
+}
+
+// SelectCertifications returns the entry matching the given 'ids'.
+func SelectCertifications(tx DB, ids ...IdCertification) (Certifications, error) {
+	rows, err := tx.Query("SELECT id, idpersonne, kind, label, obtention, expiration FROM certifications WHERE id = ANY($1)", IdCertificationArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCertifications(rows)
+}
+
+type Certifications map[IdCertification]Certification
+
+func (m Certifications) IDs() []IdCertification {
+	out := make([]IdCertification, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanCertifications(rs *sql.Rows) (Certifications, error) {
+	var (
+		s   Certification
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Certifications, 16)
+	for rs.Next() {
+		s, err = scanOneCertification(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Certification in the database and returns the item with id filled.
+func (item Certification) Insert(tx DB) (out Certification, err error) {
+	row := tx.QueryRow(`INSERT INTO certifications (
+		idpersonne, kind, label, obtention, expiration
+		) VALUES (
+		$1, $2, $3, $4, $5
+		) RETURNING id, idpersonne, kind, label, obtention, expiration;
+		`, item.IdPersonne, item.Kind, item.Label, item.Obtention, item.Expiration)
+	return ScanCertification(row)
+}
+
+// Update Certification in the database and returns the new version.
+func (item Certification) Update(tx DB) (out Certification, err error) {
+	row := tx.QueryRow(`UPDATE certifications SET (
+		idpersonne, kind, label, obtention, expiration
+		) = (
+		$1, $2, $3, $4, $5
+		) WHERE id = $6 RETURNING id, idpersonne, kind, label, obtention, expiration;
+		`, item.IdPersonne, item.Kind, item.Label, item.Obtention, item.Expiration, item.Id)
+	return ScanCertification(row)
+}
+
+// Deletes the Certification and returns the item
+func DeleteCertificationById(tx DB, id IdCertification) (Certification, error) {
+	row := tx.QueryRow("DELETE FROM certifications WHERE id = $1 RETURNING id, idpersonne, kind, label, obtention, expiration;", id)
+	return ScanCertification(row)
+}
+
+// Deletes the Certification in the database and returns the ids.
+func DeleteCertificationsByIDs(tx DB, ids ...IdCertification) ([]IdCertification, error) {
+	rows, err := tx.Query("DELETE FROM certifications WHERE id = ANY($1) RETURNING id", IdCertificationArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdCertificationArray(rows)
+}
+
+// ByIdPersonne returns a map with 'IdPersonne' as keys.
+func (items Certifications) ByIdPersonne() map[IdPersonne]Certifications {
+	out := make(map[IdPersonne]Certifications)
+	for _, target := range items {
+		dict := out[target.IdPersonne]
+		if dict == nil {
+			dict = make(Certifications)
+		}
+		dict[target.Id] = target
+		out[target.IdPersonne] = dict
+	}
+	return out
+}
+
+// IdPersonnes returns the list of ids of IdPersonne
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items Certifications) IdPersonnes() []IdPersonne {
+	out := make([]IdPersonne, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdPersonne)
+	}
+	return out
+}
+
+func SelectCertificationsByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (Certifications, error) {
+	rows, err := tx.Query("SELECT id, idpersonne, kind, label, obtention, expiration FROM certifications WHERE idpersonne = ANY($1)", IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCertifications(rows)
+}
+
+func DeleteCertificationsByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (Certifications, error) {
+	rows, err := tx.Query("DELETE FROM certifications WHERE idpersonne = ANY($1) RETURNING id, idpersonne, kind, label, obtention, expiration", IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanCertifications(rows)
+}
+
 func scanOneFicheequipier(row scanner) (Ficheequipier, error) {
 	var item Ficheequipier
 	err := row.Scan(
@@ -206,8 +358,11 @@ func scanOneFichesanitaire(row scanner) (Fichesanitaire, error) {
 	err := row.Scan(
 		&item.IdPersonne,
 		&item.DifficultesSante,
-		&item.AllergiesAlimentaires,
-		&item.TraitementMedical,
+		&item.Traitements,
+		&item.Allergies,
+		&item.Regimes,
+		&item.PAI,
+		&item.Vaccinations,
 		&item.Medecin,
 		&item.AutreContact,
 		&item.Modified,
@@ -220,7 +375,7 @@ func ScanFichesanitaire(row *sql.Row) (Fichesanitaire, error) { return scanOneFi
 
 // SelectAll returns all the items in the fichesanitaires table.
 func SelectAllFichesanitaires(db DB) (Fichesanitaires, error) {
-	rows, err := db.Query("SELECT idpersonne, difficultessante, allergiesalimentaires, traitementmedical, medecin, autrecontact, modified, owners FROM fichesanitaires")
+	rows, err := db.Query("SELECT idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, owners FROM fichesanitaires")
 	if err != nil {
 		return nil, err
 	}
@@ -256,11 +411,11 @@ func ScanFichesanitaires(rs *sql.Rows) (Fichesanitaires, error) {
 
 func (item Fichesanitaire) Insert(db DB) error {
 	_, err := db.Exec(`INSERT INTO fichesanitaires (
-			idpersonne, difficultessante, allergiesalimentaires, traitementmedical, medecin, autrecontact, modified, owners
+			idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, owners
 			) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 			);
-			`, item.IdPersonne, item.DifficultesSante, item.AllergiesAlimentaires, item.TraitementMedical, item.Medecin, item.AutreContact, item.Modified, item.Owners)
+			`, item.IdPersonne, item.DifficultesSante, item.Traitements, item.Allergies, item.Regimes, item.PAI, item.Vaccinations, item.Medecin, item.AutreContact, item.Modified, item.Owners)
 	if err != nil {
 		return err
 	}
@@ -277,8 +432,11 @@ func InsertManyFichesanitaires(tx *sql.Tx, items ...Fichesanitaire) error {
 	stmt, err := tx.Prepare(pq.CopyIn("fichesanitaires",
 		"idpersonne",
 		"difficultessante",
-		"allergiesalimentaires",
-		"traitementmedical",
+		"traitements",
+		"allergies",
+		"regimes",
+		"pai",
+		"vaccinations",
 		"medecin",
 		"autrecontact",
 		"modified",
@@ -289,7 +447,7 @@ func InsertManyFichesanitaires(tx *sql.Tx, items ...Fichesanitaire) error {
 	}
 
 	for _, item := range items {
-		_, err = stmt.Exec(item.IdPersonne, item.DifficultesSante, item.AllergiesAlimentaires, item.TraitementMedical, item.Medecin, item.AutreContact, item.Modified, item.Owners)
+		_, err = stmt.Exec(item.IdPersonne, item.DifficultesSante, item.Traitements, item.Allergies, item.Regimes, item.PAI, item.Vaccinations, item.Medecin, item.AutreContact, item.Modified, item.Owners)
 		if err != nil {
 			return err
 		}
@@ -334,7 +492,7 @@ func (items Fichesanitaires) IdPersonnes() []IdPersonne {
 
 // SelectFichesanitaireByIdPersonne return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectFichesanitaireByIdPersonne(tx DB, idPersonne IdPersonne) (item Fichesanitaire, found bool, err error) {
-	row := tx.QueryRow("SELECT idpersonne, difficultessante, allergiesalimentaires, traitementmedical, medecin, autrecontact, modified, owners FROM fichesanitaires WHERE idpersonne = $1", idPersonne)
+	row := tx.QueryRow("SELECT idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, owners FROM fichesanitaires WHERE idpersonne = $1", idPersonne)
 	item, err = ScanFichesanitaire(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -343,7 +501,7 @@ func SelectFichesanitaireByIdPersonne(tx DB, idPersonne IdPersonne) (item Fiches
 }
 
 func SelectFichesanitairesByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (Fichesanitaires, error) {
-	rows, err := tx.Query("SELECT idpersonne, difficultessante, allergiesalimentaires, traitementmedical, medecin, autrecontact, modified, owners FROM fichesanitaires WHERE idpersonne = ANY($1)", IdPersonneArrayToPQ(idPersonnes_))
+	rows, err := tx.Query("SELECT idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, owners FROM fichesanitaires WHERE idpersonne = ANY($1)", IdPersonneArrayToPQ(idPersonnes_))
 	if err != nil {
 		return nil, err
 	}
@@ -351,13 +509,281 @@ func SelectFichesanitairesByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (Fich
 }
 
 func DeleteFichesanitairesByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (Fichesanitaires, error) {
-	rows, err := tx.Query("DELETE FROM fichesanitaires WHERE idpersonne = ANY($1) RETURNING idpersonne, difficultessante, allergiesalimentaires, traitementmedical, medecin, autrecontact, modified, owners", IdPersonneArrayToPQ(idPersonnes_))
+	rows, err := tx.Query("DELETE FROM fichesanitaires WHERE idpersonne = ANY($1) RETURNING idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, owners", IdPersonneArrayToPQ(idPersonnes_))
 	if err != nil {
 		return nil, err
 	}
 	return ScanFichesanitaires(rows)
 }
 
+func scanOneFichesanitaireVersion(row scanner) (FichesanitaireVersion, error) {
+	var item FichesanitaireVersion
+	err := row.Scan(
+		&item.Id,
+		&item.IdPersonne,
+		&item.DifficultesSante,
+		&item.Traitements,
+		&item.Allergies,
+		&item.Regimes,
+		&item.PAI,
+		&item.Vaccinations,
+		&item.Medecin,
+		&item.AutreContact,
+		&item.Modified,
+		&item.Auteur,
+	)
+	return item, err
+}
+
+func ScanFichesanitaireVersion(row *sql.Row) (FichesanitaireVersion, error) {
+	return scanOneFichesanitaireVersion(row)
+}
+
+// SelectAll returns all the items in the fichesanitaireversions table.
+func SelectAllFichesanitaireVersions(db DB) (FichesanitaireVersions, error) {
+	rows, err := db.Query("SELECT id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur FROM fichesanitaireversions")
+	if err != nil {
+		return nil, err
+	}
+	return ScanFichesanitaireVersions(rows)
+}
+
+// SelectFichesanitaireVersion returns the entry matching 'id'.
+func SelectFichesanitaireVersion(tx DB, id IdFichesanitaireVersion) (FichesanitaireVersion, error) {
+	row := tx.QueryRow("SELECT id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur FROM fichesanitaireversions WHERE id = $1", id)
+	return ScanFichesanitaireVersion(row)
+}
+
+// SelectFichesanitaireVersions returns the entry matching the given 'ids'.
+func SelectFichesanitaireVersions(tx DB, ids ...IdFichesanitaireVersion) (FichesanitaireVersions, error) {
+	rows, err := tx.Query("SELECT id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur FROM fichesanitaireversions WHERE id = ANY($1)", IdFichesanitaireVersionArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFichesanitaireVersions(rows)
+}
+
+type FichesanitaireVersions map[IdFichesanitaireVersion]FichesanitaireVersion
+
+func (m FichesanitaireVersions) IDs() []IdFichesanitaireVersion {
+	out := make([]IdFichesanitaireVersion, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanFichesanitaireVersions(rs *sql.Rows) (FichesanitaireVersions, error) {
+	var (
+		s   FichesanitaireVersion
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(FichesanitaireVersions, 16)
+	for rs.Next() {
+		s, err = scanOneFichesanitaireVersion(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one FichesanitaireVersion in the database and returns the item with id filled.
+func (item FichesanitaireVersion) Insert(tx DB) (out FichesanitaireVersion, err error) {
+	row := tx.QueryRow(`INSERT INTO fichesanitaireversions (
+		idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) RETURNING id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur;
+		`, item.IdPersonne, item.DifficultesSante, item.Traitements, item.Allergies, item.Regimes, item.PAI, item.Vaccinations, item.Medecin, item.AutreContact, item.Modified, item.Auteur)
+	return ScanFichesanitaireVersion(row)
+}
+
+// Update FichesanitaireVersion in the database and returns the new version.
+func (item FichesanitaireVersion) Update(tx DB) (out FichesanitaireVersion, err error) {
+	row := tx.QueryRow(`UPDATE fichesanitaireversions SET (
+		idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur
+		) = (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) WHERE id = $12 RETURNING id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur;
+		`, item.IdPersonne, item.DifficultesSante, item.Traitements, item.Allergies, item.Regimes, item.PAI, item.Vaccinations, item.Medecin, item.AutreContact, item.Modified, item.Auteur, item.Id)
+	return ScanFichesanitaireVersion(row)
+}
+
+// Deletes the FichesanitaireVersion and returns the item
+func DeleteFichesanitaireVersionById(tx DB, id IdFichesanitaireVersion) (FichesanitaireVersion, error) {
+	row := tx.QueryRow("DELETE FROM fichesanitaireversions WHERE id = $1 RETURNING id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur;", id)
+	return ScanFichesanitaireVersion(row)
+}
+
+// Deletes the FichesanitaireVersion in the database and returns the ids.
+func DeleteFichesanitaireVersionsByIDs(tx DB, ids ...IdFichesanitaireVersion) ([]IdFichesanitaireVersion, error) {
+	rows, err := tx.Query("DELETE FROM fichesanitaireversions WHERE id = ANY($1) RETURNING id", IdFichesanitaireVersionArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdFichesanitaireVersionArray(rows)
+}
+
+// ByIdPersonne returns a map with 'IdPersonne' as keys.
+func (items FichesanitaireVersions) ByIdPersonne() map[IdPersonne]FichesanitaireVersions {
+	out := make(map[IdPersonne]FichesanitaireVersions)
+	for _, target := range items {
+		dict := out[target.IdPersonne]
+		if dict == nil {
+			dict = make(FichesanitaireVersions)
+		}
+		dict[target.Id] = target
+		out[target.IdPersonne] = dict
+	}
+	return out
+}
+
+// IdPersonnes returns the list of ids of IdPersonne
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items FichesanitaireVersions) IdPersonnes() []IdPersonne {
+	out := make([]IdPersonne, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdPersonne)
+	}
+	return out
+}
+
+func SelectFichesanitaireVersionsByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (FichesanitaireVersions, error) {
+	rows, err := tx.Query("SELECT id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur FROM fichesanitaireversions WHERE idpersonne = ANY($1)", IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFichesanitaireVersions(rows)
+}
+
+func DeleteFichesanitaireVersionsByIdPersonnes(tx DB, idPersonnes_ ...IdPersonne) (FichesanitaireVersions, error) {
+	rows, err := tx.Query("DELETE FROM fichesanitaireversions WHERE idpersonne = ANY($1) RETURNING id, idpersonne, difficultessante, traitements, allergies, regimes, pai, vaccinations, medecin, autrecontact, modified, auteur", IdPersonneArrayToPQ(idPersonnes_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanFichesanitaireVersions(rows)
+}
+
+func scanOneIdentification(row scanner) (Identification, error) {
+	var item Identification
+	err := row.Scan(
+		&item.Id,
+		&item.Moment,
+		&item.Record,
+	)
+	return item, err
+}
+
+func ScanIdentification(row *sql.Row) (Identification, error) { return scanOneIdentification(row) }
+
+// SelectAll returns all the items in the identifications table.
+func SelectAllIdentifications(db DB) (Identifications, error) {
+	rows, err := db.Query("SELECT id, moment, record FROM identifications")
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdentifications(rows)
+}
+
+// SelectIdentification returns the entry matching 'id'.
+func SelectIdentification(tx DB, id IdIdentification) (Identification, error) {
+	row := tx.QueryRow("SELECT id, moment, record FROM identifications WHERE id = $1", id)
+	return ScanIdentification(row)
+}
+
+// SelectIdentifications returns the entry matching the given 'ids'.
+func SelectIdentifications(tx DB, ids ...IdIdentification) (Identifications, error) {
+	rows, err := tx.Query("SELECT id, moment, record FROM identifications WHERE id = ANY($1)", IdIdentificationArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdentifications(rows)
+}
+
+type Identifications map[IdIdentification]Identification
+
+func (m Identifications) IDs() []IdIdentification {
+	out := make([]IdIdentification, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanIdentifications(rs *sql.Rows) (Identifications, error) {
+	var (
+		s   Identification
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(Identifications, 16)
+	for rs.Next() {
+		s, err = scanOneIdentification(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one Identification in the database and returns the item with id filled.
+func (item Identification) Insert(tx DB) (out Identification, err error) {
+	row := tx.QueryRow(`INSERT INTO identifications (
+		moment, record
+		) VALUES (
+		$1, $2
+		) RETURNING id, moment, record;
+		`, item.Moment, item.Record)
+	return ScanIdentification(row)
+}
+
+// Update Identification in the database and returns the new version.
+func (item Identification) Update(tx DB) (out Identification, err error) {
+	row := tx.QueryRow(`UPDATE identifications SET (
+		moment, record
+		) = (
+		$1, $2
+		) WHERE id = $3 RETURNING id, moment, record;
+		`, item.Moment, item.Record, item.Id)
+	return ScanIdentification(row)
+}
+
+// Deletes the Identification and returns the item
+func DeleteIdentificationById(tx DB, id IdIdentification) (Identification, error) {
+	row := tx.QueryRow("DELETE FROM identifications WHERE id = $1 RETURNING id, moment, record;", id)
+	return ScanIdentification(row)
+}
+
+// Deletes the Identification in the database and returns the ids.
+func DeleteIdentificationsByIDs(tx DB, ids ...IdIdentification) ([]IdIdentification, error) {
+	rows, err := tx.Query("DELETE FROM identifications WHERE id = ANY($1) RETURNING id", IdIdentificationArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdIdentificationArray(rows)
+}
+
 func scanOnePersonne(row scanner) (Personne, error) {
 	var item Personne
 	err := row.Scan(
@@ -521,6 +947,26 @@ func (s Tels) Value() (driver.Value, error) {
 	return pq.StringArray(s).Value()
 }
 
+func (s *Regimes) Scan(src any) error {
+	var tmp pq.Int32Array
+	err := tmp.Scan(src)
+	if err != nil {
+		return err
+	}
+	*s = make([]RegimeAlimentaire, len(tmp))
+	for i, v := range tmp {
+		(*s)[i] = RegimeAlimentaire(v)
+	}
+	return nil
+}
+func (s Regimes) Value() (driver.Value, error) {
+	tmp := make(pq.Int32Array, len(s))
+	for i, v := range s {
+		tmp[i] = int32(v)
+	}
+	return tmp.Value()
+}
+
 func (s *Nationnalite) Scan(src any) error {
 	bs, ok := src.([]byte)
 	if !ok {
@@ -567,6 +1013,87 @@ func (s Publicite) Value() (driver.Value, error) {
 	return driver.Value(bs), nil
 }
 
+func IdCertificationArrayToPQ(ids []IdCertification) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdCertificationArray scans the result of a query returning a
+// list of ID's.
+func ScanIdCertificationArray(rs *sql.Rows) ([]IdCertification, error) {
+	defer rs.Close()
+	ints := make([]IdCertification, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdCertification
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
+func IdFichesanitaireVersionArrayToPQ(ids []IdFichesanitaireVersion) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdFichesanitaireVersionArray scans the result of a query returning a
+// list of ID's.
+func ScanIdFichesanitaireVersionArray(rs *sql.Rows) ([]IdFichesanitaireVersion, error) {
+	defer rs.Close()
+	ints := make([]IdFichesanitaireVersion, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdFichesanitaireVersion
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
+func IdIdentificationArrayToPQ(ids []IdIdentification) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdIdentificationArray scans the result of a query returning a
+// list of ID's.
+func ScanIdIdentificationArray(rs *sql.Rows) ([]IdIdentification, error) {
+	defer rs.Close()
+	ints := make([]IdIdentification, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdIdentification
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func IdPersonneArrayToPQ(ids []IdPersonne) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -594,8 +1121,27 @@ func ScanIdPersonneArray(rs *sql.Rows) ([]IdPersonne, error) {
 	return ints, nil
 }
 
+func (s *Allergies) Scan(src any) error          { return loadJSON(s, src) }
+func (s Allergies) Value() (driver.Value, error) { return dumpJSON(s) }
+
 func (s *NomTel) Scan(src any) error          { return loadJSON(s, src) }
 func (s NomTel) Value() (driver.Value, error) { return dumpJSON(s) }
 
 func (s *Recommandation) Scan(src any) error          { return loadJSON(s, src) }
 func (s Recommandation) Value() (driver.Value, error) { return dumpJSON(s) }
+
+func (s *Traitements) Scan(src any) error          { return loadJSON(s, src) }
+func (s Traitements) Value() (driver.Value, error) { return dumpJSON(s) }
+
+func (s *Vaccinations) Scan(src any) error          { return loadJSON(s, src) }
+func (s Vaccinations) Value() (driver.Value, error) { return dumpJSON(s) }
+
+func SwitchFichesanitaireVersionPersonne(db DB, target IdPersonne, temporaire IdPersonne) error {
+	_, err := db.Exec("UPDATE fichesanitaireversions SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
+	return err
+}
+
+func SwitchCertificationPersonne(db DB, target IdPersonne, temporaire IdPersonne) error {
+	_, err := db.Exec("UPDATE certifications SET IdPersonne = $1 WHERE IdPersonne = $2;", target, temporaire)
+	return err
+}
@@ -23,3 +23,10 @@ func (fs Fichesanitaire) State(inscription time.Time) FichesanitaireState {
 	}
 	return UpToDate
 }
+
+// IsValideAu renvoie `true` si la certification n'a pas
+// expiré à la date donnée.
+func (c Certification) IsValideAu(date time.Time) bool {
+	expiration := c.Expiration.Time()
+	return expiration.IsZero() || !expiration.Before(date)
+}
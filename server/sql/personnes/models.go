@@ -1,10 +1,19 @@
 package personnes
 
-import "time"
+import (
+	"time"
+
+	"registro/sql/shared"
+)
 
 //go:generate ../../../../../go/src/github.com/benoitkugler/gomacro/cmd/gomacro models.go go/sqlcrud:gen_scans.go sql:gen_create.sql go/randdata:gen_randdata_test.go
 
-type IdPersonne int64
+type (
+	IdPersonne              int64
+	IdFichesanitaireVersion int64
+	IdCertification         int64
+	IdIdentification        int64
+)
 
 // Personne représente les attributs d'une personne
 //
@@ -56,9 +65,14 @@ func SelectByMail(db DB, mail string) (Personnes, error) {
 type Fichesanitaire struct {
 	IdPersonne IdPersonne `gomacro-sql-on-delete:"CASCADE"`
 
-	DifficultesSante      string
-	AllergiesAlimentaires string
-	TraitementMedical     string
+	DifficultesSante string
+	Traitements      Traitements
+	Allergies        Allergies
+	Regimes          Regimes
+	// PAI indique un Projet d'Accueil Individualisé : le document
+	// est demandé avec les vaccins (voir files.PAI)
+	PAI          bool
+	Vaccinations Vaccinations
 
 	Medecin      NomTel
 	AutreContact NomTel // added to the responsable
@@ -71,6 +85,28 @@ type Fichesanitaire struct {
 	guard bool `gomacro-sql-guard:"false"`
 }
 
+// FichesanitaireVersion est une version d'une [Fichesanitaire],
+// enregistrée à chaque modification par un responsable.
+// L'historique permet de signaler les changements aux directeurs.
+//
+// gomacro:QUERY SwitchFichesanitaireVersionPersonne UPDATE FichesanitaireVersion SET IdPersonne = $target$ WHERE IdPersonne = $temporaire$;
+type FichesanitaireVersion struct {
+	Id         IdFichesanitaireVersion
+	IdPersonne IdPersonne `gomacro-sql-on-delete:"CASCADE"`
+
+	DifficultesSante string
+	Traitements      Traitements
+	Allergies        Allergies
+	Regimes          Regimes
+	PAI              bool
+	Vaccinations     Vaccinations
+	Medecin          NomTel
+	AutreContact     NomTel
+
+	Modified time.Time
+	Auteur   string // mail du responsable
+}
+
 // Ficheequipier is an extension table loaded
 // only when dealing with equipiers.
 //
@@ -111,3 +147,27 @@ type Ficheequipier struct {
 
 	guard bool `gomacro-sql-guard:"false"`
 }
+
+// Certification est une formation ou un permis d'un équipier
+// (BAFA, PSC1, permis de conduire, ...), avec ses dates de validité.
+//
+// Le justificatif est enregistré dans la table [files.FileCertification].
+//
+// gomacro:QUERY SwitchCertificationPersonne UPDATE Certification SET IdPersonne = $target$ WHERE IdPersonne = $temporaire$;
+type Certification struct {
+	Id         IdCertification
+	IdPersonne IdPersonne `gomacro-sql-on-delete:"CASCADE"`
+
+	Kind       CertificationKind
+	Label      string // précision libre (organisme, catégorie, ...)
+	Obtention  shared.Date
+	Expiration shared.Date // zéro si la certification n'expire pas
+}
+
+// Identification est une identification récente d'un profil temporaire,
+// conservée (en base, pour survivre à un redémarrage) afin de pouvoir être annulée.
+type Identification struct {
+	Id     IdIdentification
+	Moment time.Time
+	Record []byte // logic.IdentRecord, encodé en JSON
+}
@@ -66,11 +66,18 @@ func TestSQL(t *testing.T) {
 	// check deletion properly cascade
 	err = Ficheequipier{IdPersonne: p.Id}.Insert(db)
 	tu.AssertNoErr(t, err)
+	certification := randCertification()
+	certification.IdPersonne = p.Id
+	_, err = certification.Insert(db)
+	tu.AssertNoErr(t, err)
 	_, err = DeletePersonneById(db, p.Id)
 	tu.AssertNoErr(t, err)
 	fiches, err := SelectAllFicheequipiers(db)
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, len(fiches) == 0)
+	certifications, err := SelectAllCertifications(db)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(certifications) == 0)
 }
 
 func TestDumpRandomDB(t *testing.T) {
@@ -97,3 +104,45 @@ func TestDumpRandomDB(t *testing.T) {
 	err = os.WriteFile("../../controllers/search/test/samples.json", b, os.ModePerm)
 	tu.AssertNoErr(t, err)
 }
+
+func TestRegimes(t *testing.T) {
+	tu.Assert(t, Regimes(nil).String() == "")
+	tu.Assert(t, Regimes{Vegetarien, SansGluten}.String() == "Végétarien, Sans gluten")
+}
+
+func TestFichesanitaireDiff(t *testing.T) {
+	fs := Fichesanitaire{
+		Allergies: Allergies{Asthme: true},
+		Medecin:   NomTel{Nom: "Dr Martin", Tel: "0601020304"},
+	}
+	v1 := fs.Version("parent@free.fr")
+	tu.Assert(t, v1.Auteur == "parent@free.fr")
+	tu.Assert(t, len(v1.Diff(v1)) == 0)
+
+	fs.Allergies.Alimentaires = true
+	fs.Allergies.Aliments = "Arachide"
+	fs.PAI = true
+	v2 := fs.Version("parent@free.fr")
+	diff := v2.Diff(v1)
+	tu.Assert(t, len(diff) == 2)
+	tu.Assert(t, diff[0].Rubrique == "Allergies")
+	tu.Assert(t, diff[0].Avant == "Asthme")
+	tu.Assert(t, diff[0].Apres == "Asthme, Alimentaires ; Aliments : Arachide")
+	tu.Assert(t, diff[1].Avant == "Non" && diff[1].Apres == "Oui")
+
+	// version initiale
+	tu.Assert(t, len(v1.Diff(FichesanitaireVersion{})) == 2)
+}
+
+func TestFichesanitaireHistorique(t *testing.T) {
+	now := time.Now()
+	vs := FichesanitaireVersions{
+		1: {Id: 1, Modified: now.Add(-time.Hour), PAI: true},
+		2: {Id: 2, Modified: now, PAI: true, DifficultesSante: "Asthme"},
+	}
+	h := vs.Historique()
+	tu.Assert(t, len(h) == 2)
+	tu.Assert(t, h[0].Version.Id == 2 && len(h[0].Changements) == 1)
+	tu.Assert(t, h[0].Changements[0].Rubrique == "Difficultés de santé")
+	tu.Assert(t, h[1].Version.Id == 1 && len(h[1].Changements) == 1)
+}
@@ -115,6 +115,25 @@ const (
 	DZzautre                   // AUTRE
 )
 
+// IsQualifiant renvoie true pour le BAFA et les diplômes
+// permettant d'exercer les fonctions d'animateur.
+// Les stagiaires ne sont pas comptés.
+func (d Diplome) IsQualifiant() bool {
+	switch d {
+	case DBafa, DBafd, DCap, DAssSociale, DEducSpe, DMonEduc, DInstit, DProf, DAgreg,
+		DBjeps, DDut, DEje, DStaps, DBapaat, DBeatep:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStagiaire renvoie true pour les stagiaires BAFA et BAFD.
+func (d Diplome) IsStagiaire() bool { return d == DBafaStag || d == DBafdStag }
+
+// IsBAFD renvoie true pour les titulaires et stagiaires BAFD.
+func (d Diplome) IsBAFD() bool { return d == DBafd || d == DBafdStag }
+
 type Approfondissement uint8
 
 const (
@@ -126,6 +145,20 @@ const (
 	AMoto                           // Loisirs motocyclistes
 )
 
+// CertificationKind est le type d'une [Certification].
+type CertificationKind uint8
+
+const (
+	CAutre               CertificationKind = iota // Autre
+	CBafa                                         // BAFA
+	CBafd                                         // BAFD
+	CPsc1                                         // PSC1
+	CSurveillantBaignade                          // Surveillant de baignade
+	CBnssa                                        // BNSSA
+	CPermisB                                      // Permis B
+	CPermisD                                      // Permis D
+)
+
 type Mails []string
 
 // Publicite indique les préférences de communication
@@ -199,11 +232,15 @@ func (m Maladies) List() []string {
 	return out
 }
 
+// Allergies décrit les allergies d'un participant,
+// et le protocole à suivre en cas de réaction.
 type Allergies struct {
 	Asthme          bool
 	Alimentaires    bool
 	Medicamenteuses bool
 	Autres          string
+	Aliments        string // allergènes alimentaires, seule précision communiquée à l'équipe cuisine
+	Precisions      string // autres précisions (médicaments, ...)
 	ConduiteATenir  string
 }
 
@@ -224,6 +261,63 @@ func (a Allergies) List() []string {
 	return out
 }
 
+// Traitement est un médicament à prendre pendant le séjour.
+type Traitement struct {
+	Medicament string
+	Dose       string // par exemple "1 comprimé"
+	Horaire    string // par exemple "matin et soir"
+	Duree      string // par exemple "jusqu'au 14 juillet"
+}
+
+type Traitements []Traitement
+
+// RegimeAlimentaire est un régime suivi par un participant.
+type RegimeAlimentaire uint8
+
+const (
+	_           RegimeAlimentaire = iota
+	Vegetarien                    // Végétarien
+	Vegetalien                    // Végétalien
+	SansPorc                      // Sans porc
+	SansGluten                    // Sans gluten
+	SansLactose                   // Sans lactose
+)
+
+func (r RegimeAlimentaire) String() string {
+	switch r {
+	case Vegetarien:
+		return "Végétarien"
+	case Vegetalien:
+		return "Végétalien"
+	case SansPorc:
+		return "Sans porc"
+	case SansGluten:
+		return "Sans gluten"
+	case SansLactose:
+		return "Sans lactose"
+	default:
+		return ""
+	}
+}
+
+type Regimes []RegimeAlimentaire
+
+func (rs Regimes) String() string {
+	chunks := make([]string, len(rs))
+	for i, r := range rs {
+		chunks[i] = r.String()
+	}
+	return strings.Join(chunks, ", ")
+}
+
+// Vaccination est la date du dernier rappel d'un vaccin.
+type Vaccination struct {
+	Vaccin string // par exemple "DT Polio"
+	Date   shared.Date
+}
+
+type Vaccinations []Vaccination
+
 type NomTel struct {
 	Nom string
 	Tel Tel
@@ -0,0 +1,141 @@
+package personnes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Version renvoie le contenu de la fiche, à enregistrer dans l'historique.
+func (fs Fichesanitaire) Version(auteur string) FichesanitaireVersion {
+	return FichesanitaireVersion{
+		IdPersonne:       fs.IdPersonne,
+		DifficultesSante: fs.DifficultesSante,
+		Traitements:      fs.Traitements,
+		Allergies:        fs.Allergies,
+		Regimes:          fs.Regimes,
+		PAI:              fs.PAI,
+		Vaccinations:     fs.Vaccinations,
+		Medecin:          fs.Medecin,
+		AutreContact:     fs.AutreContact,
+		Modified:         fs.Modified,
+		Auteur:           auteur,
+	}
+}
+
+// ChangementFiche décrit la modification d'une rubrique
+// de la fiche sanitaire.
+type ChangementFiche struct {
+	Rubrique string
+	Avant    string
+	Apres    string
+}
+
+func (ts Traitements) String() string {
+	chunks := make([]string, len(ts))
+	for i, t := range ts {
+		var details []string
+		for _, s := range [...]string{t.Dose, t.Horaire, t.Duree} {
+			if s = strings.TrimSpace(s); s != "" {
+				details = append(details, s)
+			}
+		}
+		chunks[i] = t.Medicament
+		if len(details) != 0 {
+			chunks[i] += " (" + strings.Join(details, ", ") + ")"
+		}
+	}
+	return strings.Join(chunks, " ; ")
+}
+
+func (a Allergies) String() string {
+	var chunks []string
+	if list := a.List(); len(list) != 0 {
+		chunks = append(chunks, strings.Join(list, ", "))
+	}
+	if a.Aliments != "" {
+		chunks = append(chunks, "Aliments : "+a.Aliments)
+	}
+	if a.Precisions != "" {
+		chunks = append(chunks, "Précisions : "+a.Precisions)
+	}
+	if a.ConduiteATenir != "" {
+		chunks = append(chunks, "Conduite à tenir : "+a.ConduiteATenir)
+	}
+	return strings.Join(chunks, " ; ")
+}
+
+func (vs Vaccinations) String() string {
+	chunks := make([]string, len(vs))
+	for i, v := range vs {
+		chunks[i] = fmt.Sprintf("%s (%s)", v.Vaccin, v.Date)
+	}
+	return strings.Join(chunks, " ; ")
+}
+
+func (nt NomTel) String() string {
+	if nt.Tel == "" {
+		return nt.Nom
+	}
+	return fmt.Sprintf("%s (%s)", nt.Nom, nt.Tel)
+}
+
+func formatPAI(pai bool) string {
+	if pai {
+		return "Oui"
+	}
+	return "Non"
+}
+
+// Diff renvoie les rubriques modifiées depuis la version [previous].
+func (v FichesanitaireVersion) Diff(previous FichesanitaireVersion) []ChangementFiche {
+	rubriques := [...]struct {
+		label        string
+		avant, apres string
+	}{
+		{"Difficultés de santé", previous.DifficultesSante, v.DifficultesSante},
+		{"Traitements", previous.Traitements.String(), v.Traitements.String()},
+		{"Allergies", previous.Allergies.String(), v.Allergies.String()},
+		{"Régimes alimentaires", previous.Regimes.String(), v.Regimes.String()},
+		{"PAI", formatPAI(previous.PAI), formatPAI(v.PAI)},
+		{"Vaccinations", previous.Vaccinations.String(), v.Vaccinations.String()},
+		{"Médecin", previous.Medecin.String(), v.Medecin.String()},
+		{"Autre contact", previous.AutreContact.String(), v.AutreContact.String()},
+	}
+	var out []ChangementFiche
+	for _, rubrique := range rubriques {
+		if rubrique.avant != rubrique.apres {
+			out = append(out, ChangementFiche{rubrique.label, rubrique.avant, rubrique.apres})
+		}
+	}
+	return out
+}
+
+// VersionChangements associe une version de la fiche
+// aux modifications apportées par rapport à la version précédente.
+type VersionChangements struct {
+	Version     FichesanitaireVersion
+	Changements []ChangementFiche
+}
+
+// Historique renvoie les versions par ordre antichronologique,
+// avec les modifications apportées par chacune.
+func (vs FichesanitaireVersions) Historique() []VersionChangements {
+	versions := make([]FichesanitaireVersion, 0, len(vs))
+	for _, v := range vs {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, func(a, b FichesanitaireVersion) int {
+		if c := a.Modified.Compare(b.Modified); c != 0 {
+			return c
+		}
+		return int(a.Id - b.Id)
+	})
+	out := make([]VersionChangements, len(versions))
+	var previous FichesanitaireVersion
+	for i, v := range versions {
+		out[len(versions)-1-i] = VersionChangements{v, v.Diff(previous)}
+		previous = v
+	}
+	return out
+}
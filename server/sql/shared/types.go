@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/lib/pq"
@@ -179,6 +180,22 @@ func (pl Plage) Contains(d Date) bool {
 	return before(pl.From.Time(), dt) && before(dt, pl.toT())
 }
 
+// Intersection renvoie le nombre de jours communs
+// aux deux plages (0 si elles sont disjointes).
+func (pl Plage) Intersection(other Plage) int {
+	from, to := pl.From.Time(), pl.toT()
+	if otherFrom := other.From.Time(); otherFrom.After(from) {
+		from = otherFrom
+	}
+	if otherTo := other.toT(); otherTo.Before(to) {
+		to = otherTo
+	}
+	if to.Before(from) {
+		return 0
+	}
+	return int(math.Round(to.Sub(from).Hours()/24)) + 1
+}
+
 func (s *Plage) Scan(src interface{}) error {
 	if src == nil {
 		return nil // zero value out
@@ -185,3 +185,20 @@ func TestPlage_HasBirthday(t *testing.T) {
 		tu.Assert(t, pl.HasBirthday(tt.d) == tt.want)
 	}
 }
+
+func TestPlage_Intersection(t *testing.T) {
+	tests := []struct {
+		pl1, pl2 Plage
+		want     int
+	}{
+		{Plage{NewDate(2000, 7, 1), 10}, Plage{NewDate(2000, 7, 5), 10}, 6},
+		{Plage{NewDate(2000, 7, 5), 10}, Plage{NewDate(2000, 7, 1), 10}, 6},
+		{Plage{NewDate(2000, 7, 1), 10}, Plage{NewDate(2000, 7, 3), 2}, 2},
+		{Plage{NewDate(2000, 7, 1), 10}, Plage{NewDate(2000, 7, 10), 5}, 1},
+		{Plage{NewDate(2000, 7, 1), 10}, Plage{NewDate(2000, 7, 11), 5}, 0},
+		{Plage{NewDate(2000, 7, 11), 5}, Plage{NewDate(2000, 7, 1), 10}, 0},
+	}
+	for _, tt := range tests {
+		tu.Assert(t, tt.pl1.Intersection(tt.pl2) == tt.want)
+	}
+}
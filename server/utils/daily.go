@@ -0,0 +1,20 @@
+package utils
+
+import "time"
+
+// RunDaily appelle [job] chaque jour, à l'heure [hour] (heure locale).
+// RunDaily ne termine jamais : elle doit être lancée dans une goroutine.
+func RunDaily(hour int, job func()) {
+	for {
+		time.Sleep(time.Until(nextDailyRun(time.Now(), hour)))
+		job()
+	}
+}
+
+func nextDailyRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
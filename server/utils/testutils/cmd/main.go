@@ -89,9 +89,9 @@ func createFilesContent(db *sql.DB, fileSys fs.FileSystem) {
 	files, err := fs.SelectAllFiles(db)
 	check(err)
 	for _, file := range files {
-		err = fileSys.Save(file.Id, testutils.PngData, false)
+		err = fileSys.Save(file, testutils.PngData, false)
 		check(err)
-		err = fileSys.Save(file.Id, testutils.PngData, true)
+		err = fileSys.Save(file, testutils.PngData, true)
 		check(err)
 	}
 	fmt.Println("Written files and miniatures:", len(files))
@@ -67,6 +67,25 @@ func QueryParamInt[T interface{ ~int64 | int }](c echo.Context, name string) (T,
 	return ParseInt[T](c.QueryParam(name))
 }
 
+// QueryParamInts parse the query param `name`, a comma separated list of ints.
+// An empty param returns an empty list.
+func QueryParamInts[T interface{ ~int32 | ~int64 | int }](c echo.Context, name string) ([]T, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	chunks := strings.Split(value, ",")
+	out := make([]T, len(chunks))
+	for i, chunk := range chunks {
+		v, err := strconv.ParseInt(strings.TrimSpace(chunk), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list parameter %s : %s", value, err)
+		}
+		out[i] = T(v)
+	}
+	return out, nil
+}
+
 func QueryParamBool(c echo.Context, name string) bool {
 	value := c.QueryParam(name)
 	return value != ""
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	tu "registro/utils/testutils"
 )
@@ -11,3 +12,12 @@ func TestRandColor(t *testing.T) {
 		tu.Assert(t, len(RandColor()) == 7)
 	}
 }
+
+func TestNextDailyRun(t *testing.T) {
+	now := time.Date(2025, 6, 12, 1, 30, 0, 0, time.UTC)
+	tu.Assert(t, nextDailyRun(now, 3).Equal(time.Date(2025, 6, 12, 3, 0, 0, 0, time.UTC)))
+	now = time.Date(2025, 6, 12, 3, 0, 0, 0, time.UTC)
+	tu.Assert(t, nextDailyRun(now, 3).Equal(time.Date(2025, 6, 13, 3, 0, 0, 0, time.UTC)))
+	now = time.Date(2025, 12, 31, 22, 0, 0, 0, time.UTC)
+	tu.Assert(t, nextDailyRun(now, 3).Equal(time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)))
+}